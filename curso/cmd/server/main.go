@@ -1,27 +1,34 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"net/http"
+	"log/slog"
 	"os"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
 	domain_event "github.com/ggialluisi/nebula-back/curso/internal/domain/event"
-	event_handler "github.com/ggialluisi/nebula-back/curso/internal/domain/event/handler"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/usecase"
 	"github.com/ggialluisi/nebula-back/curso/internal/infra/admin"
 	"github.com/ggialluisi/nebula-back/curso/internal/infra/api"
 	database "github.com/ggialluisi/nebula-back/curso/internal/infra/database/gorm"
 	msg_kafka "github.com/ggialluisi/nebula-back/curso/internal/infra/messaging/kafka"
 	"github.com/ggialluisi/nebula-back/curso/internal/infra/web"
-	events_pkg "github.com/ggialluisi/nebula-back/curso/pkg/event_dispatcher"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/health"
+	platform_kafka "github.com/ggialluisi/nebula-back/platform/kafka"
+	"github.com/ggialluisi/nebula-back/platform/lifecycle"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/metrics"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
+	"github.com/ggialluisi/nebula-back/platform/webserver"
 
-	"github.com/IBM/sarama"
 	"github.com/go-chi/jwtauth"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 )
 
 // @title           FDQF - Microserviço de Cursos
@@ -39,12 +46,15 @@ import (
 // @host      localhost:8083
 // @BasePath  /
 func main() {
+	// ✅ Logs estruturados em JSON (LOG_LEVEL), com request_id e dados pessoais mascarados
+	logging.Setup("curso")
+
 	// Vars
 	frontendURL := os.Getenv("FRONTEND_URL")
 	if frontendURL == "" {
 		frontendURL = "http://localhost:3000"
 	}
-	log.Println("✅ FRONTEND_URL:", frontendURL)
+	slog.Info("configuração carregada", "frontend_url", frontendURL)
 
 	db_sslmode := os.Getenv("DB_SSLMODE")
 	dbUser := os.Getenv("DB_CURSO_USER")
@@ -57,72 +67,187 @@ func main() {
 	if port == "" {
 		port = servicePort
 	}
-	kafkaBrokers := os.Getenv("KAFKA_BROKERS")
-	kafkaBrokersList := strings.Split(kafkaBrokers, ",")
+
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		dbHost, dbUser, dbPassword, dbName, dbPort, db_sslmode)
+
+	// ✅ Subcomando: curso-service migrate [up | down [n] | status]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(dsn, os.Args[2:]); err != nil {
+			logging.Fatal("erro nas migrações", logging.Err(err))
+		}
+		return
+	}
+	kafkaConfig := platform_kafka.ConfigFromEnv()
+
+	// ✅ Ciclo de vida: SIGTERM drena o HTTP, para os consumers e o relay e então
+	// fecha o dispatcher, o producer e o pool do banco (do último para o primeiro)
+	lc := lifecycle.New(lifecycle.ConfigFromEnv())
+
+	// ✅ Tracing (OpenTelemetry): exportador escolhido por OTEL_TRACES_EXPORTER e OTEL_EXPORTER_OTLP_*;
+	// fechado por último, depois que nada mais gera spans
+	shutdownTracing, err := tracing.Setup(context.Background(), "curso")
+	if err != nil {
+		logging.Fatal("erro ao configurar tracing", logging.Err(err))
+	}
+	lc.OnStop("tracing", shutdownTracing)
+
+	// ✅ Health: /healthz (processo de pé) e /readyz (banco, Kafka e consumers)
+	healthChecks := health.New("curso", 3*time.Second)
+	healthChecks.Add(health.Check{Name: "lifecycle", Probe: lc.Ready})
+
+	ethEventsTopic := ethEventsTopicFromEnv()
 
 	// ✅ Inicializa Kafka (create topics) - apenas se LOCAL...
-	if os.Getenv("ENV") == "LOCAL" {
-		if err := ensureKafkaTopics(kafkaBrokersList); err != nil {
-			log.Fatalf("Erro Kafka: %v", err)
+	if kafkaConfig.Local {
+		if err := platform_kafka.EnsureTopics(kafkaConfig, kafkaTopics(ethEventsTopic)); err != nil {
+			logging.Fatal("erro Kafka", logging.Err(err))
 		}
 	} else {
-		log.Println("🔑 Skip Kafka topic creation: production mode")
+		slog.Info("criação de tópicos Kafka ignorada: ambiente de produção")
 	}
 
 	// ✅ PostgreSQL
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
-		dbHost, dbUser, dbPassword, dbName, dbPort, db_sslmode)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		// erros e consultas lentas saem pelo slog, sem os valores das consultas
+		Logger: gormlogger.New(slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn), gormlogger.Config{
+			SlowThreshold:             500 * time.Millisecond,
+			LogLevel:                  gormlogger.Warn,
+			IgnoreRecordNotFoundError: true,
+			ParameterizedQueries:      true,
+		}),
+	})
 	if err != nil {
-		log.Fatalf("Erro DB: %v", err)
+		logging.Fatal("erro DB", logging.Err(err))
+	}
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		logging.Fatal("erro ao instrumentar o GORM", logging.Err(err))
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("erro DB", logging.Err(err))
 	}
 
-	if err := db.AutoMigrate(
-		&entity.User{},
-		&entity.Pessoa{},
-		&entity.Curso{},
-		&entity.Modulo{},
-		&entity.Aluno{},
-		&entity.AlunoCurso{},
-		&entity.ItemModulo{},
-		&entity.ItemModuloAula{},
-		&entity.ItemModuloContractValidation{},
-		&entity.ItemModuloVideo{},
-		&entity.AlunoCursoItemModulo{},
-	); err != nil {
-		log.Fatalf("Erro AutoMigrate: %v", err)
+	// ✅ Migrações versionadas (internal/infra/database/migrations), aplicadas sob advisory
+	// lock; com MIGRATE_ON_START=false ficam a cargo do subcomando migrate
+	if migrateOnStart() {
+		migrator, err := newMigrator(sqlDB)
+		if err != nil {
+			logging.Fatal("erro nas migrações", logging.Err(err))
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			logging.Fatal("erro nas migrações", logging.Err(err))
+		}
 	}
 
+	lc.OnStop("pool do banco", func(ctx context.Context) error {
+		return sqlDB.Close()
+	})
+	healthChecks.Add(health.Database("database", sqlDB))
+
 	cursoDB := database.NewCursoRepositoryGorm(db)
 	pessoaDB := database.NewPessoaRepositoryGorm(db)
 	userDB := database.NewUserRepositoryGorm(db)
 
+	// ✅ Admin inicial
+	seedAdminUser(userDB)
+
+	// ✅ Eventos + Producer
+	// handlers locais: logados, com tempo limite e protegidos contra panic
+	eventDispatcher := events_pkg.NewEventDispatcher(
+		events_pkg.WithMiddleware(
+			events_pkg.Logging(slog.Default()),
+			tracing.EventHandler(),
+			metrics.EventHandler(),
+			events_pkg.Timeout(10*time.Second),
+			events_pkg.Recover(),
+		),
+	)
+
+	kafkaClient, err := platform_kafka.NewClient(kafkaConfig)
+	if err != nil {
+		logging.Fatal("erro ao conectar ao Kafka", logging.Err(err))
+	}
+	lc.OnStop("client Kafka", func(ctx context.Context) error {
+		return kafkaClient.Close()
+	})
+	healthChecks.Add(platform_kafka.BrokerCheck(kafkaClient))
+
+	syncProducer, err := platform_kafka.NewSyncProducerFromClient(kafkaClient)
+	if err != nil {
+		logging.Fatal("erro ao criar producer Sarama", logging.Err(err))
+	}
+	lc.OnStop("producer Kafka", func(ctx context.Context) error {
+		return syncProducer.Close()
+	})
+	slog.Info("producer Kafka pronto", "brokers", kafkaConfig.Brokers)
+	producer := platform_kafka.NewProducer(syncProducer, "curso.saved")
+
+	// ✅ Outbox relay: publica no Kafka os eventos gravados junto com as alterações,
+	// cada tipo de evento no seu tópico
+	outboxRelay := msg_kafka.NewOutboxRelay(
+		database.NewOutboxRepositoryGorm(db),
+		map[string]msg_kafka.KafkaProducerInterface{
+			domain_event.CursoChangedName:      producer,
+			domain_event.ModuloChangedName:     producer.ForTopic("modulo.saved"),
+			domain_event.AlunoChangedName:      producer.ForTopic("aluno.saved"),
+			domain_event.AlunoCursoChangedName: producer.ForTopic("alunocurso.saved"),
+			domain_event.ItemModuloChangedName: producer.ForTopic("itemmodulo.saved"),
+			domain_event.CursoDeletedName:      producer.ForTopic("curso.deleted"),
+			domain_event.ModuloDeletedName:     producer.ForTopic("modulo.deleted"),
+			domain_event.AlunoDeletedName:      producer.ForTopic("aluno.deleted"),
+			domain_event.AlunoCursoDeletedName: producer.ForTopic("alunocurso.deleted"),
+			domain_event.ItemModuloDeletedName: producer.ForTopic("itemmodulo.deleted"),
+		},
+	)
+	lc.Go("outbox relay", func(ctx context.Context) error {
+		outboxRelay.Start(ctx)
+		return nil
+	})
+
 	// ✅ Sarama Consumer: define handlers
-	pessoaHandler := msg_kafka.NewPessoaKafkaHandlers(pessoaDB)
+	politicaExclusaoPessoa, err := entity.ParsePoliticaExclusaoPessoa(os.Getenv("PESSOA_DELETED_POLICY"))
+	if err != nil {
+		logging.Fatal("PESSOA_DELETED_POLICY inválida", logging.Err(err))
+	}
+	consumerCursoUseCase := usecase.NewSaveCursoUseCase(
+		cursoDB,
+		pessoaDB,
+		eventDispatcher,
+	)
+	pessoaHandler := msg_kafka.NewPessoaKafkaHandlers(pessoaDB, consumerCursoUseCase, politicaExclusaoPessoa)
+	ethEventHandler := msg_kafka.NewEthEventKafkaHandlers(consumerCursoUseCase)
 
-	consumers := []*KafkaConsumer{
+	// ✅ Mensagens que falham após as novas tentativas vão para <topic>.dlq
+	deadLetterDB := database.NewDeadLetterRepositoryGorm(db)
+	retryPolicy := retryPolicyFromEnv()
+	// ✅ Eventos já aplicados (header event_id) são descartados nas reentregas
+	processedMessageDB := database.NewProcessedMessageRepositoryGorm(db)
+
+	consumers := []platform_kafka.Consumer{
 		{
 			Topic:   "pessoa.saved",
 			GroupID: "curso-group",
-			Brokers: kafkaBrokersList,
-			Handler: &SimpleHandler{
-				handleFunc: func(msg *sarama.ConsumerMessage) error {
-					return pessoaHandler.CreateOrUpdatePessoa(msg)
-				},
-			},
+			Handler: msg_kafka.NewRetryingConsumerHandler(msg_kafka.NewIdempotentHandler(pessoaHandler.CreateOrUpdatePessoa, processedMessageDB), retryPolicy, producer, deadLetterDB),
+		},
+		{
+			Topic:   "pessoa.deleted",
+			GroupID: "curso-group",
+			Handler: msg_kafka.NewRetryingConsumerHandler(msg_kafka.NewIdempotentHandler(pessoaHandler.DeletePessoa, processedMessageDB), retryPolicy, producer, deadLetterDB),
+		},
+		{
+			Topic:   ethEventsTopic,
+			GroupID: "curso-group",
+			Handler: msg_kafka.NewRetryingConsumerHandler(msg_kafka.NewIdempotentHandler(ethEventHandler.ValidarContrato, processedMessageDB), retryPolicy, producer, deadLetterDB),
 		},
 	}
 
-	go startKafkaConsumers(consumers)
-
-	// ✅ Eventos + Producer
-	eventDispatcher := events_pkg.NewEventDispatcher()
-
-	producer := msg_kafka.NewKafkaProducer(kafkaBrokersList, "curso-saved", os.Getenv("KAFKA_KEY"), os.Getenv("KAFKA_SECRET"))
-
-	cursoEvent := domain_event.NewCursoChanged()
-	eventDispatcher.Register(cursoEvent.Name, &event_handler.CursoChangedKafkaHandler{
-		KafkaProducer: producer,
+	consumerStatus := platform_kafka.NewConsumerStatus()
+	healthChecks.Add(consumerStatus.Check())
+	lc.Go("consumers Kafka", func(ctx context.Context) error {
+		platform_kafka.RunConsumers(ctx, kafkaConfig, consumers, consumerStatus)
+		return nil
 	})
 
 	// ✅ JWT
@@ -136,14 +261,11 @@ func main() {
 	cursoApiHandlers := api.NewCursoHandlers(
 		eventDispatcher,
 		cursoDB,
-		cursoEvent,
-		domain_event.NewModuloChanged(),
-		domain_event.NewAlunoChanged(),
-		domain_event.NewAlunoCursoChanged(),
-		domain_event.NewItemModuloChanged(),
 		pessoaDB,
 	)
 	userApiHandlers := api.NewUserHandlers(userDB, tokenAuth, jwtExpiresIn)
+	deadLetterApiHandlers := api.NewDeadLetterHandlers(deadLetterDB, msg_kafka.NewDeadLetterReplayer(deadLetterDB, producer))
+	buscaApiHandlers := api.NewBuscaHandlers(database.NewBuscaRepositoryGorm(db))
 
 	// ✅ Admin
 	adminPanel := admin.InitializeAdmin(db)
@@ -156,9 +278,23 @@ func main() {
 		jwtExpiresIn,
 		cursoApiHandlers,
 		userApiHandlers,
+		deadLetterApiHandlers,
+		buscaApiHandlers,
 		adminPanel,
+		healthChecks,
 	)
 
-	log.Printf("🚀 Rodando na porta :%s", port)
-	http.ListenAndServe(":"+port, router)
+	// ✅ Dispatcher por último na ordem de registro: é o primeiro a ser fechado,
+	// depois que nenhuma requisição ou mensagem pode mais disparar eventos
+	lc.OnStop("event dispatcher", eventDispatcher.Close)
+
+	server := webserver.NewServer(":"+port, router)
+	lc.Go("servidor HTTP", func(ctx context.Context) error {
+		return webserver.Serve(ctx, server, lc.Config.DrainTimeout)
+	})
+
+	if err := lc.Run(); err != nil {
+		logging.Fatal("erro no encerramento", logging.Err(err))
+	}
+	slog.Info("serviço encerrado")
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/infra/database/migrations"
+	"github.com/ggialluisi/nebula-back/platform/migrate"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// migrateOnStart indica se o serviço aplica as migrações pendentes na subida
+// (MIGRATE_ON_START, padrão true). Com false, elas ficam a cargo do subcomando migrate.
+func migrateOnStart() bool {
+	v, err := strconv.ParseBool(os.Getenv("MIGRATE_ON_START"))
+	return err != nil || v
+}
+
+// newMigrator usa um advisory lock por serviço: com várias réplicas subindo juntas,
+// uma aplica as migrações e as demais esperam.
+func newMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	return migrate.New(db, "postgres", migrations.FS, migrate.PostgresLock("curso"))
+}
+
+// runMigrateCommand executa o subcomando: migrate [up | down [n] | status].
+func runMigrateCommand(dsn string, args []string) error {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	cmd := "up"
+	if len(args) > 0 {
+		cmd = args[0]
+	}
+	switch cmd {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migração(ões) aplicada(s)\n", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("número de migrações inválido: %s", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migração(ões) desfeita(s)\n", reverted)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSÃO\tNOME\tAPLICADA EM")
+		for _, s := range statuses {
+			appliedAt := "pendente"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("subcomando desconhecido: migrate %s (use up, down [n] ou status)", cmd)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+)
+
+// seedAdminUser cria o usuário admin inicial a partir de ADMIN_EMAIL/ADMIN_PASSWORD,
+// caso ainda não exista. Sem essas variáveis nenhum admin é criado.
+func seedAdminUser(userDB repository.UserRepositoryInterface) {
+	email := os.Getenv("ADMIN_EMAIL")
+	password := os.Getenv("ADMIN_PASSWORD")
+	if email == "" || password == "" {
+		slog.Info("usuário admin não criado: ADMIN_EMAIL/ADMIN_PASSWORD não definidos")
+		return
+	}
+
+	if _, err := userDB.FindByEmail(email); err == nil {
+		return
+	}
+
+	u, err := entity.NewUserWithRole("Admin", email, password, entity.RoleAdmin)
+	if err != nil {
+		logging.Fatal("erro ao criar usuário admin", logging.Err(err))
+	}
+	if err := userDB.Create(u); err != nil {
+		logging.Fatal("erro ao criar usuário admin", logging.Err(err))
+	}
+	slog.Info("usuário admin criado", "email", email)
+}
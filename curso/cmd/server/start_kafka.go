@@ -1,86 +1,24 @@
 package main
 
 import (
-	"context"
-	"log"
 	"os"
-	"strings"
-	"sync"
+	"strconv"
 	"time"
 
-	"github.com/IBM/sarama"
+	msg_kafka "github.com/ggialluisi/nebula-back/curso/internal/infra/messaging/kafka"
 )
 
-// Estrutura do consumidor usando Sarama
-type KafkaConsumer struct {
-	Topic   string
-	GroupID string
-	Brokers []string
-	Handler sarama.ConsumerGroupHandler
-}
-
-// Config global Sarama para reuso
-func newSaramaConfig() *sarama.Config {
-	config := sarama.NewConfig()
-	config.Version = sarama.V2_6_0_0
-
-	// SASL + TLS para Confluent Cloud
-	if os.Getenv("ENV") == "LOCAL" {
-		config.Net.SASL.Enable = false
-		config.Consumer.Return.Errors = false
-	} else {
-		config.Net.SASL.Enable = true
-		config.Net.SASL.User = os.Getenv("KAFKA_KEY")
-		config.Net.SASL.Password = os.Getenv("KAFKA_SECRET")
-		config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
-		config.Net.TLS.Enable = true
+// ethEventsTopicFromEnv retorna o tópico dos eventos do eth-listener (ETH_EVENTS_TOPIC)
+func ethEventsTopicFromEnv() string {
+	if topic := os.Getenv("ETH_EVENTS_TOPIC"); topic != "" {
+		return topic
 	}
-	config.Consumer.Return.Errors = true
-	return config
+	return "eth-transactions"
 }
 
-// StartConsumers inicia todos os ConsumerGroups
-func startKafkaConsumers(consumers []*KafkaConsumer) {
-	var wg sync.WaitGroup
-
-	for _, consumer := range consumers {
-		wg.Add(1)
-		go func(c *KafkaConsumer) {
-			defer wg.Done()
-
-			config := newSaramaConfig()
-			client, err := sarama.NewConsumerGroup(c.Brokers, c.GroupID, config)
-			if err != nil {
-				log.Fatalf("❌ Erro ao criar ConsumerGroup: %v", err)
-			}
-			defer client.Close()
-
-			ctx := context.Background()
-			for {
-				err := client.Consume(ctx, []string{c.Topic}, c.Handler)
-				if err != nil {
-					log.Printf("❌ Erro no ConsumerGroup %s: %v", c.GroupID, err)
-					time.Sleep(5 * time.Second)
-				}
-			}
-		}(consumer)
-	}
-
-	wg.Wait()
-}
-
-// Exemplo para criar tópicos usando Sarama
-func ensureKafkaTopics(brokers []string) error {
-	config := sarama.NewConfig()
-	config.Version = sarama.V2_5_0_0 // Compatível com Confluent Cloud
-
-	admin, err := sarama.NewClusterAdmin(brokers, config)
-	if err != nil {
-		return err
-	}
-	defer admin.Close()
-
-	topics := []string{
+// kafkaTopics retorna os tópicos usados pelo curso, criados no ambiente local.
+func kafkaTopics(ethEventsTopic string) []string {
+	return []string{
 		"curso.saved",
 		"curso.deleted",
 		"pessoa.saved",
@@ -89,53 +27,30 @@ func ensureKafkaTopics(brokers []string) error {
 		"aluno.deleted",
 		"modulo.saved",
 		"modulo.deleted",
+		"alunocurso.saved",
+		"alunocurso.deleted",
+		"itemmodulo.saved",
+		"itemmodulo.deleted",
+		ethEventsTopic,
+		// dead letters dos tópicos consumidos pelo curso
+		msg_kafka.DeadLetterTopic("pessoa.saved"),
+		msg_kafka.DeadLetterTopic("pessoa.deleted"),
+		msg_kafka.DeadLetterTopic(ethEventsTopic),
 	}
-
-	for _, topic := range topics {
-		err := admin.CreateTopic(topic, &sarama.TopicDetail{
-			NumPartitions:     1,
-			ReplicationFactor: 1,
-		}, false)
-		if err != nil {
-			// O Sarama retorna TopicError embutido no erro
-			if e, ok := err.(*sarama.TopicError); ok {
-				if e.Err == sarama.ErrTopicAlreadyExists {
-					log.Printf("🔍 Tópico %s já existe.", topic)
-					continue
-				}
-			}
-			return err
-		}
-		log.Printf("✅ Tópico %s criado com sucesso!", topic)
-	}
-
-	return nil
 }
 
-// Handler base para ConsumerGroup
-type SimpleHandler struct {
-	handleFunc func(msg *sarama.ConsumerMessage) error
-}
-
-func (h *SimpleHandler) Setup(_ sarama.ConsumerGroupSession) error   { return nil }
-func (h *SimpleHandler) Cleanup(_ sarama.ConsumerGroupSession) error { return nil }
-func (h *SimpleHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for msg := range claim.Messages() {
-		err := h.handleFunc(msg)
-		if err == nil {
-			sess.MarkMessage(msg, "")
-		}
+// retryPolicyFromEnv lê a política de novas tentativas dos consumidores:
+// KAFKA_CONSUMER_MAX_ATTEMPTS, KAFKA_CONSUMER_BACKOFF e KAFKA_CONSUMER_MAX_BACKOFF.
+func retryPolicyFromEnv() msg_kafka.RetryPolicy {
+	policy := msg_kafka.DefaultRetryPolicy()
+	if v, err := strconv.Atoi(os.Getenv("KAFKA_CONSUMER_MAX_ATTEMPTS")); err == nil && v > 0 {
+		policy.MaxAttempts = v
 	}
-	return nil
-}
-
-// Exemplo de inicialização do Kafka em main:
-func startKafka(brokers string) error {
-	broker_list := strings.Split(brokers, ",")
-	err := ensureKafkaTopics(broker_list)
-	if err != nil {
-		log.Fatalf("❌ Erro ao criar/verificar tópicos: %v", err)
-		return err
+	if v, err := time.ParseDuration(os.Getenv("KAFKA_CONSUMER_BACKOFF")); err == nil && v > 0 {
+		policy.InitialBackoff = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("KAFKA_CONSUMER_MAX_BACKOFF")); err == nil && v > 0 {
+		policy.MaxBackoff = v
 	}
-	return nil
+	return policy
 }
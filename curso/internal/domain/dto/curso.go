@@ -119,6 +119,8 @@ type AlunoCursoOutputDTO struct {
 	PercentualConcluido float32                `json:"percentual_concluido"`
 	StatusCurso         entity.StatusCurso     `json:"status_curso"`
 	StatusPagamento     entity.StatusPagamento `json:"status_pagamento"`
+	XpGanho             int64                  `json:"xp_ganho"`
+	XpDisponivel        int64                  `json:"xp_disponivel"`
 }
 
 // endregion
@@ -198,3 +200,54 @@ type AlunoCursoItemModuloUpdateDTO struct {
 }
 
 // endregion
+
+// region Eventos on-chain (eth-listener)
+
+// EthEventSchemaVersion é a versão do envelope do eth-listener suportada pelo consumidor
+// (eth-listener/schema/eth-event.v1.schema.json).
+const EthEventSchemaVersion = 1
+
+// EthEventInputDTO é o envelope publicado pelo eth-listener para cada evento de contrato decodificado.
+// Quando Revertido é true o evento foi removido da cadeia por uma reorganização e deve ser desfeito.
+type EthEventInputDTO struct {
+	SchemaVersion int                    `json:"schema_version"`
+	ChainID       uint64                 `json:"chain_id"`
+	Rede          string                 `json:"network"`
+	Contrato      string                 `json:"contract"`
+	Bloco         uint64                 `json:"block_number"`
+	BlockHash     string                 `json:"block_hash"`
+	Timestamp     time.Time              `json:"block_timestamp"`
+	TxHash        string                 `json:"tx_hash"`
+	LogIndex      uint                   `json:"log_index"`
+	Evento        string                 `json:"event"`
+	Dados         map[string]interface{} `json:"args"`
+	Revertido     bool                   `json:"removed"`
+	GasPrice      string                 `json:"gas_price_wei"`
+	TaxaTransacao string                 `json:"tx_fee_wei"`
+}
+
+// endregion
+
+// region Busca no catálogo
+
+// ResultadoBuscaOutputDTO é um item da resposta de GET /search. Trecho é HTML seguro:
+// o texto vem escapado e só os termos encontrados ficam entre <mark> e </mark>.
+type ResultadoBuscaOutputDTO struct {
+	Tipo     string     `json:"tipo"`
+	ID       uuid.UUID  `json:"id"`
+	CursoID  uuid.UUID  `json:"curso_id"`
+	ModuloID *uuid.UUID `json:"modulo_id,omitempty"`
+	Titulo   string     `json:"titulo"`
+	Trecho   string     `json:"trecho"`
+	Rank     float64    `json:"rank"`
+}
+
+// endregion
+
+// DeletedOutputDTO é o payload dos eventos *.deleted: o id do agregado excluído
+// e o último estado conhecido, no mesmo formato do evento *.saved correspondente.
+type DeletedOutputDTO struct {
+	ID        uuid.UUID   `json:"id"`
+	DeletedAt time.Time   `json:"deleted_at"`
+	Data      interface{} `json:"data"`
+}
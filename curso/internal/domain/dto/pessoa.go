@@ -1,13 +1,16 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type PessoaInputDTO struct {
-	ID   string `json:"id"`
-	Tipo string `json:"tipo"`
-	Nome string `json:"nome"`
+	ID        string    `json:"id"`
+	Tipo      string    `json:"tipo"`
+	Nome      string    `json:"nome"`
+	UpdatedAt time.Time `json:"updated_at"` // versão da pessoa no serviço de origem
 }
 
 type PessoaOutputDTO struct {
@@ -15,3 +18,9 @@ type PessoaOutputDTO struct {
 	Tipo string    `json:"tipo"`
 	Nome string    `json:"nome"`
 }
+
+// PessoaDeletedInputDTO é o payload do evento pessoa.deleted publicado pelo serviço pessoa
+type PessoaDeletedInputDTO struct {
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,103 @@
+package entity
+
+import "math"
+
+// XpPorMinuto é o XP concedido por minuto estimado de um item de módulo concluído.
+const XpPorMinuto int64 = 10
+
+// Xp retorna o XP concedido ao aluno que conclui o item.
+func (o *ItemModulo) Xp() int64 {
+	return int64(o.EstimativaTempoMin) * XpPorMinuto
+}
+
+// Concluido informa se o aluno concluiu o item.
+// Itens de validação de contrato só são concluídos após a validação on-chain.
+func (p *AlunoCursoItemModulo) Concluido() bool {
+	if p.ItemModulo.Tipo == ItemContractValidate {
+		return p.StatusValidacaoContrato == TipoStatusValidacaoContratoConcluido
+	}
+	return p.Status == TipoStatusItemModuloConcluido
+}
+
+// Iniciado informa se o aluno já começou o item.
+func (p *AlunoCursoItemModulo) Iniciado() bool {
+	return p.Concluido() || p.Progresso > 0 || (p.Status != "" && p.Status != TipoStatusItemModuloNaoIniciado)
+}
+
+// fracaoConcluida retorna a fração (0-1) do item já concluída.
+func (p *AlunoCursoItemModulo) fracaoConcluida() float64 {
+	if p.Concluido() {
+		return 1
+	}
+	if p.ItemModulo.Tipo == ItemContractValidate {
+		return 0
+	}
+	return math.Min(math.Max(float64(p.Progresso), 0), 100) / 100
+}
+
+// ReverterValidacaoContrato volta o item para validação pendente quando o evento on-chain
+// que o validou foi revertido por uma reorganização da rede. A transação de envio é mantida
+// para que o item seja validado novamente quando ela for reincluída.
+func (p *AlunoCursoItemModulo) ReverterValidacaoContrato() {
+	p.StatusValidacaoContrato = TipoStatusValidacaoContratoPendente
+	p.Status = TipoStatusItemModuloEmAndamento
+	p.Progresso = 0
+}
+
+// Reabrir volta uma matrícula aprovada para em andamento, para que RecalcularProgresso
+// reavalie o status após a reversão de um item concluído.
+func (p *AlunoCurso) Reabrir() {
+	if p.StatusCurso == StatusAprovado {
+		p.StatusCurso = StatusEmAndamento
+	}
+}
+
+// RecalcularProgresso recalcula percentual, XP e status da matrícula a partir dos itens.
+// O percentual é ponderado pela EstimativaTempoMin de cada item e o XP só é concedido
+// para itens concluídos. O status só avança (nao_iniciado -> em_andamento -> aprovado)
+// e matrículas canceladas não são alteradas.
+// Retorna a variação de XP ganho, para ser somada ao XpTotal do aluno.
+func (p *AlunoCurso) RecalcularProgresso(itens []AlunoCursoItemModulo) int64 {
+	if p.StatusCurso == StatusCancelado {
+		return 0
+	}
+
+	var pesoTotal, pesoConcluido float64
+	var xpGanho, xpTotal int64
+	iniciado := false
+	concluidos := 0
+	for i := range itens {
+		item := &itens[i]
+		peso := float64(item.ItemModulo.EstimativaTempoMin)
+		pesoTotal += peso
+		pesoConcluido += peso * item.fracaoConcluida()
+
+		xpTotal += item.ItemModulo.Xp()
+		if item.Concluido() {
+			xpGanho += item.ItemModulo.Xp()
+			concluidos++
+		}
+		if item.Iniciado() {
+			iniciado = true
+		}
+	}
+
+	percentual := float32(0)
+	if pesoTotal > 0 {
+		percentual = float32(math.Round(pesoConcluido/pesoTotal*10000) / 100)
+	}
+
+	delta := xpGanho - p.XpGanho
+	p.PercentualConcluido = percentual
+	p.XpGanho = xpGanho
+	p.XpDisponivel = xpTotal - xpGanho
+
+	switch {
+	case len(itens) > 0 && concluidos == len(itens):
+		p.StatusCurso = StatusAprovado
+	case iniciado && p.StatusCurso == StatusNaoIniciado:
+		p.StatusCurso = StatusEmAndamento
+	}
+
+	return delta
+}
@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Testes do motor de progresso da matrícula (AlunoCurso)
+
+func newItemProgresso(tipo TipoItem, minutos int, status TipoStatusItemModulo, progresso float32) AlunoCursoItemModulo {
+	return AlunoCursoItemModulo{
+		ID:         uuid.New(),
+		ItemModulo: ItemModulo{ID: uuid.New(), Tipo: tipo, EstimativaTempoMin: minutos},
+		Status:     status,
+		Progresso:  progresso,
+	}
+}
+
+func TestRecalcularProgresso_NaoIniciado(t *testing.T) {
+	obj := AlunoCurso{StatusCurso: StatusNaoIniciado}
+	itens := []AlunoCursoItemModulo{
+		newItemProgresso(ItemAula, 10, TipoStatusItemModuloNaoIniciado, 0),
+		newItemProgresso(ItemAula, 30, TipoStatusItemModuloNaoIniciado, 0),
+	}
+
+	delta := obj.RecalcularProgresso(itens)
+	assert.Equal(t, int64(0), delta)
+	assert.Equal(t, float32(0), obj.PercentualConcluido)
+	assert.Equal(t, int64(0), obj.XpGanho)
+	assert.Equal(t, int64(40)*XpPorMinuto, obj.XpDisponivel)
+	assert.Equal(t, StatusNaoIniciado, obj.StatusCurso)
+}
+
+func TestRecalcularProgresso_PonderadoPelaEstimativa(t *testing.T) {
+	obj := AlunoCurso{StatusCurso: StatusNaoIniciado}
+	itens := []AlunoCursoItemModulo{
+		newItemProgresso(ItemAula, 10, TipoStatusItemModuloConcluido, 100),
+		newItemProgresso(ItemAula, 30, TipoStatusItemModuloEmAndamento, 50),
+	}
+
+	delta := obj.RecalcularProgresso(itens)
+	// (10*1 + 30*0.5) / 40 = 62.5%
+	assert.Equal(t, float32(62.5), obj.PercentualConcluido)
+	assert.Equal(t, int64(10)*XpPorMinuto, delta)
+	assert.Equal(t, int64(10)*XpPorMinuto, obj.XpGanho)
+	assert.Equal(t, int64(30)*XpPorMinuto, obj.XpDisponivel)
+	assert.Equal(t, StatusEmAndamento, obj.StatusCurso)
+}
+
+func TestRecalcularProgresso_ValidacaoContratoSoContaQuandoValidada(t *testing.T) {
+	obj := AlunoCurso{StatusCurso: StatusEmAndamento}
+	contrato := newItemProgresso(ItemContractValidate, 20, TipoStatusItemModuloConcluido, 100)
+	contrato.StatusValidacaoContrato = TipoStatusValidacaoContratoPendente
+	itens := []AlunoCursoItemModulo{
+		newItemProgresso(ItemAula, 20, TipoStatusItemModuloConcluido, 100),
+		contrato,
+	}
+
+	obj.RecalcularProgresso(itens)
+	assert.Equal(t, float32(50), obj.PercentualConcluido)
+	assert.Equal(t, StatusEmAndamento, obj.StatusCurso)
+
+	itens[1].StatusValidacaoContrato = TipoStatusValidacaoContratoConcluido
+	delta := obj.RecalcularProgresso(itens)
+	assert.Equal(t, float32(100), obj.PercentualConcluido)
+	assert.Equal(t, int64(20)*XpPorMinuto, delta)
+	assert.Equal(t, int64(0), obj.XpDisponivel)
+	assert.Equal(t, StatusAprovado, obj.StatusCurso)
+}
+
+func TestRecalcularProgresso_StatusNaoRegride(t *testing.T) {
+	obj := AlunoCurso{StatusCurso: StatusAprovado, XpGanho: 100}
+	itens := []AlunoCursoItemModulo{
+		newItemProgresso(ItemAula, 10, TipoStatusItemModuloEmAndamento, 50),
+	}
+
+	delta := obj.RecalcularProgresso(itens)
+	assert.Equal(t, StatusAprovado, obj.StatusCurso)
+	assert.Equal(t, int64(-100), delta)
+}
+
+func TestRecalcularProgresso_CanceladoNaoAltera(t *testing.T) {
+	obj := AlunoCurso{StatusCurso: StatusCancelado}
+	itens := []AlunoCursoItemModulo{
+		newItemProgresso(ItemAula, 10, TipoStatusItemModuloConcluido, 100),
+	}
+
+	delta := obj.RecalcularProgresso(itens)
+	assert.Equal(t, int64(0), delta)
+	assert.Equal(t, float32(0), obj.PercentualConcluido)
+	assert.Equal(t, StatusCancelado, obj.StatusCurso)
+}
+
+func TestRecalcularProgresso_ReversaoValidacaoContrato(t *testing.T) {
+	obj := AlunoCurso{StatusCurso: StatusNaoIniciado}
+	contrato := newItemProgresso(ItemContractValidate, 10, TipoStatusItemModuloConcluido, 100)
+	contrato.StatusValidacaoContrato = TipoStatusValidacaoContratoConcluido
+	itens := []AlunoCursoItemModulo{contrato}
+
+	obj.RecalcularProgresso(itens)
+	assert.Equal(t, StatusAprovado, obj.StatusCurso)
+
+	itens[0].ReverterValidacaoContrato()
+	assert.Equal(t, TipoStatusValidacaoContratoPendente, itens[0].StatusValidacaoContrato)
+	assert.Equal(t, TipoStatusItemModuloEmAndamento, itens[0].Status)
+
+	obj.Reabrir()
+	delta := obj.RecalcularProgresso(itens)
+	assert.Equal(t, int64(-10)*XpPorMinuto, delta)
+	assert.Equal(t, float32(0), obj.PercentualConcluido)
+	assert.Equal(t, StatusEmAndamento, obj.StatusCurso)
+}
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// TipoResultadoBusca identifica o que foi encontrado na busca do catálogo.
+type TipoResultadoBusca string
+
+const (
+	ResultadoCurso      TipoResultadoBusca = "curso"
+	ResultadoModulo     TipoResultadoBusca = "modulo"
+	ResultadoItemModulo TipoResultadoBusca = "item_modulo"
+)
+
+// TiposResultadoBusca são todos os tipos pesquisados quando a busca não restringe.
+var TiposResultadoBusca = []TipoResultadoBusca{ResultadoCurso, ResultadoModulo, ResultadoItemModulo}
+
+const (
+	buscaTextoMin = 2
+	buscaTextoMax = 200
+)
+
+var (
+	ErrBuscaTextoInvalido = errors.New("texto da busca deve ter entre 2 e 200 caracteres")
+	ErrBuscaTipoInvalido  = errors.New("tipo de busca inválido (use curso, modulo ou item_modulo)")
+)
+
+// BuscaCatalogo é uma busca textual em cursos, módulos e itens de módulo (incluindo o
+// texto das aulas). Texto aceita a sintaxe de busca web: "frase exata", -excluir, or.
+type BuscaCatalogo struct {
+	Texto string
+	Tipos []TipoResultadoBusca
+}
+
+func NewBuscaCatalogo(texto string, tipos []string) (*BuscaCatalogo, error) {
+	busca := &BuscaCatalogo{Texto: strings.TrimSpace(texto)}
+	for _, tipo := range tipos {
+		if tipo = strings.TrimSpace(tipo); tipo != "" {
+			busca.Tipos = append(busca.Tipos, TipoResultadoBusca(tipo))
+		}
+	}
+	if len(busca.Tipos) == 0 {
+		busca.Tipos = TiposResultadoBusca
+	}
+	if err := busca.IsValid(); err != nil {
+		return nil, err
+	}
+	return busca, nil
+}
+
+func (b *BuscaCatalogo) IsValid() error {
+	if n := utf8.RuneCountInString(b.Texto); n < buscaTextoMin || n > buscaTextoMax {
+		return ErrBuscaTextoInvalido
+	}
+	for _, tipo := range b.Tipos {
+		if tipo != ResultadoCurso && tipo != ResultadoModulo && tipo != ResultadoItemModulo {
+			return ErrBuscaTipoInvalido
+		}
+	}
+	return nil
+}
+
+// ResultadoBusca é um registro encontrado, com a relevância calculada pelo banco e um
+// trecho do texto com os termos encontrados entre <mark> e </mark>.
+type ResultadoBusca struct {
+	Tipo     TipoResultadoBusca
+	ID       uuid.UUID
+	CursoID  uuid.UUID
+	ModuloID *uuid.UUID
+	Titulo   string
+	Trecho   string
+	Rank     float64
+}
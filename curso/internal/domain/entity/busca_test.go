@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBuscaCatalogo_TodosOsTiposPorPadrao(t *testing.T) {
+	busca, err := NewBuscaCatalogo("  ethereum developer  ", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "ethereum developer", busca.Texto)
+	assert.Equal(t, TiposResultadoBusca, busca.Tipos)
+}
+
+func TestNewBuscaCatalogo_RestringeTipos(t *testing.T) {
+	busca, err := NewBuscaCatalogo("solidity", []string{"curso", " item_modulo", ""})
+	assert.NoError(t, err)
+	assert.Equal(t, []TipoResultadoBusca{ResultadoCurso, ResultadoItemModulo}, busca.Tipos)
+}
+
+func TestNewBuscaCatalogo_Invalida(t *testing.T) {
+	_, err := NewBuscaCatalogo(" a ", nil)
+	assert.ErrorIs(t, err, ErrBuscaTextoInvalido)
+
+	_, err = NewBuscaCatalogo(strings.Repeat("á", 201), nil)
+	assert.ErrorIs(t, err, ErrBuscaTextoInvalido)
+
+	_, err = NewBuscaCatalogo("solidity", []string{"aluno"})
+	assert.ErrorIs(t, err, ErrBuscaTipoInvalido)
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatusDeadLetter string
+
+const (
+	DeadLetterPendente     StatusDeadLetter = "pendente"
+	DeadLetterReprocessada StatusDeadLetter = "reprocessada"
+)
+
+var ErrDeadLetterReprocessada = errors.New("dead letter already replayed")
+
+// DeadLetter é uma mensagem que o consumidor não conseguiu processar depois de todas as tentativas.
+// A mensagem também é publicada em <topic>.dlq; o registro local permite listar e reprocessar.
+type DeadLetter struct {
+	ID         uuid.UUID        `gorm:"type:uuid;primary_key" json:"id"`
+	CreatedAt  time.Time        `json:"created_at" gorm:"autoCreateTime;index"`
+	Topic      string           `gorm:"type:varchar(200);index;not null" json:"topic"`
+	Partition  int32            `json:"partition"`
+	Offset     int64            `json:"offset"`
+	Key        string           `gorm:"type:text" json:"key"`
+	Value      string           `gorm:"type:text" json:"value"`
+	Headers    string           `gorm:"type:text" json:"headers"` // JSON com os headers originais
+	Error      string           `gorm:"type:text" json:"error"`
+	Attempts   int              `json:"attempts"`
+	Status     StatusDeadLetter `gorm:"type:varchar(20);index;default:'pendente'" json:"status"`
+	ReplayedAt *time.Time       `json:"replayed_at"`
+}
+
+func NewDeadLetter(topic string, partition int32, offset int64, key, value string, headers map[string]string, errMsg string, attempts int) (*DeadLetter, error) {
+	if topic == "" {
+		return nil, errors.New("invalid topic")
+	}
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	jsonHeaders, err := json.Marshal(headers)
+	if err != nil {
+		return nil, err
+	}
+	return &DeadLetter{
+		ID:        uuid.New(),
+		Topic:     topic,
+		Partition: partition,
+		Offset:    offset,
+		Key:       key,
+		Value:     value,
+		Headers:   string(jsonHeaders),
+		Error:     errMsg,
+		Attempts:  attempts,
+		Status:    DeadLetterPendente,
+	}, nil
+}
+
+// HeadersMap devolve os headers originais da mensagem.
+func (d *DeadLetter) HeadersMap() (map[string]string, error) {
+	headers := map[string]string{}
+	if d.Headers == "" {
+		return headers, nil
+	}
+	err := json.Unmarshal([]byte(d.Headers), &headers)
+	return headers, err
+}
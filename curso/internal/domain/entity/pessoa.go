@@ -13,6 +13,13 @@ type Pessoa struct {
 	ID        uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
 	Tipo      TipoPessoa `gorm:"type:varchar(20)" json:"tipo"`
 	Nome      string     `gorm:"type:varchar(100)" json:"nome"`
+
+	// UpdatedAt da pessoa no serviço pessoa, usado para descartar eventos fora de ordem
+	AtualizadoNaOrigemEm time.Time `json:"atualizado_na_origem_em"`
+
+	// preenchidos quando a pessoa é excluída no serviço pessoa (evento pessoa.deleted)
+	ExclusaoSolicitadaEm *time.Time `json:"exclusao_solicitada_em,omitempty"`
+	Anonimizada          bool       `json:"anonimizada"`
 }
 
 type TipoPessoa string
@@ -22,6 +29,8 @@ const (
 	PessoaJuridica TipoPessoa = "JURIDICA"
 )
 
+var ErrPessoaVersaoDesatualizada = errors.New("stale pessoa version")
+
 func NewPessoa(itemID *uuid.UUID, tipo TipoPessoa, nome string) (*Pessoa, error) {
 	pessoa := &Pessoa{
 		ID:   *itemID,
@@ -50,3 +59,18 @@ func (p *Pessoa) IsValid() error {
 	}
 	return nil
 }
+
+// VersaoDesatualizada informa se um evento com a versão atualizadoEm é mais antigo que a réplica
+// (ou que a exclusão já registrada). Eventos sem versão são sempre aplicados.
+func (p *Pessoa) VersaoDesatualizada(atualizadoEm time.Time) bool {
+	if atualizadoEm.IsZero() {
+		return false
+	}
+	if !p.AtualizadoNaOrigemEm.IsZero() && !atualizadoEm.After(p.AtualizadoNaOrigemEm) {
+		return true
+	}
+	if p.ExclusaoSolicitadaEm != nil && !atualizadoEm.After(*p.ExclusaoSolicitadaEm) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// PoliticaExclusaoPessoa define como o curso trata a réplica local de uma pessoa excluída no serviço pessoa.
+type PoliticaExclusaoPessoa string
+
+const (
+	// PoliticaExclusaoDesativar inativa os alunos da pessoa e mantém os dados.
+	PoliticaExclusaoDesativar PoliticaExclusaoPessoa = "desativar"
+	// PoliticaExclusaoAnonimizar inativa os alunos e remove nome e carteira.
+	PoliticaExclusaoAnonimizar PoliticaExclusaoPessoa = "anonimizar"
+	// PoliticaExclusaoBloquear mantém a réplica intacta enquanto houver matrículas ativas;
+	// sem matrículas ativas, desativa. A exclusão bloqueada fica registrada na pessoa e o
+	// aluno é desativado quando a última matrícula ativa é concluída ou excluída.
+	PoliticaExclusaoBloquear PoliticaExclusaoPessoa = "bloquear"
+)
+
+// ResultadoExclusaoPessoa informa o que foi feito com a réplica local.
+type ResultadoExclusaoPessoa string
+
+const (
+	ResultadoExclusaoIgnorada    ResultadoExclusaoPessoa = "ignorada" // pessoa não replicada no curso
+	ResultadoExclusaoDesativada  ResultadoExclusaoPessoa = "desativada"
+	ResultadoExclusaoAnonimizada ResultadoExclusaoPessoa = "anonimizada"
+	ResultadoExclusaoBloqueada   ResultadoExclusaoPessoa = "bloqueada"
+)
+
+// NomePessoaAnonimizada substitui o nome de pessoas anonimizadas.
+const NomePessoaAnonimizada = "Pessoa removida"
+
+// ParsePoliticaExclusaoPessoa converte o valor configurado; vazio usa PoliticaExclusaoDesativar.
+func ParsePoliticaExclusaoPessoa(valor string) (PoliticaExclusaoPessoa, error) {
+	switch p := PoliticaExclusaoPessoa(valor); p {
+	case "":
+		return PoliticaExclusaoDesativar, nil
+	case PoliticaExclusaoDesativar, PoliticaExclusaoAnonimizar, PoliticaExclusaoBloquear:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid politica de exclusao de pessoa: %s", valor)
+	}
+}
+
+// MarcarExclusao registra quando a pessoa foi excluída no serviço de origem.
+func (p *Pessoa) MarcarExclusao(em time.Time) {
+	p.ExclusaoSolicitadaEm = &em
+}
+
+// Anonimizar remove os dados pessoais da réplica.
+func (p *Pessoa) Anonimizar() {
+	p.Nome = NomePessoaAnonimizada
+	p.Anonimizada = true
+}
+
+// Desativar inativa o aluno.
+func (a *Aluno) Desativar() {
+	a.StatusAluno = StatusAlunoInativo
+}
+
+// Anonimizar remove a carteira vinculada ao aluno.
+func (a *Aluno) Anonimizar() {
+	a.Wallet = ""
+}
+
+// Ativa informa se a matrícula ainda está em curso.
+func (p *AlunoCurso) Ativa() bool {
+	return p.StatusCurso == StatusNaoIniciado || p.StatusCurso == StatusEmAndamento
+}
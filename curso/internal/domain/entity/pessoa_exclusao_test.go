@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePoliticaExclusaoPessoa(t *testing.T) {
+	politica, err := ParsePoliticaExclusaoPessoa("")
+	assert.NoError(t, err)
+	assert.Equal(t, PoliticaExclusaoDesativar, politica)
+
+	politica, err = ParsePoliticaExclusaoPessoa("anonimizar")
+	assert.NoError(t, err)
+	assert.Equal(t, PoliticaExclusaoAnonimizar, politica)
+
+	_, err = ParsePoliticaExclusaoPessoa("apagar")
+	assert.Error(t, err)
+}
+
+func TestExclusaoPessoa_AnonimizaPessoaEAluno(t *testing.T) {
+	pessoa := &Pessoa{Nome: "Maria"}
+	em := time.Now()
+	pessoa.MarcarExclusao(em)
+	pessoa.Anonimizar()
+	assert.Equal(t, NomePessoaAnonimizada, pessoa.Nome)
+	assert.True(t, pessoa.Anonimizada)
+	assert.Equal(t, em, *pessoa.ExclusaoSolicitadaEm)
+
+	aluno := &Aluno{StatusAluno: StatusAlunoAtivo, Wallet: "0xabc"}
+	aluno.Desativar()
+	aluno.Anonimizar()
+	assert.Equal(t, StatusAlunoInativo, aluno.StatusAluno)
+	assert.Empty(t, aluno.Wallet)
+
+	assert.True(t, (&AlunoCurso{StatusCurso: StatusEmAndamento}).Ativa())
+	assert.False(t, (&AlunoCurso{StatusCurso: StatusAprovado}).Ativa())
+}
+
+func TestPessoa_VersaoDesatualizada(t *testing.T) {
+	agora := time.Now()
+	pessoa := &Pessoa{Nome: "Maria", AtualizadoNaOrigemEm: agora}
+
+	assert.False(t, pessoa.VersaoDesatualizada(time.Time{}))
+	assert.False(t, pessoa.VersaoDesatualizada(agora.Add(time.Second)))
+	assert.True(t, pessoa.VersaoDesatualizada(agora))
+	assert.True(t, pessoa.VersaoDesatualizada(agora.Add(-time.Second)))
+
+	pessoa.MarcarExclusao(agora.Add(time.Minute))
+	assert.True(t, pessoa.VersaoDesatualizada(agora.Add(time.Second)))
+}
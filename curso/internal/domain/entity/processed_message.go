@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// ProcessedMessage registra um evento já aplicado pelo consumidor, identificado pelo
+// header event_id, para que entregas repetidas e replays sejam descartados.
+type ProcessedMessage struct {
+	Topic       string    `gorm:"type:varchar(200);primaryKey" json:"topic"`
+	EventID     string    `gorm:"type:varchar(100);primaryKey" json:"event_id"`
+	ProcessedAt time.Time `gorm:"autoCreateTime" json:"processed_at"`
+}
@@ -23,3 +23,21 @@ func TestUser_ValidatePassword(t *testing.T) {
 	assert.False(t, user.ValidatePassword("1234567"))
 	assert.NotEqual(t, "123456", user.Password)
 }
+
+func TestNewUser_DefaultRoleAluno(t *testing.T) {
+	user, err := NewUser("John Doe", "j@j.com", "123456")
+	assert.Nil(t, err)
+	assert.Equal(t, RoleAluno, user.Role)
+	assert.Nil(t, user.AlunoID)
+}
+
+func TestNewUserWithRole(t *testing.T) {
+	user, err := NewUserWithRole("John Doe", "j@j.com", "123456", RoleAdmin)
+	assert.Nil(t, err)
+	assert.Equal(t, RoleAdmin, user.Role)
+
+	user, err = NewUserWithRole("John Doe", "j@j.com", "123456", Role("root"))
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Equal(t, "invalid role: root", err.Error())
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ValidacaoContratoEvento registra, para auditoria, o evento on-chain usado
+// para validar (ou rejeitar) um AlunoCursoItemModulo de validação de contrato.
+type ValidacaoContratoEvento struct {
+	ID                     uuid.UUID                   `gorm:"type:uuid;primaryKey" json:"id"`
+	CreatedAt              time.Time                   `json:"created_at"`
+	AlunoCursoItemModuloID uuid.UUID                   `gorm:"type:uuid;index" json:"aluno_curso_item_modulo_id"`
+	TxHash                 string                      `gorm:"type:varchar(100);index" json:"tx_hash"`
+	Contrato               string                      `gorm:"type:varchar(100)" json:"contrato"`
+	Evento                 string                      `gorm:"type:varchar(100)" json:"evento"`
+	Bloco                  uint64                      `json:"bloco"`
+	Resultado              TipoStatusValidacaoContrato `gorm:"type:varchar(50)" json:"resultado"`
+	Payload                string                      `gorm:"type:text" json:"payload"`
+}
+
+func NewValidacaoContratoEvento(itemID uuid.UUID, txHash, contrato, evento string, bloco uint64, resultado TipoStatusValidacaoContrato, payload string) *ValidacaoContratoEvento {
+	return &ValidacaoContratoEvento{
+		ID:                     uuid.New(),
+		AlunoCursoItemModuloID: itemID,
+		TxHash:                 txHash,
+		Contrato:               contrato,
+		Evento:                 evento,
+		Bloco:                  bloco,
+		Resultado:              resultado,
+		Payload:                payload,
+	}
+}
+
+var enderecoRegex = regexp.MustCompile(`^0x(0{24})?[0-9a-fA-F]{40}$`)
+
+// NormalizarEndereco converte um endereço (ou um tópico indexado de 32 bytes)
+// para o formato 0x + 40 hex em minúsculas. Retorna false se não for um endereço.
+func NormalizarEndereco(valor string) (string, bool) {
+	if !enderecoRegex.MatchString(valor) {
+		return "", false
+	}
+	return "0x" + strings.ToLower(valor[len(valor)-40:]), true
+}
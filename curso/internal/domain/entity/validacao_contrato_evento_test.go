@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizarEndereco(t *testing.T) {
+	endereco, ok := NormalizarEndereco("0xD47B84cD828538eE33264911E117e3557af39231")
+	assert.True(t, ok)
+	assert.Equal(t, "0xd47b84cd828538ee33264911e117e3557af39231", endereco)
+
+	// tópico indexado de 32 bytes
+	endereco, ok = NormalizarEndereco("0x000000000000000000000000d47b84cd828538ee33264911e117e3557af39231")
+	assert.True(t, ok)
+	assert.Equal(t, "0xd47b84cd828538ee33264911e117e3557af39231", endereco)
+
+	_, ok = NormalizarEndereco("0x1234")
+	assert.False(t, ok)
+	_, ok = NormalizarEndereco("texto qualquer")
+	assert.False(t, ok)
+}
@@ -1,37 +1,21 @@
 package event
 
 import (
-	"time"
+	"context"
 
-	events_pkg "github.com/ggialluisi/nebula-back/curso/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
 )
 
-type AlunoChanged struct {
-	Name    string
-	Payload interface{}
-}
+const AlunoChangedName = "AlunoChanged"
 
-func NewAlunoChanged() *AlunoChanged {
-	return &AlunoChanged{
-		Name: "AlunoChanged",
-	}
-}
-
-func (e *AlunoChanged) GetName() string {
-	return e.Name
-}
-
-func (e *AlunoChanged) GetPayload() interface{} {
-	return e.Payload
-}
-
-func (e *AlunoChanged) SetPayload(payload interface{}) {
-	e.Payload = payload
+type AlunoChanged struct {
+	Event
 }
 
-func (e *AlunoChanged) GetDateTime() time.Time {
-	return time.Now()
+func NewAlunoChanged(ctx context.Context, payload dto.AlunoOutputDTO) AlunoChanged {
+	return AlunoChanged{Event: newEvent(ctx, AlunoChangedName, payload.ID.String(), payload)}
 }
 
 // verifica se implementa a interface
-var _ events_pkg.EventInterface = (*AlunoChanged)(nil)
+var _ events_pkg.EventInterface = AlunoChanged{}
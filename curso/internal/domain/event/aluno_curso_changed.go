@@ -1,37 +1,21 @@
 package event
 
 import (
-	"time"
+	"context"
 
-	events_pkg "github.com/ggialluisi/nebula-back/curso/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
 )
 
-type AlunoCursoChanged struct {
-	Name    string
-	Payload interface{}
-}
+const AlunoCursoChangedName = "AlunoCursoChanged"
 
-func NewAlunoCursoChanged() *AlunoChanged {
-	return &AlunoChanged{
-		Name: "AlunoCursoChanged",
-	}
-}
-
-func (e *AlunoCursoChanged) GetName() string {
-	return e.Name
-}
-
-func (e *AlunoCursoChanged) GetPayload() interface{} {
-	return e.Payload
-}
-
-func (e *AlunoCursoChanged) SetPayload(payload interface{}) {
-	e.Payload = payload
+type AlunoCursoChanged struct {
+	Event
 }
 
-func (e *AlunoCursoChanged) GetDateTime() time.Time {
-	return time.Now()
+func NewAlunoCursoChanged(ctx context.Context, payload dto.AlunoCursoOutputDTO) AlunoCursoChanged {
+	return AlunoCursoChanged{Event: newEvent(ctx, AlunoCursoChangedName, payload.ID.String(), payload)}
 }
 
 // verifica se implementa a interface
-var _ events_pkg.EventInterface = (*AlunoChanged)(nil)
+var _ events_pkg.EventInterface = AlunoCursoChanged{}
@@ -0,0 +1,21 @@
+package event
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+)
+
+const AlunoCursoDeletedName = "AlunoCursoDeleted"
+
+type AlunoCursoDeleted struct {
+	Event
+}
+
+func NewAlunoCursoDeleted(ctx context.Context, payload dto.DeletedOutputDTO) AlunoCursoDeleted {
+	return AlunoCursoDeleted{Event: newEvent(ctx, AlunoCursoDeletedName, payload.ID.String(), payload)}
+}
+
+// verifica se implementa a interface
+var _ events_pkg.EventInterface = AlunoCursoDeleted{}
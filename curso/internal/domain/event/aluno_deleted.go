@@ -0,0 +1,21 @@
+package event
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+)
+
+const AlunoDeletedName = "AlunoDeleted"
+
+type AlunoDeleted struct {
+	Event
+}
+
+func NewAlunoDeleted(ctx context.Context, payload dto.DeletedOutputDTO) AlunoDeleted {
+	return AlunoDeleted{Event: newEvent(ctx, AlunoDeletedName, payload.ID.String(), payload)}
+}
+
+// verifica se implementa a interface
+var _ events_pkg.EventInterface = AlunoDeleted{}
@@ -1,37 +1,21 @@
 package event
 
 import (
-	"time"
+	"context"
 
-	events_pkg "github.com/ggialluisi/nebula-back/curso/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
 )
 
-type CursoChanged struct {
-	Name    string
-	Payload interface{}
-}
+const CursoChangedName = "CursoChanged"
 
-func NewCursoChanged() *CursoChanged {
-	return &CursoChanged{
-		Name: "CursoChanged",
-	}
-}
-
-func (e *CursoChanged) GetName() string {
-	return e.Name
-}
-
-func (e *CursoChanged) GetPayload() interface{} {
-	return e.Payload
-}
-
-func (e *CursoChanged) SetPayload(payload interface{}) {
-	e.Payload = payload
+type CursoChanged struct {
+	Event
 }
 
-func (e *CursoChanged) GetDateTime() time.Time {
-	return time.Now()
+func NewCursoChanged(ctx context.Context, payload dto.CursoOutputDTO) CursoChanged {
+	return CursoChanged{Event: newEvent(ctx, CursoChangedName, payload.ID.String(), payload)}
 }
 
 // verifica se implementa a interface
-var _ events_pkg.EventInterface = (*CursoChanged)(nil)
+var _ events_pkg.EventInterface = CursoChanged{}
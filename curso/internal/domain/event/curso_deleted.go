@@ -0,0 +1,21 @@
+package event
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+)
+
+const CursoDeletedName = "CursoDeleted"
+
+type CursoDeleted struct {
+	Event
+}
+
+func NewCursoDeleted(ctx context.Context, payload dto.DeletedOutputDTO) CursoDeleted {
+	return CursoDeleted{Event: newEvent(ctx, CursoDeletedName, payload.ID.String(), payload)}
+}
+
+// verifica se implementa a interface
+var _ events_pkg.EventInterface = CursoDeleted{}
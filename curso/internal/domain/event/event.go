@@ -0,0 +1,73 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/google/uuid"
+)
+
+// Event reúne os dados comuns a todos os eventos de domínio. Os campos são
+// preenchidos na criação e não mudam depois: cada ocorrência gera um valor novo,
+// então requisições concorrentes nunca compartilham o mesmo evento.
+type Event struct {
+	id            string
+	name          string
+	occurredAt    time.Time
+	aggregateID   string
+	correlationID string
+	requestID     string
+	payload       interface{}
+}
+
+// newEvent cria a ocorrência de um evento. Os ids de correlação e da requisição vêm
+// do contexto; sem id de correlação, o próprio evento inicia a cadeia e usa o seu id.
+func newEvent(ctx context.Context, name string, aggregateID string, payload interface{}) Event {
+	id := uuid.New().String()
+	correlationID := events_pkg.CorrelationIDFromContext(ctx)
+	if correlationID == "" {
+		correlationID = id
+	}
+	return Event{
+		id:            id,
+		name:          name,
+		occurredAt:    time.Now().UTC(),
+		aggregateID:   aggregateID,
+		correlationID: correlationID,
+		requestID:     events_pkg.RequestIDFromContext(ctx),
+		payload:       payload,
+	}
+}
+
+func (e Event) GetID() string {
+	return e.id
+}
+
+func (e Event) GetName() string {
+	return e.name
+}
+
+// GetDateTime devolve o instante em que o evento ocorreu.
+func (e Event) GetDateTime() time.Time {
+	return e.occurredAt
+}
+
+func (e Event) GetAggregateID() string {
+	return e.aggregateID
+}
+
+func (e Event) GetCorrelationID() string {
+	return e.correlationID
+}
+
+func (e Event) GetRequestID() string {
+	return e.requestID
+}
+
+func (e Event) GetPayload() interface{} {
+	return e.payload
+}
+
+// verifica se implementa a interface
+var _ events_pkg.EventInterface = Event{}
@@ -0,0 +1,34 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCursoChanged_CadaOcorrenciaTemIdentidadePropria(t *testing.T) {
+	payload := dto.CursoOutputDTO{ID: uuid.New(), Nome: "curso 1"}
+
+	e1 := NewCursoChanged(context.Background(), payload)
+	e2 := NewCursoChanged(context.Background(), payload)
+
+	assert.Equal(t, CursoChangedName, e1.GetName())
+	assert.Equal(t, payload.ID.String(), e1.GetAggregateID())
+	assert.Equal(t, payload, e1.GetPayload())
+	assert.False(t, e1.GetDateTime().IsZero())
+	assert.NotEqual(t, e1.GetID(), e2.GetID())
+	// sem correlação no contexto o evento inicia a própria cadeia
+	assert.Equal(t, e1.GetID(), e1.GetCorrelationID())
+}
+
+func TestNewCursoDeleted_HerdaCorrelacaoDoContexto(t *testing.T) {
+	ctx := events_pkg.WithCorrelationID(context.Background(), "req-1")
+
+	evt := NewCursoDeleted(ctx, dto.DeletedOutputDTO{ID: uuid.New()})
+
+	assert.Equal(t, "req-1", evt.GetCorrelationID())
+}
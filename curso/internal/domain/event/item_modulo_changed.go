@@ -1,37 +1,21 @@
 package event
 
 import (
-	"time"
+	"context"
 
-	events_pkg "github.com/ggialluisi/nebula-back/curso/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
 )
 
-type ItemModuloChanged struct {
-	Name    string
-	Payload interface{}
-}
+const ItemModuloChangedName = "ItemModuloChanged"
 
-func NewItemModuloChanged() *AlunoChanged {
-	return &AlunoChanged{
-		Name: "ItemModuloChanged",
-	}
-}
-
-func (e *ItemModuloChanged) GetName() string {
-	return e.Name
-}
-
-func (e *ItemModuloChanged) GetPayload() interface{} {
-	return e.Payload
-}
-
-func (e *ItemModuloChanged) SetPayload(payload interface{}) {
-	e.Payload = payload
+type ItemModuloChanged struct {
+	Event
 }
 
-func (e *ItemModuloChanged) GetDateTime() time.Time {
-	return time.Now()
+func NewItemModuloChanged(ctx context.Context, payload dto.ItemModuloOutputDTO) ItemModuloChanged {
+	return ItemModuloChanged{Event: newEvent(ctx, ItemModuloChangedName, payload.ID, payload)}
 }
 
 // verifica se implementa a interface
-var _ events_pkg.EventInterface = (*AlunoChanged)(nil)
+var _ events_pkg.EventInterface = ItemModuloChanged{}
@@ -0,0 +1,21 @@
+package event
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+)
+
+const ItemModuloDeletedName = "ItemModuloDeleted"
+
+type ItemModuloDeleted struct {
+	Event
+}
+
+func NewItemModuloDeleted(ctx context.Context, payload dto.DeletedOutputDTO) ItemModuloDeleted {
+	return ItemModuloDeleted{Event: newEvent(ctx, ItemModuloDeletedName, payload.ID.String(), payload)}
+}
+
+// verifica se implementa a interface
+var _ events_pkg.EventInterface = ItemModuloDeleted{}
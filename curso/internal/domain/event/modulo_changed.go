@@ -1,37 +1,21 @@
 package event
 
 import (
-	"time"
+	"context"
 
-	events_pkg "github.com/ggialluisi/nebula-back/curso/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
 )
 
-type ModuloChanged struct {
-	Name    string
-	Payload interface{}
-}
+const ModuloChangedName = "ModuloChanged"
 
-func NewModuloChanged() *ModuloChanged {
-	return &ModuloChanged{
-		Name: "ModuloChanged",
-	}
-}
-
-func (e *ModuloChanged) GetName() string {
-	return e.Name
-}
-
-func (e *ModuloChanged) GetPayload() interface{} {
-	return e.Payload
-}
-
-func (e *ModuloChanged) SetPayload(payload interface{}) {
-	e.Payload = payload
+type ModuloChanged struct {
+	Event
 }
 
-func (e *ModuloChanged) GetDateTime() time.Time {
-	return time.Now()
+func NewModuloChanged(ctx context.Context, payload dto.ModuloOutputDTO) ModuloChanged {
+	return ModuloChanged{Event: newEvent(ctx, ModuloChangedName, payload.ID.String(), payload)}
 }
 
 // verifica se implementa a interface
-var _ events_pkg.EventInterface = (*ModuloChanged)(nil)
+var _ events_pkg.EventInterface = ModuloChanged{}
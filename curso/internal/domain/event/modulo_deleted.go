@@ -0,0 +1,21 @@
+package event
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+)
+
+const ModuloDeletedName = "ModuloDeleted"
+
+type ModuloDeleted struct {
+	Event
+}
+
+func NewModuloDeleted(ctx context.Context, payload dto.DeletedOutputDTO) ModuloDeleted {
+	return ModuloDeleted{Event: newEvent(ctx, ModuloDeletedName, payload.ID.String(), payload)}
+}
+
+// verifica se implementa a interface
+var _ events_pkg.EventInterface = ModuloDeleted{}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/query"
+)
+
+type BuscaRepositoryInterface interface {
+	// BuscarCatalogo devolve a página de resultados, do mais relevante para o menos
+	// relevante; a ordenação de spec é ignorada.
+	BuscarCatalogo(ctx context.Context, busca entity.BuscaCatalogo, spec query.Spec) (query.Page[entity.ResultadoBusca], error)
+}
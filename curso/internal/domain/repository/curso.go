@@ -1,17 +1,27 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/query"
 	"github.com/google/uuid"
 )
 
 type CursoRepositoryInterface interface {
+	// WithTransaction executa fn dentro de uma transação; fn recebe um repositório ligado à
+	// transação e ao contexto (trace da requisição nas consultas)
+	WithTransaction(ctx context.Context, fn func(repo CursoRepositoryInterface) error) error
+	CreateOutboxEvent(obj *entity.OutboxEvent) error
+	// UpdatePessoa grava a réplica da pessoa pela mesma conexão (e transação) do repositório
+	UpdatePessoa(obj *entity.Pessoa) (*entity.Pessoa, error)
+
 	CreateCurso(obj *entity.Curso) (*entity.Curso, error)
 	UpdateCurso(obj *entity.Curso) (*entity.Curso, error)
 	DeleteCurso(objID uuid.UUID) error
 	GetCurso(objID uuid.UUID) (*entity.Curso, error)
 	GetCursoByDocumento(documento string) (*entity.Curso, error)
-	FindAllCursos(page, limit int, sort string) ([]entity.Curso, error)
+	FindAllCursos(spec query.Spec) (query.Page[entity.Curso], error)
 
 	CreateModulo(obj *entity.Modulo) (*entity.Modulo, error)
 	UpdateModulo(obj *entity.Modulo) (*entity.Modulo, error)
@@ -33,21 +43,32 @@ type CursoRepositoryInterface interface {
 	GetAluno(objID uuid.UUID) (*entity.Aluno, error)
 	GetAlunoByWallet(wallet string) (*entity.Aluno, error)
 	GetAlunoByDocumento(documento string) (*entity.Aluno, error)
-	FindAllAlunos(page, limit int, sort string) ([]entity.Aluno, error)
+	FindAllAlunos(spec query.Spec) (query.Page[entity.Aluno], error)
 	HasAlunoPagamentoPendente(alunoID uuid.UUID) (bool, error)
+	AddXpAluno(alunoID uuid.UUID, xp int64) error
+	FindAlunosByPessoa(pessoaID uuid.UUID) ([]entity.Aluno, error)
+	UpdateAlunoStatusWallet(obj *entity.Aluno) error
 
 	CreateAlunoCurso(obj *entity.AlunoCurso) (*entity.AlunoCurso, error)
 	UpdateAlunoCurso(obj *entity.AlunoCurso) (*entity.AlunoCurso, error)
 	DeleteAlunoCurso(objID uuid.UUID) error
 	GetAlunoCurso(objID uuid.UUID) (*entity.AlunoCurso, error)
-	FindAllAlunoCursos(page, limit int, sort string) ([]entity.AlunoCurso, error)
+	// GetAlunoCursoForUpdate carrega a matrícula travando-a até o fim da transação
+	GetAlunoCursoForUpdate(objID uuid.UUID) (*entity.AlunoCurso, error)
+	FindAllAlunoCursos(spec query.Spec) (query.Page[entity.AlunoCurso], error)
 	FindCursosDoAluno(alunoID uuid.UUID) ([]entity.AlunoCurso, error)
 	FindAlunosDoCurso(cursoID uuid.UUID) ([]entity.AlunoCurso, error)
 	CountCursosDoAluno(alunoID uuid.UUID) (int64, error)
+	CountMatriculasAtivasDoAluno(alunoID uuid.UUID) (int64, error)
 	CountAlunosDoCurso(cursoID uuid.UUID) (int64, error)
+	UpdateAlunoCursoProgresso(obj *entity.AlunoCurso) error
 
 	CreateAlunoCursoItemModulosBatch(items []*entity.AlunoCursoItemModulo) error
 	FindItemModulosByAlunoCurso(alunoCursoID uuid.UUID) ([]entity.AlunoCursoItemModulo, error)
 	GetAlunoCursoItemModulo(id uuid.UUID) (*entity.AlunoCursoItemModulo, error)
 	UpdateAlunoCursoItemModulo(item *entity.AlunoCursoItemModulo) error
+	FindValidacoesContratoPendentes(txHash string, enderecos []string) ([]entity.AlunoCursoItemModulo, error)
+	FindValidacoesContratoPorTx(txHash string) ([]entity.AlunoCursoItemModulo, error)
+	ResetValidacaoContrato(item *entity.AlunoCursoItemModulo) error
+	CreateValidacaoContratoEvento(obj *entity.ValidacaoContratoEvento) error
 }
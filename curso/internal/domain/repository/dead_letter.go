@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/query"
+	"github.com/google/uuid"
+)
+
+type DeadLetterRepositoryInterface interface {
+	CreateDeadLetter(obj *entity.DeadLetter) error
+	GetDeadLetter(objID uuid.UUID) (*entity.DeadLetter, error)
+	FindDeadLetters(spec query.Spec) (query.Page[entity.DeadLetter], error)
+	MarkDeadLetterReplayed(objID uuid.UUID) error
+}
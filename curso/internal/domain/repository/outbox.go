@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type OutboxRepositoryInterface interface {
+	// WithTransaction executa fn numa transação; os eventos retornados por FindPendingOutboxEvents
+	// ficam travados para outras instâncias do relay até o fim da transação
+	WithTransaction(ctx context.Context, fn func(repo OutboxRepositoryInterface) error) error
+	CreateOutboxEvent(obj *entity.OutboxEvent) error
+	FindPendingOutboxEvents(limit int) ([]entity.OutboxEvent, error)
+	MarkOutboxEventPublished(objID uuid.UUID) error
+	MarkOutboxEventFailed(objID uuid.UUID, errMsg string) error
+	MarkOutboxEventDead(objID uuid.UUID, errMsg string) error
+}
@@ -1,14 +1,21 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/query"
 	"github.com/google/uuid"
 )
 
 type PessoaRepositoryInterface interface {
+	// WithTransaction executa fn dentro de uma transação; fn recebe um repositório ligado à
+	// transação e ao contexto (trace da mensagem nas consultas)
+	WithTransaction(ctx context.Context, fn func(repo PessoaRepositoryInterface) error) error
+
 	CreatePessoa(obj *entity.Pessoa) (*entity.Pessoa, error)
 	UpdatePessoa(obj *entity.Pessoa) (*entity.Pessoa, error)
 	DeletePessoa(objID uuid.UUID) error
 	GetPessoa(objID uuid.UUID) (*entity.Pessoa, error)
-	GetPessoas() ([]entity.Pessoa, error)
+	FindAllPessoas(spec query.Spec) (query.Page[entity.Pessoa], error)
 }
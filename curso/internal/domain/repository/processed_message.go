@@ -0,0 +1,6 @@
+package repository
+
+type ProcessedMessageRepositoryInterface interface {
+	IsProcessed(topic, eventID string) (bool, error)
+	MarkProcessed(topic, eventID string) error
+}
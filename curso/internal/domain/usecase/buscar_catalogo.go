@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/query"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
+)
+
+type BuscarCatalogoUseCase struct {
+	BuscaRepository repository.BuscaRepositoryInterface
+}
+
+func NewBuscarCatalogoUseCase(
+	BuscaRepository repository.BuscaRepositoryInterface,
+) *BuscarCatalogoUseCase {
+	return &BuscarCatalogoUseCase{
+		BuscaRepository: BuscaRepository,
+	}
+}
+
+// Execute valida a busca e devolve a página de resultados, do mais relevante para o
+// menos relevante. Texto ou tipo inválidos retornam os erros de entity.NewBuscaCatalogo.
+func (c *BuscarCatalogoUseCase) Execute(ctx context.Context, texto string, tipos []string, spec query.Spec) (query.Page[dto.ResultadoBuscaOutputDTO], error) {
+	ctx, span := tracing.Start(ctx, "BuscarCatalogoUseCase.Execute")
+	defer span.End()
+
+	busca, err := entity.NewBuscaCatalogo(texto, tipos)
+	if err != nil {
+		return query.Page[dto.ResultadoBuscaOutputDTO]{}, err
+	}
+
+	resultados, err := c.BuscaRepository.BuscarCatalogo(ctx, *busca, spec)
+	if err != nil {
+		return query.Page[dto.ResultadoBuscaOutputDTO]{}, err
+	}
+
+	return query.Map(resultados, func(r entity.ResultadoBusca) dto.ResultadoBuscaOutputDTO {
+		return dto.ResultadoBuscaOutputDTO{
+			Tipo:     string(r.Tipo),
+			ID:       r.ID,
+			CursoID:  r.CursoID,
+			ModuloID: r.ModuloID,
+			Titulo:   r.Titulo,
+			Trecho:   r.Trecho,
+			Rank:     r.Rank,
+		}
+	}), nil
+}
@@ -1,24 +1,23 @@
 package usecase
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	domain_event "github.com/ggialluisi/nebula-back/curso/internal/domain/event"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
-	"github.com/ggialluisi/nebula-back/curso/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/query"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
 	"github.com/google/uuid"
 )
 
 type SaveCursoUseCase struct {
 	CursoRepository  repository.CursoRepositoryInterface
-	CursoSaved       event_dispatcher.EventInterface
-	ModuloSaved      event_dispatcher.EventInterface
-	AlunoSaved       event_dispatcher.EventInterface
-	AlunoCursoSaved  event_dispatcher.EventInterface
-	ItemModuloSaved  event_dispatcher.EventInterface
 	EventDispatcher  event_dispatcher.EventDispatcherInterface
 	PessoaRepository repository.PessoaRepositoryInterface
 }
@@ -26,28 +25,60 @@ type SaveCursoUseCase struct {
 func NewSaveCursoUseCase(
 	CursoRepository repository.CursoRepositoryInterface,
 	PessoaRepository repository.PessoaRepositoryInterface,
-	CursoSaved event_dispatcher.EventInterface,
-	ModuloSaved event_dispatcher.EventInterface,
-	AlunoSaved event_dispatcher.EventInterface,
-	AlunoCursoSaved event_dispatcher.EventInterface,
-	ItemModuloSaved event_dispatcher.EventInterface,
 	EventDispatcher event_dispatcher.EventDispatcherInterface,
 ) *SaveCursoUseCase {
 	return &SaveCursoUseCase{
 		CursoRepository:  CursoRepository,
 		PessoaRepository: PessoaRepository,
-		CursoSaved:       CursoSaved,
-		ModuloSaved:      ModuloSaved,
-		AlunoSaved:       AlunoSaved,
-		AlunoCursoSaved:  AlunoCursoSaved,
-		ItemModuloSaved:  ItemModuloSaved,
 		EventDispatcher:  EventDispatcher,
 	}
 }
 
+// addToOutbox grava o evento no outbox usando o repositório da transação corrente,
+// garantindo que ele só exista se a alteração do agregado for confirmada. O registro
+// herda id, instante e correlação do evento, então o evento despachado localmente e
+// o publicado no Kafka têm o mesmo event_id. O traceparent do contexto é guardado para a
+// publicação continuar o trace da requisição.
+func (c *SaveCursoUseCase) addToOutbox(ctx context.Context, repo repository.CursoRepositoryInterface, event event_dispatcher.EventInterface) error {
+	aggregateID, err := uuid.Parse(event.GetAggregateID())
+	if err != nil {
+		return err
+	}
+	eventID, err := uuid.Parse(event.GetID())
+	if err != nil {
+		return err
+	}
+	outboxEvent, err := entity.NewOutboxEvent(event.GetName(), aggregateID, event.GetPayload())
+	if err != nil {
+		return err
+	}
+	outboxEvent.ID = eventID
+	outboxEvent.CreatedAt = event.GetDateTime()
+	outboxEvent.CorrelationID = event.GetCorrelationID()
+	outboxEvent.RequestID = event.GetRequestID()
+	outboxEvent.TraceParent = tracing.TraceParent(ctx)
+	return repo.CreateOutboxEvent(outboxEvent)
+}
+
+// deletedOutputDTO monta o payload dos eventos *.deleted com o último estado do agregado excluído.
+func deletedOutputDTO(aggregateID uuid.UUID, data interface{}) dto.DeletedOutputDTO {
+	return dto.DeletedOutputDTO{
+		ID:        aggregateID,
+		DeletedAt: time.Now(),
+		Data:      data,
+	}
+}
+
+// dispatchEvent dispara no dispatcher local, depois do commit, um evento já gravado no outbox.
+func (c *SaveCursoUseCase) dispatchEvent(ctx context.Context, event event_dispatcher.EventInterface) error {
+	return c.EventDispatcher.Dispatch(ctx, event)
+}
+
 // region cadastro de Curso
 
-func (c *SaveCursoUseCase) ExecuteCreateCurso(input dto.CursoInputDTO) (dto.CursoOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteCreateCurso(ctx context.Context, input dto.CursoInputDTO) (dto.CursoOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteCreateCurso")
+	defer span.End()
 
 	curso, err := entity.NewCurso(
 		nil,
@@ -58,26 +89,35 @@ func (c *SaveCursoUseCase) ExecuteCreateCurso(input dto.CursoInputDTO) (dto.Curs
 		return dto.CursoOutputDTO{}, err
 	}
 
-	ret, err := c.CursoRepository.CreateCurso(curso)
-	if err != nil {
-		return dto.CursoOutputDTO{}, err
-	}
+	var out_dto dto.CursoOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		ret, err := repo.CreateCurso(curso)
+		if err != nil {
+			return err
+		}
+
+		saved_obj, err := repo.GetCurso(ret.ID)
+		if err != nil {
+			return err
+		}
 
-	saved_obj, err := c.CursoRepository.GetCurso(ret.ID)
+		out_dto = dto.CursoOutputDTO{
+			ID:        saved_obj.ID,
+			CreatedAt: saved_obj.CreatedAt,
+			UpdatedAt: saved_obj.UpdatedAt,
+			Nome:      saved_obj.Nome,
+			Descricao: saved_obj.Descricao,
+		}
+
+		evt = domain_event.NewCursoChanged(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return dto.CursoOutputDTO{}, err
 	}
 
-	out_dto := dto.CursoOutputDTO{
-		ID:        saved_obj.ID,
-		CreatedAt: saved_obj.CreatedAt,
-		UpdatedAt: saved_obj.UpdatedAt,
-		Nome:      saved_obj.Nome,
-		Descricao: saved_obj.Descricao,
-	}
-
-	c.CursoSaved.SetPayload(out_dto)
-	err = c.EventDispatcher.Dispatch(c.CursoSaved)
+	err = c.dispatchEvent(ctx, evt)
 	if err != nil {
 		return dto.CursoOutputDTO{}, err
 	}
@@ -85,7 +125,10 @@ func (c *SaveCursoUseCase) ExecuteCreateCurso(input dto.CursoInputDTO) (dto.Curs
 	return out_dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteUpdateCurso(obj_id string, input dto.CursoInputDTO) (dto.CursoOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteUpdateCurso(ctx context.Context, obj_id string, input dto.CursoInputDTO) (dto.CursoOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteUpdateCurso")
+	defer span.End()
+
 	obj_uuid, err := uuid.Parse(obj_id)
 	if err != nil {
 		return dto.CursoOutputDTO{}, err
@@ -100,30 +143,35 @@ func (c *SaveCursoUseCase) ExecuteUpdateCurso(obj_id string, input dto.CursoInpu
 		return dto.CursoOutputDTO{}, err
 	}
 
-	ret, err := c.CursoRepository.UpdateCurso(curso)
-	if err != nil {
-		return dto.CursoOutputDTO{}, err
-	}
+	var out_dto dto.CursoOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		ret, err := repo.UpdateCurso(curso)
+		if err != nil {
+			return err
+		}
 
-	saved_obj, err := c.CursoRepository.GetCurso(ret.ID)
-	if err != nil {
-		return dto.CursoOutputDTO{}, err
-	}
+		saved_obj, err := repo.GetCurso(ret.ID)
+		if err != nil {
+			return err
+		}
 
-	out_dto := dto.CursoOutputDTO{
-		ID:        saved_obj.ID,
-		CreatedAt: saved_obj.CreatedAt,
-		UpdatedAt: saved_obj.UpdatedAt,
-		Nome:      saved_obj.Nome,
-		Descricao: saved_obj.Descricao,
-	}
-	_, err = json.Marshal(out_dto)
+		out_dto = dto.CursoOutputDTO{
+			ID:        saved_obj.ID,
+			CreatedAt: saved_obj.CreatedAt,
+			UpdatedAt: saved_obj.UpdatedAt,
+			Nome:      saved_obj.Nome,
+			Descricao: saved_obj.Descricao,
+		}
+
+		evt = domain_event.NewCursoChanged(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return dto.CursoOutputDTO{}, err
 	}
 
-	c.CursoSaved.SetPayload(out_dto)
-	err = c.EventDispatcher.Dispatch(c.CursoSaved)
+	err = c.dispatchEvent(ctx, evt)
 	if err != nil {
 		dto_nil := dto.CursoOutputDTO{}
 		return dto_nil, err
@@ -132,20 +180,41 @@ func (c *SaveCursoUseCase) ExecuteUpdateCurso(obj_id string, input dto.CursoInpu
 	return out_dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteDeleteCurso(obj_id string) error {
+func (c *SaveCursoUseCase) ExecuteDeleteCurso(ctx context.Context, obj_id string) error {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteDeleteCurso")
+	defer span.End()
+
 	obj_uuid, err := uuid.Parse(obj_id)
 	if err != nil {
 		return err
 	}
 
-	err = c.CursoRepository.DeleteCurso(obj_uuid)
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		saved_obj, err := repo.GetCurso(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		err = repo.DeleteCurso(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		evt = domain_event.NewCursoDeleted(ctx, deletedOutputDTO(obj_uuid, dto.CursoOutputDTO{
+			ID:        saved_obj.ID,
+			CreatedAt: saved_obj.CreatedAt,
+			UpdatedAt: saved_obj.UpdatedAt,
+			Nome:      saved_obj.Nome,
+			Descricao: saved_obj.Descricao,
+		}))
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return err
 	}
 
-	//to-do - aqui precisa disparar um evento para o log
-
-	return nil
+	return c.dispatchEvent(ctx, evt)
 }
 
 func (c *SaveCursoUseCase) ExecuteGetCurso(obj_id string) (dto.CursoOutputDTO, error) {
@@ -170,31 +239,31 @@ func (c *SaveCursoUseCase) ExecuteGetCurso(obj_id string) (dto.CursoOutputDTO, e
 	return dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteGetCursos(page, limit int, sort string) ([]dto.CursoOutputDTO, error) {
-	saved_objs, err := c.CursoRepository.FindAllCursos(page, limit, sort)
+func (c *SaveCursoUseCase) ExecuteGetCursos(spec query.Spec) (query.Page[dto.CursoOutputDTO], error) {
+	saved_objs, err := c.CursoRepository.FindAllCursos(spec)
 	if err != nil {
-		return []dto.CursoOutputDTO{}, err
+		return query.Page[dto.CursoOutputDTO]{}, err
 	}
 
-	var dtos []dto.CursoOutputDTO
-	for _, saved_obj := range saved_objs {
-		dto := dto.CursoOutputDTO{
+	return query.Map(saved_objs, func(saved_obj entity.Curso) dto.CursoOutputDTO {
+		return dto.CursoOutputDTO{
 			ID:        saved_obj.ID,
 			CreatedAt: saved_obj.CreatedAt,
 			UpdatedAt: saved_obj.UpdatedAt,
 			Nome:      saved_obj.Nome,
 			Descricao: saved_obj.Descricao,
 		}
-		dtos = append(dtos, dto)
-	}
-	return dtos, nil
+	}), nil
 }
 
 // endregion
 
 // region cadastro de Modulo
 
-func (c *SaveCursoUseCase) ExecuteCreateModulo(input dto.ModuloInputDTO) (dto.ModuloOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteCreateModulo(ctx context.Context, input dto.ModuloInputDTO) (dto.ModuloOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteCreateModulo")
+	defer span.End()
+
 	parent_uuid, err := uuid.Parse(input.CursoID)
 	if err != nil {
 		return dto.ModuloOutputDTO{}, err
@@ -210,29 +279,39 @@ func (c *SaveCursoUseCase) ExecuteCreateModulo(input dto.ModuloInputDTO) (dto.Mo
 		return dto.ModuloOutputDTO{}, err
 	}
 
-	ret, err := c.CursoRepository.CreateModulo(modulo)
+	var out_dto dto.ModuloOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		ret, err := repo.CreateModulo(modulo)
+		if err != nil {
+			return err
+		}
+
+		saved_obj, err := repo.GetModulo(ret.ID)
+		if err != nil {
+			return err
+		}
+
+		out_dto = moduloOutputDTO(saved_obj)
+		evt = domain_event.NewModuloChanged(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return dto.ModuloOutputDTO{}, err
 	}
 
-	saved_obj, err := c.CursoRepository.GetModulo(ret.ID)
+	err = c.dispatchEvent(ctx, evt)
 	if err != nil {
 		return dto.ModuloOutputDTO{}, err
 	}
 
-	dto := dto.ModuloOutputDTO{
-		ID:        saved_obj.ID,
-		CursoID:   saved_obj.CursoID,
-		CreatedAt: saved_obj.CreatedAt,
-		UpdatedAt: saved_obj.UpdatedAt,
-		Nome:      saved_obj.Nome,
-		Descricao: saved_obj.Descricao,
-	}
-
-	return dto, nil
+	return out_dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteUpdateModulo(obj_id string, input dto.ModuloInputDTO) (dto.ModuloOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteUpdateModulo(ctx context.Context, obj_id string, input dto.ModuloInputDTO) (dto.ModuloOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteUpdateModulo")
+	defer span.End()
+
 	parent_uuid, err := uuid.Parse(input.CursoID)
 	if err != nil {
 		return dto.ModuloOutputDTO{}, err
@@ -253,42 +332,64 @@ func (c *SaveCursoUseCase) ExecuteUpdateModulo(obj_id string, input dto.ModuloIn
 		return dto.ModuloOutputDTO{}, err
 	}
 
-	ret, err := c.CursoRepository.UpdateModulo(modulo)
+	var out_dto dto.ModuloOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		ret, err := repo.UpdateModulo(modulo)
+		if err != nil {
+			return err
+		}
+
+		saved_obj, err := repo.GetModulo(ret.ID)
+		if err != nil {
+			return err
+		}
+
+		out_dto = moduloOutputDTO(saved_obj)
+		evt = domain_event.NewModuloChanged(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return dto.ModuloOutputDTO{}, err
 	}
 
-	saved_obj, err := c.CursoRepository.GetModulo(ret.ID)
+	err = c.dispatchEvent(ctx, evt)
 	if err != nil {
 		return dto.ModuloOutputDTO{}, err
 	}
 
-	dto := dto.ModuloOutputDTO{
-		ID:        saved_obj.ID,
-		CursoID:   saved_obj.CursoID,
-		CreatedAt: saved_obj.CreatedAt,
-		UpdatedAt: saved_obj.UpdatedAt,
-		Nome:      saved_obj.Nome,
-		Descricao: saved_obj.Descricao,
-	}
-
-	return dto, nil
+	return out_dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteDeleteModulo(obj_id string) error {
+func (c *SaveCursoUseCase) ExecuteDeleteModulo(ctx context.Context, obj_id string) error {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteDeleteModulo")
+	defer span.End()
+
 	obj_uuid, err := uuid.Parse(obj_id)
 	if err != nil {
 		return err
 	}
 
-	err = c.CursoRepository.DeleteModulo(obj_uuid)
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		saved_obj, err := repo.GetModulo(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		err = repo.DeleteModulo(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		evt = domain_event.NewModuloDeleted(ctx, deletedOutputDTO(obj_uuid, moduloOutputDTO(saved_obj)))
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return err
 	}
 
-	//to-do - aqui precisa disparar um evento para o log
-
-	return nil
+	return c.dispatchEvent(ctx, evt)
 }
 
 func (c *SaveCursoUseCase) ExecuteGetModulo(obj_id string) (dto.ModuloOutputDTO, error) {
@@ -341,23 +442,38 @@ func (c *SaveCursoUseCase) ExecuteGetModulosDeCurso(parent_id string) ([]dto.Mod
 	return dtos, nil
 }
 
+func moduloOutputDTO(saved_obj *entity.Modulo) dto.ModuloOutputDTO {
+	return dto.ModuloOutputDTO{
+		ID:        saved_obj.ID,
+		CursoID:   saved_obj.CursoID,
+		CreatedAt: saved_obj.CreatedAt,
+		UpdatedAt: saved_obj.UpdatedAt,
+		Nome:      saved_obj.Nome,
+		Descricao: saved_obj.Descricao,
+	}
+}
+
 // endregion
 
 // region cadastro de Aluno
 
-func (c *SaveCursoUseCase) ExecuteCreateAluno(input dto.AlunoNewInputDTO) (dto.AlunoOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteCreateAluno(ctx context.Context, input dto.AlunoNewInputDTO) (dto.AlunoOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteCreateAluno")
+	defer span.End()
+
 	pessoa_id, err := uuid.Parse(input.PessoaID)
 	if err != nil {
 		return dto.AlunoOutputDTO{}, err
 	}
 
-	// Verifica se a pessoa já existe
+	// Cria ou atualiza a réplica da pessoa pelo mesmo caminho dos eventos pessoa.saved,
+	// que preserva a versão de origem e os dados de exclusão/anonimização
 	input_pessoa := dto.PessoaInputDTO{
 		ID:   input.PessoaID,
 		Tipo: "FISICA",
 		Nome: input.Nome,
 	}
-	_, err = c.ExecuteCreateOrUpdatePessoa(input_pessoa)
+	_, err = NewSavePessoaUseCase(c.PessoaRepository).ExecuteCreateOrUpdatePessoa(ctx, input_pessoa)
 	if err != nil {
 		return dto.AlunoOutputDTO{}, err
 	}
@@ -373,32 +489,22 @@ func (c *SaveCursoUseCase) ExecuteCreateAluno(input dto.AlunoNewInputDTO) (dto.A
 		return dto.AlunoOutputDTO{}, err
 	}
 
-	ret, err := c.CursoRepository.CreateAluno(item)
-	if err != nil {
-		return dto.AlunoOutputDTO{}, err
-	}
+	var out_dto dto.AlunoOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		ret, err := repo.CreateAluno(item)
+		if err != nil {
+			return err
+		}
 
-	saved_obj, err := c.CursoRepository.GetAluno(ret.ID)
+		out_dto, evt, err = c.addAlunoSavedToOutbox(ctx, repo, ret.ID)
+		return err
+	})
 	if err != nil {
 		return dto.AlunoOutputDTO{}, err
 	}
 
-	out_dto := dto.AlunoOutputDTO{
-		ID:          saved_obj.ID,
-		CreatedAt:   saved_obj.CreatedAt,
-		UpdatedAt:   saved_obj.UpdatedAt,
-		PessoaID:    saved_obj.PessoaID,
-		Nome:        saved_obj.Nome(),
-		Wallet:      saved_obj.Wallet,
-		TipoPessoa:  saved_obj.TipoPessoa(),
-		DataInicio:  saved_obj.DataInicio,
-		XpTotal:     saved_obj.XpTotal,
-		NftId:       saved_obj.NftId,
-		StatusAluno: saved_obj.StatusAluno,
-	}
-
-	c.AlunoSaved.SetPayload(out_dto)
-	err = c.EventDispatcher.Dispatch(c.AlunoSaved)
+	err = c.dispatchEvent(ctx, evt)
 	if err != nil {
 		return dto.AlunoOutputDTO{}, err
 	}
@@ -406,7 +512,10 @@ func (c *SaveCursoUseCase) ExecuteCreateAluno(input dto.AlunoNewInputDTO) (dto.A
 	return out_dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteUpdateAluno(obj_id string, input dto.AlunoInputDTO) (dto.AlunoOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteUpdateAluno(ctx context.Context, obj_id string, input dto.AlunoInputDTO) (dto.AlunoOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteUpdateAluno")
+	defer span.End()
+
 	obj_uuid, err := uuid.Parse(obj_id)
 	if err != nil {
 		return dto.AlunoOutputDTO{}, err
@@ -430,58 +539,58 @@ func (c *SaveCursoUseCase) ExecuteUpdateAluno(obj_id string, input dto.AlunoInpu
 		return dto.AlunoOutputDTO{}, err
 	}
 
-	ret, err := c.CursoRepository.UpdateAluno(curso)
-	if err != nil {
-		return dto.AlunoOutputDTO{}, err
-	}
+	var out_dto dto.AlunoOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		ret, err := repo.UpdateAluno(curso)
+		if err != nil {
+			return err
+		}
 
-	saved_obj, err := c.CursoRepository.GetAluno(ret.ID)
+		out_dto, evt, err = c.addAlunoSavedToOutbox(ctx, repo, ret.ID)
+		return err
+	})
 	if err != nil {
 		return dto.AlunoOutputDTO{}, err
 	}
 
-	out_dto := dto.AlunoOutputDTO{
-		ID:          saved_obj.ID,
-		CreatedAt:   saved_obj.CreatedAt,
-		UpdatedAt:   saved_obj.UpdatedAt,
-		PessoaID:    saved_obj.PessoaID,
-		Nome:        saved_obj.Nome(),
-		Wallet:      saved_obj.Wallet,
-		TipoPessoa:  saved_obj.TipoPessoa(),
-		DataInicio:  saved_obj.DataInicio,
-		XpTotal:     saved_obj.XpTotal,
-		NftId:       saved_obj.NftId,
-		StatusAluno: saved_obj.StatusAluno,
-	}
-	_, err = json.Marshal(out_dto)
+	err = c.dispatchEvent(ctx, evt)
 	if err != nil {
 		return dto.AlunoOutputDTO{}, err
 	}
 
-	c.AlunoSaved.SetPayload(out_dto)
-	err = c.EventDispatcher.Dispatch(c.AlunoSaved)
-	if err != nil {
-		dto_nil := dto.AlunoOutputDTO{}
-		return dto_nil, err
-	}
-
 	return out_dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteDeleteAluno(obj_id string) error {
+func (c *SaveCursoUseCase) ExecuteDeleteAluno(ctx context.Context, obj_id string) error {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteDeleteAluno")
+	defer span.End()
+
 	obj_uuid, err := uuid.Parse(obj_id)
 	if err != nil {
 		return err
 	}
 
-	err = c.CursoRepository.DeleteAluno(obj_uuid)
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		saved_obj, err := repo.GetAluno(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		err = repo.DeleteAluno(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		evt = domain_event.NewAlunoDeleted(ctx, deletedOutputDTO(obj_uuid, alunoOutputDTO(saved_obj)))
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return err
 	}
 
-	//to-do - aqui precisa disparar um evento para o log
-
-	return nil
+	return c.dispatchEvent(ctx, evt)
 }
 
 func (c *SaveCursoUseCase) ExecuteGetAluno(obj_id string) (dto.AlunoOutputDTO, error) {
@@ -540,15 +649,14 @@ func (c *SaveCursoUseCase) ExecuteGetAlunoByWallet(obj_wallet string) (dto.Aluno
 	return dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteGetAlunos(page, limit int, sort string) ([]dto.AlunoOutputDTO, error) {
-	saved_objs, err := c.CursoRepository.FindAllAlunos(page, limit, sort)
+func (c *SaveCursoUseCase) ExecuteGetAlunos(spec query.Spec) (query.Page[dto.AlunoOutputDTO], error) {
+	saved_objs, err := c.CursoRepository.FindAllAlunos(spec)
 	if err != nil {
-		return []dto.AlunoOutputDTO{}, err
+		return query.Page[dto.AlunoOutputDTO]{}, err
 	}
 
-	var dtos []dto.AlunoOutputDTO
-	for _, saved_obj := range saved_objs {
-		dto := dto.AlunoOutputDTO{
+	return query.Map(saved_objs, func(saved_obj entity.Aluno) dto.AlunoOutputDTO {
+		return dto.AlunoOutputDTO{
 			ID:          saved_obj.ID,
 			CreatedAt:   saved_obj.CreatedAt,
 			UpdatedAt:   saved_obj.UpdatedAt,
@@ -561,15 +669,16 @@ func (c *SaveCursoUseCase) ExecuteGetAlunos(page, limit int, sort string) ([]dto
 			NftId:       saved_obj.NftId,
 			StatusAluno: saved_obj.StatusAluno,
 		}
-		dtos = append(dtos, dto)
-	}
-	return dtos, nil
+	}), nil
 }
 
 // endregion
 
 // region cadastro de AlunoCurso
-func (c *SaveCursoUseCase) ExecuteCreateAlunoCurso(input dto.AlunoCursoInputDTO) (dto.AlunoCursoOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteCreateAlunoCurso(ctx context.Context, input dto.AlunoCursoInputDTO) (dto.AlunoCursoOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteCreateAlunoCurso")
+	defer span.End()
+
 	alunoID, err := uuid.Parse(input.AlunoID)
 	if err != nil {
 		return dto.AlunoCursoOutputDTO{}, err
@@ -590,73 +699,83 @@ func (c *SaveCursoUseCase) ExecuteCreateAlunoCurso(input dto.AlunoCursoInputDTO)
 		return dto.AlunoCursoOutputDTO{}, err
 	}
 
-	ret, err := c.CursoRepository.CreateAlunoCurso(alunoCurso)
-	if err != nil {
-		return dto.AlunoCursoOutputDTO{}, err
-	}
-
-	// Busca todos os ItemModulo do curso para criar os AlunoCursoItemModulo
-	modulos, err := c.CursoRepository.GetModulosDeCurso(cursoID)
-	if err != nil {
-		return dto.AlunoCursoOutputDTO{}, err
-	}
+	var out_dto dto.AlunoCursoOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		ret, err := repo.CreateAlunoCurso(alunoCurso)
+		if err != nil {
+			return err
+		}
 
-	var allItemModulos []entity.ItemModulo
-	for _, modulo := range modulos {
-		items, err := c.CursoRepository.FindItemModulosByModulo(modulo.ID)
+		// Busca todos os ItemModulo do curso para criar os AlunoCursoItemModulo
+		modulos, err := repo.GetModulosDeCurso(cursoID)
 		if err != nil {
-			return dto.AlunoCursoOutputDTO{}, err
+			return err
 		}
-		allItemModulos = append(allItemModulos, items...)
-	}
 
-	// Monta todos os AlunoCursoItemModulo com status inicial
-	var itensToCreate []*entity.AlunoCursoItemModulo
-	now := time.Now()
-	for _, item := range allItemModulos {
-		itensToCreate = append(itensToCreate, &entity.AlunoCursoItemModulo{
-			ID:           uuid.New(),
-			AlunoCursoID: ret.ID,
-			ItemModuloID: item.ID,
-			Status:       entity.TipoStatusItemModuloNaoIniciado,
-			Progresso:    0,
-			CreatedAt:    now,
-			UpdatedAt:    now,
-		})
-	}
+		var allItemModulos []entity.ItemModulo
+		for _, modulo := range modulos {
+			items, err := repo.FindItemModulosByModulo(modulo.ID)
+			if err != nil {
+				return err
+			}
+			allItemModulos = append(allItemModulos, items...)
+		}
 
-	if len(itensToCreate) > 0 {
-		err = c.CursoRepository.CreateAlunoCursoItemModulosBatch(itensToCreate)
+		// Monta todos os AlunoCursoItemModulo com status inicial
+		var itensToCreate []*entity.AlunoCursoItemModulo
+		now := time.Now()
+		for _, item := range allItemModulos {
+			novoItem := &entity.AlunoCursoItemModulo{
+				ID:           uuid.New(),
+				AlunoCursoID: ret.ID,
+				ItemModuloID: item.ID,
+				Status:       entity.TipoStatusItemModuloNaoIniciado,
+				Progresso:    0,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+			if item.Tipo == entity.ItemContractValidate {
+				novoItem.StatusValidacaoContrato = entity.TipoStatusValidacaoContratoPendente
+				if item.ContractValidation != nil {
+					novoItem.BlockchainRedeValidacao = string(item.ContractValidation.Rede)
+				}
+			}
+			itensToCreate = append(itensToCreate, novoItem)
+		}
+
+		if len(itensToCreate) > 0 {
+			err = repo.CreateAlunoCursoItemModulosBatch(itensToCreate)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Calcula o XP disponível da matrícula
+		_, err = c.recalcularProgressoAlunoCurso(repo, ret.ID)
 		if err != nil {
-			return dto.AlunoCursoOutputDTO{}, err
+			return err
 		}
-	}
 
-	// Retorna DTO
-	savedObj, err := c.CursoRepository.GetAlunoCurso(ret.ID)
+		out_dto, evt, err = c.addAlunoCursoSavedToOutbox(ctx, repo, ret.ID)
+		return err
+	})
 	if err != nil {
 		return dto.AlunoCursoOutputDTO{}, err
 	}
 
-	dto := dto.AlunoCursoOutputDTO{
-		ID:                  savedObj.ID,
-		CursoID:             savedObj.CursoID,
-		AlunoID:             savedObj.AlunoID,
-		CreatedAt:           savedObj.CreatedAt,
-		UpdatedAt:           savedObj.UpdatedAt,
-		AlunoNome:           savedObj.Aluno.Nome(),
-		CursoNome:           savedObj.Curso.Nome,
-		CursoDescricao:      savedObj.Curso.Descricao,
-		DataMatricula:       savedObj.DataMatricula,
-		PercentualConcluido: savedObj.PercentualConcluido,
-		StatusCurso:         savedObj.StatusCurso,
-		StatusPagamento:     savedObj.StatusPagamento,
+	err = c.dispatchEvent(ctx, evt)
+	if err != nil {
+		return dto.AlunoCursoOutputDTO{}, err
 	}
 
-	return dto, nil
+	return out_dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteUpdateAlunoCurso(obj_id string, input dto.AlunoCursoInputDTO) (dto.AlunoCursoOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteUpdateAlunoCurso(ctx context.Context, obj_id string, input dto.AlunoCursoInputDTO) (dto.AlunoCursoOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteUpdateAlunoCurso")
+	defer span.End()
+
 	obj_uuid, err := uuid.Parse(obj_id)
 	if err != nil {
 		return dto.AlunoCursoOutputDTO{}, err
@@ -681,47 +800,75 @@ func (c *SaveCursoUseCase) ExecuteUpdateAlunoCurso(obj_id string, input dto.Alun
 		return dto.AlunoCursoOutputDTO{}, err
 	}
 
-	ret, err := c.CursoRepository.UpdateAlunoCurso(alunocurso)
+	var out_dto dto.AlunoCursoOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		ret, err := repo.UpdateAlunoCurso(alunocurso)
+		if err != nil {
+			return err
+		}
+
+		out_dto, evt, err = c.addAlunoCursoSavedToOutbox(ctx, repo, ret.ID)
+		return err
+	})
 	if err != nil {
 		return dto.AlunoCursoOutputDTO{}, err
 	}
 
-	saved_obj, err := c.CursoRepository.GetAlunoCurso(ret.ID)
+	err = c.dispatchEvent(ctx, evt)
 	if err != nil {
 		return dto.AlunoCursoOutputDTO{}, err
 	}
 
-	dto := dto.AlunoCursoOutputDTO{
-		ID:                  saved_obj.ID,
-		CursoID:             saved_obj.CursoID,
-		AlunoID:             saved_obj.AlunoID,
-		CreatedAt:           saved_obj.CreatedAt,
-		UpdatedAt:           saved_obj.UpdatedAt,
-		AlunoNome:           saved_obj.Aluno.Nome(),
-		CursoNome:           saved_obj.Curso.Nome,
-		CursoDescricao:      saved_obj.Curso.Descricao,
-		DataMatricula:       saved_obj.DataMatricula,
-		PercentualConcluido: saved_obj.PercentualConcluido,
-		StatusCurso:         saved_obj.StatusCurso,
-		StatusPagamento:     saved_obj.StatusPagamento,
-	}
-
-	return dto, nil
+	return out_dto, nil
 }
-func (c *SaveCursoUseCase) ExecuteDeleteAlunoCurso(obj_id string) error {
+func (c *SaveCursoUseCase) ExecuteDeleteAlunoCurso(ctx context.Context, obj_id string) error {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteDeleteAlunoCurso")
+	defer span.End()
+
 	obj_uuid, err := uuid.Parse(obj_id)
 	if err != nil {
 		return err
 	}
 
-	err = c.CursoRepository.DeleteAlunoCurso(obj_uuid)
+	var evt, evtAluno event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		saved_obj, err := repo.GetAlunoCursoForUpdate(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		err = repo.DeleteAlunoCurso(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		// o XP ganho na matrícula excluída deixa de contar no total do aluno
+		if saved_obj.XpGanho != 0 {
+			err = repo.AddXpAluno(saved_obj.AlunoID, -saved_obj.XpGanho)
+			if err != nil {
+				return err
+			}
+		}
+
+		evt = domain_event.NewAlunoCursoDeleted(ctx, deletedOutputDTO(obj_uuid, alunoCursoOutputDTO(saved_obj)))
+		err = c.addToOutbox(ctx, repo, evt)
+		if err != nil {
+			return err
+		}
+
+		evtAluno, err = c.aplicarExclusaoPendente(ctx, repo, saved_obj.AlunoID)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	//to-do - aqui precisa disparar um evento para o log
-
-	return nil
+	err = c.dispatchEvent(ctx, evt)
+	if err != nil || evtAluno == nil {
+		return err
+	}
+	return c.dispatchEvent(ctx, evtAluno)
 }
 func (c *SaveCursoUseCase) ExecuteGetAlunoCurso(obj_id string) (dto.AlunoCursoOutputDTO, error) {
 	obj_uuid, err := uuid.Parse(obj_id)
@@ -747,6 +894,8 @@ func (c *SaveCursoUseCase) ExecuteGetAlunoCurso(obj_id string) (dto.AlunoCursoOu
 		PercentualConcluido: saved_obj.PercentualConcluido,
 		StatusCurso:         saved_obj.StatusCurso,
 		StatusPagamento:     saved_obj.StatusPagamento,
+		XpGanho:             saved_obj.XpGanho,
+		XpDisponivel:        saved_obj.XpDisponivel,
 	}
 
 	return dto, nil
@@ -777,6 +926,8 @@ func (c *SaveCursoUseCase) ExecuteGetAlunosDoCurso(parent_id string) ([]dto.Alun
 			PercentualConcluido: saved_obj.PercentualConcluido,
 			StatusCurso:         saved_obj.StatusCurso,
 			StatusPagamento:     saved_obj.StatusPagamento,
+			XpGanho:             saved_obj.XpGanho,
+			XpDisponivel:        saved_obj.XpDisponivel,
 		}
 
 		dtos = append(dtos, dto)
@@ -810,21 +961,22 @@ func (c *SaveCursoUseCase) ExecuteGetCursosDoAluno(parent_id string) ([]dto.Alun
 			PercentualConcluido: saved_obj.PercentualConcluido,
 			StatusCurso:         saved_obj.StatusCurso,
 			StatusPagamento:     saved_obj.StatusPagamento,
+			XpGanho:             saved_obj.XpGanho,
+			XpDisponivel:        saved_obj.XpDisponivel,
 		}
 		dtos = append(dtos, dto)
 	}
 
 	return dtos, nil
 }
-func (c *SaveCursoUseCase) ExecuteGetAlunoCursos(page, limit int, sort string) ([]dto.AlunoCursoOutputDTO, error) {
-	saved_objs, err := c.CursoRepository.FindAllAlunoCursos(page, limit, sort)
+func (c *SaveCursoUseCase) ExecuteGetAlunoCursos(spec query.Spec) (query.Page[dto.AlunoCursoOutputDTO], error) {
+	saved_objs, err := c.CursoRepository.FindAllAlunoCursos(spec)
 	if err != nil {
-		return []dto.AlunoCursoOutputDTO{}, err
+		return query.Page[dto.AlunoCursoOutputDTO]{}, err
 	}
 
-	var dtos []dto.AlunoCursoOutputDTO
-	for _, saved_obj := range saved_objs {
-		dto := dto.AlunoCursoOutputDTO{
+	return query.Map(saved_objs, func(saved_obj entity.AlunoCurso) dto.AlunoCursoOutputDTO {
+		return dto.AlunoCursoOutputDTO{
 			ID:                  saved_obj.ID,
 			CursoID:             saved_obj.CursoID,
 			AlunoID:             saved_obj.AlunoID,
@@ -837,18 +989,20 @@ func (c *SaveCursoUseCase) ExecuteGetAlunoCursos(page, limit int, sort string) (
 			PercentualConcluido: saved_obj.PercentualConcluido,
 			StatusCurso:         saved_obj.StatusCurso,
 			StatusPagamento:     saved_obj.StatusPagamento,
+			XpGanho:             saved_obj.XpGanho,
+			XpDisponivel:        saved_obj.XpDisponivel,
 		}
-		dtos = append(dtos, dto)
-	}
-
-	return dtos, nil
+	}), nil
 }
 
 // endregion
 
 // region cadastro de ItemModulo
 
-func (c *SaveCursoUseCase) ExecuteCreateItemModulo(input dto.ItemModuloInputDTO) (dto.ItemModuloOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteCreateItemModulo(ctx context.Context, input dto.ItemModuloInputDTO) (dto.ItemModuloOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteCreateItemModulo")
+	defer span.End()
+
 	moduloID, err := uuid.Parse(input.ModuloID)
 	if err != nil {
 		return dto.ItemModuloOutputDTO{}, err
@@ -897,12 +1051,28 @@ func (c *SaveCursoUseCase) ExecuteCreateItemModulo(input dto.ItemModuloInputDTO)
 		}
 	}
 
-	err = c.CursoRepository.CreateItemModulo(item)
+	var out_dto dto.ItemModuloOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		err := repo.CreateItemModulo(item)
+		if err != nil {
+			return err
+		}
+
+		out_dto = toOutputDTO(item)
+		evt = domain_event.NewItemModuloChanged(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return dto.ItemModuloOutputDTO{}, err
 	}
 
-	return toOutputDTO(item), nil
+	err = c.dispatchEvent(ctx, evt)
+	if err != nil {
+		return dto.ItemModuloOutputDTO{}, err
+	}
+
+	return out_dto, nil
 }
 
 func (c *SaveCursoUseCase) ExecuteFindItemModuloByID(obj_id string) (dto.ItemModuloOutputDTO, error) {
@@ -933,7 +1103,10 @@ func (c *SaveCursoUseCase) ExecuteFindItemModulosByModulo(parent_id string) ([]d
 	return output, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteUpdateItemModulo(obj_id string, input dto.ItemModuloInputDTO) (dto.ItemModuloOutputDTO, error) {
+func (c *SaveCursoUseCase) ExecuteUpdateItemModulo(ctx context.Context, obj_id string, input dto.ItemModuloInputDTO) (dto.ItemModuloOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteUpdateItemModulo")
+	defer span.End()
+
 	itemID, err := uuid.Parse(obj_id)
 	if err != nil {
 		return dto.ItemModuloOutputDTO{}, err
@@ -974,20 +1147,59 @@ func (c *SaveCursoUseCase) ExecuteUpdateItemModulo(obj_id string, input dto.Item
 		}
 	}
 
-	err = c.CursoRepository.UpdateItemModulo(item)
+	var out_dto dto.ItemModuloOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		err := repo.UpdateItemModulo(item)
+		if err != nil {
+			return err
+		}
+
+		out_dto = toOutputDTO(item)
+		evt = domain_event.NewItemModuloChanged(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return dto.ItemModuloOutputDTO{}, err
 	}
 
-	return toOutputDTO(item), nil
+	err = c.dispatchEvent(ctx, evt)
+	if err != nil {
+		return dto.ItemModuloOutputDTO{}, err
+	}
+
+	return out_dto, nil
 }
 
-func (c *SaveCursoUseCase) ExecuteDeleteItemModulo(obj_id string) error {
+func (c *SaveCursoUseCase) ExecuteDeleteItemModulo(ctx context.Context, obj_id string) error {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteDeleteItemModulo")
+	defer span.End()
+
 	itemID, err := uuid.Parse(obj_id)
 	if err != nil {
 		return err
 	}
-	return c.CursoRepository.DeleteItemModulo(itemID)
+
+	var evt event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		item, err := repo.FindItemModuloByID(itemID)
+		if err != nil {
+			return err
+		}
+
+		err = repo.DeleteItemModulo(itemID)
+		if err != nil {
+			return err
+		}
+
+		evt = domain_event.NewItemModuloDeleted(ctx, deletedOutputDTO(itemID, toOutputDTO(item)))
+		return c.addToOutbox(ctx, repo, evt)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.dispatchEvent(ctx, evt)
 }
 
 func (c *SaveCursoUseCase) ExecuteMoveItemModulo(id uuid.UUID, action string) error {
@@ -1027,56 +1239,153 @@ func toOutputDTO(item *entity.ItemModulo) dto.ItemModuloOutputDTO {
 // endregion
 
 // region cadastro de Pessoa
-func (c *SaveCursoUseCase) ExecuteCreateOrUpdatePessoa(input dto.PessoaInputDTO) (dto.PessoaOutputDTO, error) {
+
+// ExecuteExcluirPessoa aplica na réplica local o evento pessoa.deleted, conforme a política:
+// desativa os alunos da pessoa, desativa e anonimiza, ou (bloquear) mantém tudo intacto enquanto
+// algum aluno da pessoa tiver matrícula ativa. Em todos os casos a data da exclusão é registrada;
+// na política bloquear ela fica pendente e cada aluno é desativado quando a sua última matrícula
+// ativa termina (ver aplicarExclusaoPendente).
+func (c *SaveCursoUseCase) ExecuteExcluirPessoa(ctx context.Context, input dto.PessoaDeletedInputDTO, politica entity.PoliticaExclusaoPessoa) (entity.ResultadoExclusaoPessoa, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteExcluirPessoa")
+	defer span.End()
+
 	id, err := uuid.Parse(input.ID)
 	if err != nil {
-		return dto.PessoaOutputDTO{}, err
+		return "", err
 	}
 
-	//print o nome
-	fmt.Printf("Saving Pessoa - input.Nome: %s", input.Nome)
-
-	pessoa, err := entity.NewPessoa(
-		&id,
-		entity.TipoPessoa(input.Tipo),
-		input.Nome,
-	)
+	pessoa, err := c.PessoaRepository.GetPessoa(id)
 	if err != nil {
-		return dto.PessoaOutputDTO{}, err
+		// pessoa nunca replicada no curso
+		return entity.ResultadoExclusaoIgnorada, nil
 	}
 
-	// seta created_at e updated_at
-	pessoa.CreatedAt = time.Now()
-	pessoa.UpdatedAt = time.Now()
+	deletedAt := input.DeletedAt
+	if deletedAt.IsZero() {
+		deletedAt = time.Now()
+	}
+	pessoa.MarcarExclusao(deletedAt)
 
-	var ret *entity.Pessoa
-	p, err := c.PessoaRepository.GetPessoa(pessoa.ID)
-	if err != nil {
-		//vai criar
-		ret, err = c.PessoaRepository.CreatePessoa(pessoa)
+	// a réplica da pessoa e os alunos são gravados juntos: uma falha não deixa a pessoa
+	// anonimizada com alunos ainda ativos (ou o contrário)
+	var resultado entity.ResultadoExclusaoPessoa
+	var eventos []event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		alunos, err := repo.FindAlunosByPessoa(id)
 		if err != nil {
-			return dto.PessoaOutputDTO{}, err
+			return err
+		}
+
+		if politica == entity.PoliticaExclusaoBloquear {
+			for _, aluno := range alunos {
+				ativas, err := repo.CountMatriculasAtivasDoAluno(aluno.ID)
+				if err != nil {
+					return err
+				}
+				if ativas > 0 {
+					resultado = entity.ResultadoExclusaoBloqueada
+					_, err = repo.UpdatePessoa(pessoa)
+					return err
+				}
+			}
+		}
+
+		resultado = entity.ResultadoExclusaoDesativada
+		if politica == entity.PoliticaExclusaoAnonimizar {
+			pessoa.Anonimizar()
+			resultado = entity.ResultadoExclusaoAnonimizada
+		}
+		_, err = repo.UpdatePessoa(pessoa)
+		if err != nil {
+			return err
+		}
+
+		for i := range alunos {
+			aluno := &alunos[i]
+			aluno.Desativar()
+			if politica == entity.PoliticaExclusaoAnonimizar {
+				aluno.Anonimizar()
+			}
+			err := repo.UpdateAlunoStatusWallet(aluno)
+			if err != nil {
+				return err
+			}
+
+			_, evt, err := c.addAlunoSavedToOutbox(ctx, repo, aluno.ID)
+			if err != nil {
+				return err
+			}
+			eventos = append(eventos, evt)
 		}
-	} else {
-		//vai atualizar
-		pessoa.CreatedAt = p.CreatedAt
-		ret, err = c.PessoaRepository.UpdatePessoa(pessoa)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, evt := range eventos {
+		err = c.dispatchEvent(ctx, evt)
 		if err != nil {
-			return dto.PessoaOutputDTO{}, err
+			return resultado, err
 		}
 	}
 
-	saved_obj, err := c.PessoaRepository.GetPessoa(ret.ID)
+	return resultado, nil
+}
+
+// aplicarExclusaoPendente conclui a exclusão de uma pessoa bloqueada pela política bloquear:
+// quando a pessoa do aluno já foi excluída na origem e o aluno não tem mais matrículas ativas,
+// o aluno é desativado na transação corrente. Retorna o evento AlunoChanged a ser despachado
+// depois do commit, ou nil se não havia exclusão pendente.
+func (c *SaveCursoUseCase) aplicarExclusaoPendente(ctx context.Context, repo repository.CursoRepositoryInterface, alunoID uuid.UUID) (event_dispatcher.EventInterface, error) {
+	aluno, err := repo.GetAluno(alunoID)
 	if err != nil {
-		return dto.PessoaOutputDTO{}, err
+		return nil, err
+	}
+	if aluno.Pessoa.ExclusaoSolicitadaEm == nil || aluno.StatusAluno != entity.StatusAlunoAtivo {
+		return nil, nil
 	}
 
-	out_dto := dto.PessoaOutputDTO{
-		ID:   saved_obj.ID,
-		Tipo: string(saved_obj.Tipo),
-		Nome: saved_obj.Nome,
+	ativas, err := repo.CountMatriculasAtivasDoAluno(aluno.ID)
+	if err != nil || ativas > 0 {
+		return nil, err
+	}
+
+	aluno.Desativar()
+	err = repo.UpdateAlunoStatusWallet(aluno)
+	if err != nil {
+		return nil, err
+	}
+	_, evt, err := c.addAlunoSavedToOutbox(ctx, repo, aluno.ID)
+	return evt, err
+}
+
+// addAlunoSavedToOutbox grava no outbox da transação o evento AlunoChanged com o aluno atualizado.
+func (c *SaveCursoUseCase) addAlunoSavedToOutbox(ctx context.Context, repo repository.CursoRepositoryInterface, alunoID uuid.UUID) (dto.AlunoOutputDTO, event_dispatcher.EventInterface, error) {
+	saved_obj, err := repo.GetAluno(alunoID)
+	if err != nil {
+		return dto.AlunoOutputDTO{}, nil, err
+	}
+
+	out_dto := alunoOutputDTO(saved_obj)
+	evt := domain_event.NewAlunoChanged(ctx, out_dto)
+	return out_dto, evt, c.addToOutbox(ctx, repo, evt)
+}
+
+func alunoOutputDTO(saved_obj *entity.Aluno) dto.AlunoOutputDTO {
+	return dto.AlunoOutputDTO{
+		ID:          saved_obj.ID,
+		CreatedAt:   saved_obj.CreatedAt,
+		UpdatedAt:   saved_obj.UpdatedAt,
+		PessoaID:    saved_obj.PessoaID,
+		Nome:        saved_obj.Nome(),
+		Wallet:      saved_obj.Wallet,
+		TipoPessoa:  saved_obj.TipoPessoa(),
+		DataInicio:  saved_obj.DataInicio,
+		XpTotal:     saved_obj.XpTotal,
+		NftId:       saved_obj.NftId,
+		StatusAluno: saved_obj.StatusAluno,
 	}
-	return out_dto, nil
 }
 
 // endregion
@@ -1166,47 +1475,88 @@ func (c *SaveCursoUseCase) ExecuteGetAlunoCursoItemModulo(id string) (dto.AlunoC
 }
 
 // ExecuteUpdateAlunoCursoItemModulo atualiza campos do AlunoCursoItemModulo
-func (c *SaveCursoUseCase) ExecuteUpdateAlunoCursoItemModulo(id string, input dto.AlunoCursoItemModuloUpdateDTO) (dto.AlunoCursoItemModuloResponseDTO, error) {
+func (c *SaveCursoUseCase) ExecuteUpdateAlunoCursoItemModulo(ctx context.Context, id string, input dto.AlunoCursoItemModuloUpdateDTO) (dto.AlunoCursoItemModuloResponseDTO, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteUpdateAlunoCursoItemModulo")
+	defer span.End()
+
 	itemID, err := uuid.Parse(id)
 	if err != nil {
 		return dto.AlunoCursoItemModuloResponseDTO{}, err
 	}
 
-	item, err := c.CursoRepository.GetAlunoCursoItemModulo(itemID)
+	var item *entity.AlunoCursoItemModulo
+	var evt, evtAluno event_dispatcher.EventInterface
+	err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+		item, err = repo.GetAlunoCursoItemModulo(itemID)
+		if err != nil {
+			return err
+		}
+
+		// Aplicar apenas os campos não-nulos
+		if input.Status != nil {
+			item.Status = *input.Status
+		}
+		if input.Progresso != nil {
+			item.Progresso = *input.Progresso
+		}
+		if input.TempoAssistido != nil {
+			item.TempoAssistido = *input.TempoAssistido
+		}
+		if input.EnderecoContratoValidar != nil {
+			// novo contrato informado: volta a aguardar o evento on-chain
+			if *input.EnderecoContratoValidar != item.EnderecoContratoValidar && item.TipoItemModulo() == entity.ItemContractValidate {
+				item.StatusValidacaoContrato = entity.TipoStatusValidacaoContratoPendente
+			}
+			item.EnderecoContratoValidar = *input.EnderecoContratoValidar
+		}
+		if input.BlockchainRedeValidacao != nil {
+			item.BlockchainRedeValidacao = *input.BlockchainRedeValidacao
+		}
+		if input.BlockchainTxEnvio != nil {
+			// nova transação enviada: volta a aguardar o evento on-chain
+			if *input.BlockchainTxEnvio != item.BlockchainTxEnvio && item.TipoItemModulo() == entity.ItemContractValidate {
+				item.StatusValidacaoContrato = entity.TipoStatusValidacaoContratoPendente
+			}
+			item.BlockchainTxEnvio = *input.BlockchainTxEnvio
+		}
+		if input.StatusValidacaoContrato != nil {
+			item.StatusValidacaoContrato = *input.StatusValidacaoContrato
+		}
+
+		// Atualiza timestamp
+		item.UpdatedAt = time.Now()
+
+		err = repo.UpdateAlunoCursoItemModulo(item)
+		if err != nil {
+			return err
+		}
+
+		alunoCurso, err := c.recalcularProgressoAlunoCurso(repo, item.AlunoCursoID)
+		if err != nil {
+			return err
+		}
+
+		_, evt, err = c.addAlunoCursoSavedToOutbox(ctx, repo, alunoCurso.ID)
+		if err != nil || alunoCurso.Ativa() {
+			return err
+		}
+		evtAluno, err = c.aplicarExclusaoPendente(ctx, repo, alunoCurso.AlunoID)
+		return err
+	})
 	if err != nil {
 		return dto.AlunoCursoItemModuloResponseDTO{}, err
 	}
 
-	// Aplicar apenas os campos não-nulos
-	if input.Status != nil {
-		item.Status = *input.Status
-	}
-	if input.Progresso != nil {
-		item.Progresso = *input.Progresso
-	}
-	if input.TempoAssistido != nil {
-		item.TempoAssistido = *input.TempoAssistido
-	}
-	if input.EnderecoContratoValidar != nil {
-		item.EnderecoContratoValidar = *input.EnderecoContratoValidar
-	}
-	if input.BlockchainRedeValidacao != nil {
-		item.BlockchainRedeValidacao = *input.BlockchainRedeValidacao
-	}
-	if input.BlockchainTxEnvio != nil {
-		item.BlockchainTxEnvio = *input.BlockchainTxEnvio
-	}
-	if input.StatusValidacaoContrato != nil {
-		item.StatusValidacaoContrato = *input.StatusValidacaoContrato
-	}
-
-	// Atualiza timestamp
-	item.UpdatedAt = time.Now()
-
-	err = c.CursoRepository.UpdateAlunoCursoItemModulo(item)
+	err = c.dispatchEvent(ctx, evt)
 	if err != nil {
 		return dto.AlunoCursoItemModuloResponseDTO{}, err
 	}
+	if evtAluno != nil {
+		err = c.dispatchEvent(ctx, evtAluno)
+		if err != nil {
+			return dto.AlunoCursoItemModuloResponseDTO{}, err
+		}
+	}
 
 	output := dto.AlunoCursoItemModuloResponseDTO{
 		ID:                      item.ID,
@@ -1228,4 +1578,277 @@ func (c *SaveCursoUseCase) ExecuteUpdateAlunoCursoItemModulo(id string, input dt
 	return output, nil
 }
 
+// recalcularProgressoAlunoCurso aplica o motor de progresso na matrícula
+// e soma ao XpTotal do aluno a variação de XP ganho. A matrícula fica travada até o
+// commit, senão duas atualizações simultâneas somariam a mesma variação duas vezes.
+func (c *SaveCursoUseCase) recalcularProgressoAlunoCurso(repo repository.CursoRepositoryInterface, alunoCursoID uuid.UUID) (*entity.AlunoCurso, error) {
+	alunoCurso, err := repo.GetAlunoCursoForUpdate(alunoCursoID)
+	if err != nil {
+		return nil, err
+	}
+
+	itens, err := repo.FindItemModulosByAlunoCurso(alunoCursoID)
+	if err != nil {
+		return nil, err
+	}
+
+	deltaXp := alunoCurso.RecalcularProgresso(itens)
+	err = repo.UpdateAlunoCursoProgresso(alunoCurso)
+	if err != nil {
+		return nil, err
+	}
+
+	if deltaXp != 0 {
+		err = repo.AddXpAluno(alunoCurso.AlunoID, deltaXp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return alunoCurso, nil
+}
+
+// addAlunoCursoSavedToOutbox grava no outbox da transação o evento AlunoCursoChanged com a matrícula atualizada.
+func (c *SaveCursoUseCase) addAlunoCursoSavedToOutbox(ctx context.Context, repo repository.CursoRepositoryInterface, alunoCursoID uuid.UUID) (dto.AlunoCursoOutputDTO, event_dispatcher.EventInterface, error) {
+	saved_obj, err := repo.GetAlunoCurso(alunoCursoID)
+	if err != nil {
+		return dto.AlunoCursoOutputDTO{}, nil, err
+	}
+
+	out_dto := alunoCursoOutputDTO(saved_obj)
+	evt := domain_event.NewAlunoCursoChanged(ctx, out_dto)
+	return out_dto, evt, c.addToOutbox(ctx, repo, evt)
+}
+
+func alunoCursoOutputDTO(saved_obj *entity.AlunoCurso) dto.AlunoCursoOutputDTO {
+	return dto.AlunoCursoOutputDTO{
+		ID:                  saved_obj.ID,
+		CursoID:             saved_obj.CursoID,
+		AlunoID:             saved_obj.AlunoID,
+		CreatedAt:           saved_obj.CreatedAt,
+		UpdatedAt:           saved_obj.UpdatedAt,
+		AlunoNome:           saved_obj.Aluno.Nome(),
+		CursoNome:           saved_obj.Curso.Nome,
+		CursoDescricao:      saved_obj.Curso.Descricao,
+		DataMatricula:       saved_obj.DataMatricula,
+		PercentualConcluido: saved_obj.PercentualConcluido,
+		StatusCurso:         saved_obj.StatusCurso,
+		StatusPagamento:     saved_obj.StatusPagamento,
+		XpGanho:             saved_obj.XpGanho,
+		XpDisponivel:        saved_obj.XpDisponivel,
+	}
+}
+
+// endregion
+
+// region validação de contrato por eventos on-chain
+
+// ExecuteValidarContratoPorEvento aplica um evento publicado pelo eth-listener nos itens de
+// validação de contrato pendentes ou com erro. O item é encontrado pela transação de envio
+// informada pelo aluno ou pelo endereço do contrato do aluno presente nos dados do evento.
+// Eventos emitidos por outro contrato que não o validador do item são ignorados. A validação é
+// concluída quando o evento referencia o contrato do aluno; se a transação informada pelo aluno
+// não o referencia, o item é marcado com erro. Retorna quantos itens foram atualizados.
+func (c *SaveCursoUseCase) ExecuteValidarContratoPorEvento(ctx context.Context, input dto.EthEventInputDTO, payload string) (int, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteValidarContratoPorEvento")
+	defer span.End()
+
+	if input.TxHash == "" {
+		return 0, errors.New("evento sem tx hash")
+	}
+
+	enderecos := enderecosDoEvento(input.Dados)
+	itens, err := c.CursoRepository.FindValidacoesContratoPendentes(input.TxHash, enderecos)
+	if err != nil {
+		return 0, err
+	}
+
+	atualizados := 0
+	for i := range itens {
+		item := &itens[i]
+
+		// o aluno informou outra transação: o evento não é dele (se a transação informada já
+		// falhou, vale qualquer evento que referencie o contrato do aluno)
+		if item.BlockchainTxEnvio != "" && !strings.EqualFold(item.BlockchainTxEnvio, input.TxHash) &&
+			item.StatusValidacaoContrato != entity.TipoStatusValidacaoContratoErro {
+			continue
+		}
+		if !redeDoEventoConfere(item, input.Rede) {
+			continue
+		}
+
+		resultado, ok := resultadoValidacaoContrato(item, input.Contrato, enderecos)
+		if !ok {
+			continue
+		}
+		var evt, evtAluno event_dispatcher.EventInterface
+		err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+			item.StatusValidacaoContrato = resultado
+			item.BlockchainTxEnvio = input.TxHash
+			item.UpdatedAt = time.Now()
+			if resultado == entity.TipoStatusValidacaoContratoConcluido {
+				item.Status = entity.TipoStatusItemModuloConcluido
+				item.Progresso = 100
+			}
+			err := repo.UpdateAlunoCursoItemModulo(item)
+			if err != nil {
+				return err
+			}
+
+			err = repo.CreateValidacaoContratoEvento(entity.NewValidacaoContratoEvento(
+				item.ID, input.TxHash, input.Contrato, input.Evento, input.Bloco, resultado, payload))
+			if err != nil {
+				return err
+			}
+
+			alunoCurso, err := c.recalcularProgressoAlunoCurso(repo, item.AlunoCursoID)
+			if err != nil {
+				return err
+			}
+
+			_, evt, err = c.addAlunoCursoSavedToOutbox(ctx, repo, alunoCurso.ID)
+			if err != nil || alunoCurso.Ativa() {
+				return err
+			}
+			evtAluno, err = c.aplicarExclusaoPendente(ctx, repo, alunoCurso.AlunoID)
+			return err
+		})
+		if err != nil {
+			return atualizados, err
+		}
+		atualizados++
+
+		err = c.dispatchEvent(ctx, evt)
+		if err != nil {
+			return atualizados, err
+		}
+		if evtAluno != nil {
+			err = c.dispatchEvent(ctx, evtAluno)
+			if err != nil {
+				return atualizados, err
+			}
+		}
+	}
+
+	return atualizados, nil
+}
+
+// ExecuteReverterValidacaoContratoPorEvento desfaz as validações feitas por um evento que foi
+// removido da cadeia por uma reorganização: os itens voltam para validação pendente e o
+// progresso e o XP das matrículas são recalculados. Retorna quantos itens foram revertidos.
+func (c *SaveCursoUseCase) ExecuteReverterValidacaoContratoPorEvento(ctx context.Context, input dto.EthEventInputDTO, payload string) (int, error) {
+	ctx, span := tracing.Start(ctx, "SaveCursoUseCase.ExecuteReverterValidacaoContratoPorEvento")
+	defer span.End()
+
+	if input.TxHash == "" {
+		return 0, errors.New("evento sem tx hash")
+	}
+
+	itens, err := c.CursoRepository.FindValidacoesContratoPorTx(input.TxHash)
+	if err != nil {
+		return 0, err
+	}
+
+	revertidos := 0
+	for i := range itens {
+		item := &itens[i]
+		if !redeDoEventoConfere(item, input.Rede) {
+			continue
+		}
+
+		var evt event_dispatcher.EventInterface
+		err = c.CursoRepository.WithTransaction(ctx, func(repo repository.CursoRepositoryInterface) error {
+			item.ReverterValidacaoContrato()
+			err := repo.ResetValidacaoContrato(item)
+			if err != nil {
+				return err
+			}
+
+			err = repo.CreateValidacaoContratoEvento(entity.NewValidacaoContratoEvento(
+				item.ID, input.TxHash, input.Contrato, input.Evento, input.Bloco, item.StatusValidacaoContrato, payload))
+			if err != nil {
+				return err
+			}
+
+			matricula, err := repo.GetAlunoCurso(item.AlunoCursoID)
+			if err != nil {
+				return err
+			}
+			matricula.Reabrir()
+			err = repo.UpdateAlunoCursoProgresso(matricula)
+			if err != nil {
+				return err
+			}
+
+			alunoCurso, err := c.recalcularProgressoAlunoCurso(repo, item.AlunoCursoID)
+			if err != nil {
+				return err
+			}
+
+			_, evt, err = c.addAlunoCursoSavedToOutbox(ctx, repo, alunoCurso.ID)
+			return err
+		})
+		if err != nil {
+			return revertidos, err
+		}
+		revertidos++
+
+		err = c.dispatchEvent(ctx, evt)
+		if err != nil {
+			return revertidos, err
+		}
+	}
+
+	return revertidos, nil
+}
+
+// resultadoValidacaoContrato decide se o evento valida o item ou se deve ser marcado como erro.
+// Retorna false quando o evento foi emitido por outro contrato que não o validador do item: com
+// vários contratos monitorados, esse evento não diz respeito ao item, que continua aguardando.
+func resultadoValidacaoContrato(item *entity.AlunoCursoItemModulo, contratoEvento string, enderecos []string) (entity.TipoStatusValidacaoContrato, bool) {
+	if cv := item.ItemModulo.ContractValidation; cv != nil && cv.EnderecoContrato != "" && contratoEvento != "" {
+		validador, _ := entity.NormalizarEndereco(cv.EnderecoContrato)
+		emissor, _ := entity.NormalizarEndereco(contratoEvento)
+		if validador != emissor {
+			return "", false
+		}
+	}
+
+	if item.EnderecoContratoValidar != "" {
+		esperado, _ := entity.NormalizarEndereco(item.EnderecoContratoValidar)
+		for _, endereco := range enderecos {
+			if endereco == esperado {
+				return entity.TipoStatusValidacaoContratoConcluido, true
+			}
+		}
+		return entity.TipoStatusValidacaoContratoErro, true
+	}
+
+	return entity.TipoStatusValidacaoContratoConcluido, true
+}
+
+// redeDoEventoConfere informa se o evento foi emitido na rede em que o item deve ser validado.
+// Eventos sem rede (versões antigas do eth-listener) e itens sem rede definida são aceitos.
+func redeDoEventoConfere(item *entity.AlunoCursoItemModulo, rede string) bool {
+	redeItem := item.BlockchainRedeValidacao
+	if redeItem == "" && item.ItemModulo.ContractValidation != nil {
+		redeItem = string(item.ItemModulo.ContractValidation.Rede)
+	}
+	return rede == "" || redeItem == "" || strings.EqualFold(redeItem, rede)
+}
+
+// enderecosDoEvento extrai, normalizados, os endereços presentes nos dados decodificados do evento.
+func enderecosDoEvento(dados map[string]interface{}) []string {
+	enderecos := []string{}
+	for _, valor := range dados {
+		str, ok := valor.(string)
+		if !ok {
+			continue
+		}
+		if endereco, ok := entity.NormalizarEndereco(str); ok {
+			enderecos = append(enderecos, endereco)
+		}
+	}
+	return enderecos
+}
+
 // endregion
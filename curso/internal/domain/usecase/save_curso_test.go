@@ -0,0 +1,297 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	domain_event "github.com/ggialluisi/nebula-back/curso/internal/domain/event"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/usecase"
+	database "github.com/ggialluisi/nebula-back/curso/internal/infra/database/gorm"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newSaveCursoUseCase monta o caso de uso sobre os repositórios GORM num SQLite em memória.
+func newSaveCursoUseCase(t *testing.T) (*gorm.DB, *usecase.SaveCursoUseCase) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.AutoMigrate(
+		&entity.Pessoa{},
+		&entity.Curso{},
+		&entity.Modulo{},
+		&entity.Aluno{},
+		&entity.AlunoCurso{},
+		&entity.ItemModulo{},
+		&entity.ItemModuloAula{},
+		&entity.ItemModuloContractValidation{},
+		&entity.ItemModuloVideo{},
+		&entity.AlunoCursoItemModulo{},
+		&entity.ValidacaoContratoEvento{},
+		&entity.OutboxEvent{},
+	))
+
+	uc := usecase.NewSaveCursoUseCase(
+		database.NewCursoRepositoryGorm(db),
+		database.NewPessoaRepositoryGorm(db),
+		event_dispatcher.NewEventDispatcher(),
+	)
+	return db, uc
+}
+
+func TestExecuteCreateAluno_NaoDesfazAnonimizacaoDaPessoa(t *testing.T) {
+	db, uc := newSaveCursoUseCase(t)
+
+	id := uuid.New()
+	excluidaEm := time.Now().Add(-time.Hour)
+	atualizadaEm := excluidaEm.Add(-time.Hour)
+	pessoa, err := entity.NewPessoa(&id, entity.PessoaFisica, "Maria")
+	require.NoError(t, err)
+	pessoa.AtualizadoNaOrigemEm = atualizadaEm
+	pessoa.MarcarExclusao(excluidaEm)
+	pessoa.Anonimizar()
+	require.NoError(t, db.Create(pessoa).Error)
+
+	_, err = uc.ExecuteCreateAluno(context.Background(), dto.AlunoNewInputDTO{
+		PessoaID: id.String(),
+		Nome:     "Maria",
+		Wallet:   "0xabc",
+	})
+	require.NoError(t, err)
+
+	var salva entity.Pessoa
+	require.NoError(t, db.First(&salva, "id = ?", id).Error)
+	assert.True(t, salva.Anonimizada)
+	assert.Equal(t, entity.NomePessoaAnonimizada, salva.Nome)
+	require.NotNil(t, salva.ExclusaoSolicitadaEm)
+	assert.WithinDuration(t, excluidaEm, *salva.ExclusaoSolicitadaEm, time.Millisecond)
+	assert.WithinDuration(t, atualizadaEm, salva.AtualizadoNaOrigemEm, time.Millisecond)
+	// a versão de origem preservada continua descartando eventos antigos
+	assert.True(t, salva.VersaoDesatualizada(atualizadaEm))
+}
+
+func TestExecuteDeleteAlunoCurso_RetiraXpGanhoDoAluno(t *testing.T) {
+	db, uc := newSaveCursoUseCase(t)
+
+	aluno := entity.Aluno{ID: uuid.New(), PessoaID: uuid.New(), NftId: "nft", StatusAluno: entity.StatusAlunoAtivo, XpTotal: 50}
+	require.NoError(t, db.Omit("Pessoa").Create(&aluno).Error)
+	curso, err := entity.NewCurso(nil, "curso", "descricao")
+	require.NoError(t, err)
+	require.NoError(t, db.Create(curso).Error)
+	matricula := entity.AlunoCurso{ID: uuid.New(), AlunoID: aluno.ID, CursoID: curso.ID, StatusCurso: entity.StatusEmAndamento, XpGanho: 30}
+	require.NoError(t, db.Omit("Aluno", "Curso").Create(&matricula).Error)
+
+	require.NoError(t, uc.ExecuteDeleteAlunoCurso(context.Background(), matricula.ID.String()))
+
+	var salvo entity.Aluno
+	require.NoError(t, db.First(&salvo, "id = ?", aluno.ID).Error)
+	assert.Equal(t, int64(20), salvo.XpTotal)
+}
+
+func TestExecuteValidarContratoPorEvento_IgnoraEventoDeOutroContrato(t *testing.T) {
+	db, uc := newSaveCursoUseCase(t)
+
+	const validador = "0x1111111111111111111111111111111111111111"
+	const contratoAluno = "0xd47b84cd828538ee33264911e117e3557af39231"
+
+	item := entity.ItemModulo{ID: uuid.New(), ModuloID: uuid.New(), Nome: "deploy", Tipo: entity.ItemContractValidate, EstimativaTempoMin: 10, Ordem: 1,
+		ContractValidation: &entity.ItemModuloContractValidation{EnderecoContrato: validador}}
+	require.NoError(t, db.Create(&item).Error)
+	aluno := entity.Aluno{ID: uuid.New(), PessoaID: uuid.New(), NftId: "nft", StatusAluno: entity.StatusAlunoAtivo}
+	require.NoError(t, db.Omit("Pessoa").Create(&aluno).Error)
+	matricula := entity.AlunoCurso{ID: uuid.New(), AlunoID: aluno.ID, CursoID: uuid.New(), StatusCurso: entity.StatusEmAndamento}
+	require.NoError(t, db.Omit("Aluno", "Curso").Create(&matricula).Error)
+	pendente := entity.AlunoCursoItemModulo{ID: uuid.New(), AlunoCursoID: matricula.ID, ItemModuloID: item.ID,
+		EnderecoContratoValidar: contratoAluno, StatusValidacaoContrato: entity.TipoStatusValidacaoContratoPendente}
+	require.NoError(t, db.Omit("AlunoCurso", "ItemModulo").Create(&pendente).Error)
+
+	evento := dto.EthEventInputDTO{
+		Contrato: "0x2222222222222222222222222222222222222222",
+		TxHash:   "0xaaa",
+		Evento:   "Transfer",
+		Dados:    map[string]interface{}{"to": contratoAluno},
+	}
+	atualizados, err := uc.ExecuteValidarContratoPorEvento(context.Background(), evento, "{}")
+	require.NoError(t, err)
+	assert.Equal(t, 0, atualizados)
+
+	var salvo entity.AlunoCursoItemModulo
+	require.NoError(t, db.First(&salvo, "id = ?", pendente.ID).Error)
+	assert.Equal(t, entity.TipoStatusValidacaoContratoPendente, salvo.StatusValidacaoContrato)
+	assert.Empty(t, salvo.BlockchainTxEnvio)
+
+	// o evento do contrato validador, em outra transação, conclui o item
+	evento.Contrato = validador
+	evento.TxHash = "0xbbb"
+	atualizados, err = uc.ExecuteValidarContratoPorEvento(context.Background(), evento, "{}")
+	require.NoError(t, err)
+	assert.Equal(t, 1, atualizados)
+
+	require.NoError(t, db.First(&salvo, "id = ?", pendente.ID).Error)
+	assert.Equal(t, entity.TipoStatusValidacaoContratoConcluido, salvo.StatusValidacaoContrato)
+	assert.Equal(t, "0xbbb", salvo.BlockchainTxEnvio)
+}
+
+func TestExecuteValidarContratoPorEvento_RevalidaItemComErro(t *testing.T) {
+	db, uc := newSaveCursoUseCase(t)
+
+	const contratoAluno = "0xd47b84cd828538ee33264911e117e3557af39231"
+
+	item := entity.ItemModulo{ID: uuid.New(), ModuloID: uuid.New(), Nome: "deploy", Tipo: entity.ItemContractValidate, EstimativaTempoMin: 10, Ordem: 1}
+	require.NoError(t, db.Create(&item).Error)
+	aluno := entity.Aluno{ID: uuid.New(), PessoaID: uuid.New(), NftId: "nft", StatusAluno: entity.StatusAlunoAtivo}
+	require.NoError(t, db.Omit("Pessoa").Create(&aluno).Error)
+	matricula := entity.AlunoCurso{ID: uuid.New(), AlunoID: aluno.ID, CursoID: uuid.New(), StatusCurso: entity.StatusEmAndamento}
+	require.NoError(t, db.Omit("Aluno", "Curso").Create(&matricula).Error)
+	comErro := entity.AlunoCursoItemModulo{ID: uuid.New(), AlunoCursoID: matricula.ID, ItemModuloID: item.ID, BlockchainTxEnvio: "0xaaa",
+		EnderecoContratoValidar: contratoAluno, StatusValidacaoContrato: entity.TipoStatusValidacaoContratoErro}
+	require.NoError(t, db.Omit("AlunoCurso", "ItemModulo").Create(&comErro).Error)
+
+	atualizados, err := uc.ExecuteValidarContratoPorEvento(context.Background(), dto.EthEventInputDTO{
+		TxHash: "0xbbb",
+		Evento: "Transfer",
+		Dados:  map[string]interface{}{"to": contratoAluno},
+	}, "{}")
+	require.NoError(t, err)
+	assert.Equal(t, 1, atualizados)
+
+	var salvo entity.AlunoCursoItemModulo
+	require.NoError(t, db.First(&salvo, "id = ?", comErro.ID).Error)
+	assert.Equal(t, entity.TipoStatusValidacaoContratoConcluido, salvo.StatusValidacaoContrato)
+}
+
+func TestExecuteUpdateItemModulo_SalvaDentroDaTransacao(t *testing.T) {
+	db, uc := newSaveCursoUseCase(t)
+
+	item := entity.ItemModulo{ID: uuid.New(), ModuloID: uuid.New(), Nome: "aula", Tipo: entity.ItemAula, EstimativaTempoMin: 10, Ordem: 1,
+		Aula: &entity.ItemModuloAula{Texto: "texto"}}
+	require.NoError(t, db.Create(&item).Error)
+
+	_, err := uc.ExecuteUpdateItemModulo(context.Background(), item.ID.String(), dto.ItemModuloInputDTO{
+		ModuloID:           item.ModuloID.String(),
+		Nome:               "aula revisada",
+		EstimativaTempoMin: 15,
+		Tipo:               string(entity.ItemAula),
+		Aula:               &dto.ItemModuloAulaDTO{Texto: "texto revisado"},
+	})
+	require.NoError(t, err)
+
+	var salvo entity.ItemModulo
+	require.NoError(t, db.Preload("Aula").First(&salvo, "id = ?", item.ID).Error)
+	assert.Equal(t, "aula revisada", salvo.Nome)
+	require.NotNil(t, salvo.Aula)
+	assert.Equal(t, "texto revisado", salvo.Aula.Texto)
+}
+
+func TestExecuteExcluirPessoa_FalhaNosAlunosNaoAnonimizaAPessoa(t *testing.T) {
+	db, uc := newSaveCursoUseCase(t)
+
+	id := uuid.New()
+	pessoa, err := entity.NewPessoa(&id, entity.PessoaFisica, "Maria")
+	require.NoError(t, err)
+	require.NoError(t, db.Create(pessoa).Error)
+	aluno := entity.Aluno{ID: uuid.New(), PessoaID: id, NftId: "nft", StatusAluno: entity.StatusAlunoAtivo, Wallet: "0xabc"}
+	require.NoError(t, db.Omit("Pessoa").Create(&aluno).Error)
+
+	// sem a tabela do outbox a gravação dos alunos falha depois da pessoa já atualizada
+	require.NoError(t, db.Migrator().DropTable(&entity.OutboxEvent{}))
+
+	_, err = uc.ExecuteExcluirPessoa(context.Background(), dto.PessoaDeletedInputDTO{ID: id.String()}, entity.PoliticaExclusaoAnonimizar)
+	require.Error(t, err)
+
+	var salva entity.Pessoa
+	require.NoError(t, db.First(&salva, "id = ?", id).Error)
+	assert.False(t, salva.Anonimizada)
+	assert.Equal(t, "Maria", salva.Nome)
+	assert.Nil(t, salva.ExclusaoSolicitadaEm)
+
+	var salvo entity.Aluno
+	require.NoError(t, db.First(&salvo, "id = ?", aluno.ID).Error)
+	assert.Equal(t, entity.StatusAlunoAtivo, salvo.StatusAluno)
+	assert.Equal(t, "0xabc", salvo.Wallet)
+}
+
+// pessoaExcluidaComMatriculaAtiva prepara uma pessoa com um aluno matriculado num curso de um
+// único item de aula e aplica a exclusão com a política bloquear.
+func pessoaExcluidaComMatriculaAtiva(t *testing.T, db *gorm.DB, uc *usecase.SaveCursoUseCase) (entity.Aluno, entity.AlunoCurso, entity.AlunoCursoItemModulo) {
+	id := uuid.New()
+	pessoa, err := entity.NewPessoa(&id, entity.PessoaFisica, "Maria")
+	require.NoError(t, err)
+	require.NoError(t, db.Create(pessoa).Error)
+	aluno := entity.Aluno{ID: uuid.New(), PessoaID: id, NftId: "nft", StatusAluno: entity.StatusAlunoAtivo, Wallet: "0xabc"}
+	require.NoError(t, db.Omit("Pessoa").Create(&aluno).Error)
+	curso, err := entity.NewCurso(nil, "curso", "descricao")
+	require.NoError(t, err)
+	require.NoError(t, db.Create(curso).Error)
+	item := entity.ItemModulo{ID: uuid.New(), ModuloID: uuid.New(), Nome: "aula", Tipo: entity.ItemAula, EstimativaTempoMin: 10, Ordem: 1}
+	require.NoError(t, db.Create(&item).Error)
+	matricula := entity.AlunoCurso{ID: uuid.New(), AlunoID: aluno.ID, CursoID: curso.ID, StatusCurso: entity.StatusEmAndamento}
+	require.NoError(t, db.Omit("Aluno", "Curso").Create(&matricula).Error)
+	itemAluno := entity.AlunoCursoItemModulo{ID: uuid.New(), AlunoCursoID: matricula.ID, ItemModuloID: item.ID, Status: entity.TipoStatusItemModuloEmAndamento}
+	require.NoError(t, db.Omit("AlunoCurso", "ItemModulo").Create(&itemAluno).Error)
+
+	resultado, err := uc.ExecuteExcluirPessoa(context.Background(), dto.PessoaDeletedInputDTO{ID: id.String()}, entity.PoliticaExclusaoBloquear)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ResultadoExclusaoBloqueada, resultado)
+	assertStatusAluno(t, db, aluno.ID, entity.StatusAlunoAtivo)
+	return aluno, matricula, itemAluno
+}
+
+func assertStatusAluno(t *testing.T, db *gorm.DB, alunoID uuid.UUID, status entity.StatusAluno) {
+	var salvo entity.Aluno
+	require.NoError(t, db.First(&salvo, "id = ?", alunoID).Error)
+	assert.Equal(t, status, salvo.StatusAluno)
+}
+
+func assertAlunoChangedNoOutbox(t *testing.T, db *gorm.DB, alunoID uuid.UUID) {
+	var count int64
+	require.NoError(t, db.Model(&entity.OutboxEvent{}).
+		Where("event_name = ? AND aggregate_id = ?", domain_event.AlunoChangedName, alunoID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestExecuteExcluirPessoa_BloqueadaDesativaAoExcluirUltimaMatricula(t *testing.T) {
+	db, uc := newSaveCursoUseCase(t)
+	aluno, matricula, _ := pessoaExcluidaComMatriculaAtiva(t, db, uc)
+
+	require.NoError(t, uc.ExecuteDeleteAlunoCurso(context.Background(), matricula.ID.String()))
+
+	assertStatusAluno(t, db, aluno.ID, entity.StatusAlunoInativo)
+	assertAlunoChangedNoOutbox(t, db, aluno.ID)
+}
+
+func TestExecuteExcluirPessoa_BloqueadaDesativaAoConcluirUltimaMatricula(t *testing.T) {
+	db, uc := newSaveCursoUseCase(t)
+	aluno, _, itemAluno := pessoaExcluidaComMatriculaAtiva(t, db, uc)
+
+	concluido := entity.TipoStatusItemModuloConcluido
+	_, err := uc.ExecuteUpdateAlunoCursoItemModulo(context.Background(), itemAluno.ID.String(), dto.AlunoCursoItemModuloUpdateDTO{Status: &concluido})
+	require.NoError(t, err)
+
+	assertStatusAluno(t, db, aluno.ID, entity.StatusAlunoInativo)
+	assertAlunoChangedNoOutbox(t, db, aluno.ID)
+}
+
+func TestExecuteDeleteAlunoCurso_SemExclusaoPendenteMantemAlunoAtivo(t *testing.T) {
+	db, uc := newSaveCursoUseCase(t)
+
+	aluno := entity.Aluno{ID: uuid.New(), PessoaID: uuid.New(), NftId: "nft", StatusAluno: entity.StatusAlunoAtivo}
+	require.NoError(t, db.Omit("Pessoa").Create(&aluno).Error)
+	matricula := entity.AlunoCurso{ID: uuid.New(), AlunoID: aluno.ID, CursoID: uuid.New(), StatusCurso: entity.StatusEmAndamento}
+	require.NoError(t, db.Omit("Aluno", "Curso").Create(&matricula).Error)
+
+	require.NoError(t, uc.ExecuteDeleteAlunoCurso(context.Background(), matricula.ID.String()))
+	assertStatusAluno(t, db, aluno.ID, entity.StatusAlunoAtivo)
+}
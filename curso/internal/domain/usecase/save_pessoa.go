@@ -1,11 +1,14 @@
 package usecase
 
 import (
+	"context"
 	"time"
 
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/query"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
 	"github.com/google/uuid"
 )
 
@@ -23,7 +26,12 @@ func NewSavePessoaUseCase(
 
 // region cadastro de Pessoa
 
-func (c *SavePessoaUseCase) ExecuteCreateOrUpdatePessoa(input dto.PessoaInputDTO) (dto.PessoaOutputDTO, error) {
+// ExecuteCreateOrUpdatePessoa grava na réplica local a pessoa recebida do serviço pessoa,
+// numa transação para que a leitura da versão atual e a gravação não se intercalem.
+func (c *SavePessoaUseCase) ExecuteCreateOrUpdatePessoa(ctx context.Context, input dto.PessoaInputDTO) (dto.PessoaOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SavePessoaUseCase.ExecuteCreateOrUpdatePessoa")
+	defer span.End()
+
 	id, err := uuid.Parse(input.ID)
 	if err != nil {
 		return dto.PessoaOutputDTO{}, err
@@ -41,25 +49,41 @@ func (c *SavePessoaUseCase) ExecuteCreateOrUpdatePessoa(input dto.PessoaInputDTO
 	// seta created_at e updated_at
 	pessoa.CreatedAt = time.Now()
 	pessoa.UpdatedAt = time.Now()
+	pessoa.AtualizadoNaOrigemEm = input.UpdatedAt
 
-	var ret *entity.Pessoa
-	p, err := c.PessoaRepository.GetPessoa(pessoa.ID)
-	if err != nil {
-		//vai criar
-		ret, err = c.PessoaRepository.CreatePessoa(pessoa)
-		if err != nil {
-			return dto.PessoaOutputDTO{}, err
-		}
-	} else {
-		//vai atualizar
-		pessoa.CreatedAt = p.CreatedAt
-		ret, err = c.PessoaRepository.UpdatePessoa(pessoa)
+	var saved_obj *entity.Pessoa
+	err = c.PessoaRepository.WithTransaction(ctx, func(repo repository.PessoaRepositoryInterface) error {
+		var ret *entity.Pessoa
+		p, err := repo.GetPessoa(pessoa.ID)
 		if err != nil {
-			return dto.PessoaOutputDTO{}, err
+			//vai criar
+			ret, err = repo.CreatePessoa(pessoa)
+			if err != nil {
+				return err
+			}
+		} else {
+			//vai atualizar, descartando versões mais antigas que a réplica
+			if p.VersaoDesatualizada(input.UpdatedAt) {
+				return entity.ErrPessoaVersaoDesatualizada
+			}
+			pessoa.CreatedAt = p.CreatedAt
+			pessoa.ExclusaoSolicitadaEm = p.ExclusaoSolicitadaEm
+			if p.Anonimizada {
+				// dados pessoais removidos não voltam para a réplica
+				pessoa.Anonimizar()
+			}
+			if input.UpdatedAt.IsZero() {
+				pessoa.AtualizadoNaOrigemEm = p.AtualizadoNaOrigemEm
+			}
+			ret, err = repo.UpdatePessoa(pessoa)
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	saved_obj, err := c.PessoaRepository.GetPessoa(ret.ID)
+		saved_obj, err = repo.GetPessoa(ret.ID)
+		return err
+	})
 	if err != nil {
 		return dto.PessoaOutputDTO{}, err
 	}
@@ -105,22 +129,19 @@ func (c *SavePessoaUseCase) ExecuteGetPessoa(obj_id string) (dto.PessoaOutputDTO
 	return out_dto, nil
 }
 
-func (c *SavePessoaUseCase) ExecuteGetPessoas() ([]dto.PessoaOutputDTO, error) {
-	saved_objs, err := c.PessoaRepository.GetPessoas()
+func (c *SavePessoaUseCase) ExecuteGetPessoas(spec query.Spec) (query.Page[dto.PessoaOutputDTO], error) {
+	saved_objs, err := c.PessoaRepository.FindAllPessoas(spec)
 	if err != nil {
-		return nil, err
+		return query.Page[dto.PessoaOutputDTO]{}, err
 	}
 
-	var dtos []dto.PessoaOutputDTO
-	for _, saved_obj := range saved_objs {
-		out_dto := dto.PessoaOutputDTO{
+	return query.Map(saved_objs, func(saved_obj entity.Pessoa) dto.PessoaOutputDTO {
+		return dto.PessoaOutputDTO{
 			ID:   saved_obj.ID,
 			Tipo: string(saved_obj.Tipo),
 			Nome: saved_obj.Nome,
 		}
-		dtos = append(dtos, out_dto)
-	}
-	return dtos, nil
+	}), nil
 }
 
 // endregion
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/go-chi/jwtauth"
+	"github.com/google/uuid"
+)
+
+var ErrForbidden = errors.New("forbidden")
+
+// AuthClaims representa o usuário autenticado extraído do JWT.
+type AuthClaims struct {
+	UserID  uuid.UUID
+	Role    entity.Role
+	AlunoID *uuid.UUID
+}
+
+// ClaimsFromContext lê as claims colocadas no contexto pelo jwtauth.Verifier.
+// Retorna false quando não há token ou o token é inválido.
+func ClaimsFromContext(ctx context.Context) (AuthClaims, bool) {
+	token, claims, err := jwtauth.FromContext(ctx)
+	if err != nil || token == nil {
+		return AuthClaims{}, false
+	}
+
+	var out AuthClaims
+	if sub, ok := claims["sub"].(string); ok {
+		out.UserID, _ = uuid.Parse(sub)
+	}
+	if role, ok := claims["role"].(string); ok {
+		out.Role = entity.Role(role)
+	}
+	if alunoID, ok := claims["aluno_id"].(string); ok && alunoID != "" {
+		id, err := uuid.Parse(alunoID)
+		if err == nil {
+			out.AlunoID = &id
+		}
+	}
+	if !out.Role.IsValid() {
+		return AuthClaims{}, false
+	}
+	return out, true
+}
+
+// RoleFromContext retorna o papel do usuário autenticado, para webserver.RequireRoles.
+func RoleFromContext(ctx context.Context) (entity.Role, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	return claims.Role, ok
+}
+
+// HasRole informa se o usuário possui um dos papéis informados.
+func (c AuthClaims) HasRole(roles ...entity.Role) bool {
+	for _, role := range roles {
+		if c.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessAluno informa se o usuário pode acessar os dados do aluno informado.
+// Admins e instrutores acessam qualquer aluno; alunos apenas o próprio cadastro.
+func (c AuthClaims) CanAccessAluno(alunoID uuid.UUID) bool {
+	if c.HasRole(entity.RoleAdmin, entity.RoleInstrutor) {
+		return true
+	}
+	return c.AlunoID != nil && *c.AlunoID == alunoID
+}
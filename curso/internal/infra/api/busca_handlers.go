@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/usecase"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/query"
+)
+
+// buscaQuery só pagina: a ordem é sempre pela relevância.
+var buscaQuery = query.Schema{
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+type BuscaHandlers struct {
+	BuscaRepository repository.BuscaRepositoryInterface
+}
+
+func NewBuscaHandlers(repo repository.BuscaRepositoryInterface) *BuscaHandlers {
+	return &BuscaHandlers{
+		BuscaRepository: repo,
+	}
+}
+
+// BuscarCatalogo godoc
+// @Summary      Busca textual no catálogo
+// @Description  Full-text search over cursos, modulos and itens de modulo (including aula text), ranked by relevance
+// @Tags         busca
+// @Accept       json
+// @Produce      json
+// @Param        q      query     string  true   "texto procurado (2 a 200 caracteres; aceita \"frase\", -termo e or)"
+// @Param        tipo   query     string  false  "tipos separados por vírgula: curso, modulo, item_modulo (padrão todos)"
+// @Param        page   query     int     false  "página (padrão 1)"
+// @Param        limit  query     int     false  "resultados por página (padrão 20, máximo 100)"
+// @Success      200  {object}  query.Page[dto.ResultadoBuscaOutputDTO]
+// @Failure      400  {object}  Error
+// @Failure      500  {object}  string
+// @Router       /search [get]
+func (h *BuscaHandlers) BuscarCatalogo(w http.ResponseWriter, r *http.Request) {
+	spec, ok := parseListQuery(w, r, buscaQuery)
+	if !ok {
+		return
+	}
+
+	var tipos []string
+	if v := r.URL.Query().Get("tipo"); v != "" {
+		tipos = strings.Split(v, ",")
+	}
+
+	uc := usecase.NewBuscarCatalogoUseCase(h.BuscaRepository)
+	resultados, err := uc.Execute(r.Context(), r.URL.Query().Get("q"), tipos, spec)
+	if errors.Is(err, entity.ErrBuscaTextoInvalido) || errors.Is(err, entity.ErrBuscaTipoInvalido) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(Error{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "BuscarCatalogo", logging.Err(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resultados)
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/google/uuid"
+)
+
+// HeaderCorrelationID identifica a cadeia de eventos originada por uma requisição.
+const HeaderCorrelationID = "X-Correlation-ID"
+
+// CorrelationID coloca no contexto da requisição o X-Correlation-ID recebido, ou um novo,
+// para que os eventos de domínio gerados por ela compartilhem o mesmo id de correlação.
+func CorrelationID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationID := r.Header.Get(HeaderCorrelationID)
+		if correlationID == "" {
+			correlationID = uuid.New().String()
+		}
+		w.Header().Set(HeaderCorrelationID, correlationID)
+		ctx := event_dispatcher.WithCorrelationID(r.Context(), correlationID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
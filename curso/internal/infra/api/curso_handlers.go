@@ -2,47 +2,33 @@ package api
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/usecase"
-	"github.com/ggialluisi/nebula-back/curso/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/logging"
 	"github.com/google/uuid"
 )
 
 type CursoHandlers struct {
-	EventDispatcher        event_dispatcher.EventDispatcherInterface
-	CursoRepository        repository.CursoRepositoryInterface
-	CursoChangedEvent      event_dispatcher.EventInterface
-	ModuloChangedEvent     event_dispatcher.EventInterface
-	AlunoChangedEvent      event_dispatcher.EventInterface
-	AlunoCursoChangedEvent event_dispatcher.EventInterface
-	ItemModuloChangedEvent event_dispatcher.EventInterface
-	PessoaRepository       repository.PessoaRepositoryInterface
+	EventDispatcher  event_dispatcher.EventDispatcherInterface
+	CursoRepository  repository.CursoRepositoryInterface
+	PessoaRepository repository.PessoaRepositoryInterface
 }
 
 func NewCursoHandlers(
 	EventDispatcher event_dispatcher.EventDispatcherInterface,
 	CursoRepository repository.CursoRepositoryInterface,
-	CursoChangedEvent event_dispatcher.EventInterface,
-	ModuloChangedEvent event_dispatcher.EventInterface,
-	AlunoChangedEvent event_dispatcher.EventInterface,
-	AlunoCursoChangedEvent event_dispatcher.EventInterface,
-	ItemModuloChangedEvent event_dispatcher.EventInterface,
 	PessoaRepository repository.PessoaRepositoryInterface,
 ) *CursoHandlers {
 	return &CursoHandlers{
-		EventDispatcher:        EventDispatcher,
-		CursoRepository:        CursoRepository,
-		CursoChangedEvent:      CursoChangedEvent,
-		ModuloChangedEvent:     ModuloChangedEvent,
-		AlunoChangedEvent:      AlunoChangedEvent,
-		AlunoCursoChangedEvent: AlunoCursoChangedEvent,
-		ItemModuloChangedEvent: ItemModuloChangedEvent,
-		PessoaRepository:       PessoaRepository,
+		EventDispatcher:  EventDispatcher,
+		CursoRepository:  CursoRepository,
+		PessoaRepository: PessoaRepository,
 	}
 }
 
@@ -68,16 +54,8 @@ func (h *CursoHandlers) CreateCurso(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteCreateCurso(dto)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	output, err := ucCurso.ExecuteCreateCurso(r.Context(), dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -105,7 +83,7 @@ func (h *CursoHandlers) UpdateCurso(w http.ResponseWriter, r *http.Request) {
 	// id := chi.URLParam(r, "id")
 	id := r.PathValue("id")
 
-	log.Default().Println("UpdateCurso - ID: ", id)
+	slog.DebugContext(r.Context(), "UpdateCurso", "id", id)
 
 	if id == "" {
 		w.WriteHeader(http.StatusBadRequest)
@@ -119,16 +97,8 @@ func (h *CursoHandlers) UpdateCurso(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteUpdateCurso(id, dto)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	output, err := ucCurso.ExecuteUpdateCurso(r.Context(), id, dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -154,22 +124,14 @@ func (h *CursoHandlers) UpdateCurso(w http.ResponseWriter, r *http.Request) {
 // @Router       /cursos/{id} [get]
 func (h *CursoHandlers) GetCurso(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	log.Default().Println("GetCurso - ID: ", id)
+	slog.DebugContext(r.Context(), "GetCurso", "id", id)
 
 	if id == "" {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	obj, err := ucCurso.ExecuteGetCurso(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -199,18 +161,10 @@ func (h *CursoHandlers) DeleteCurso(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	err := ucCurso.ExecuteDeleteCurso(id)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	err := ucCurso.ExecuteDeleteCurso(r.Context(), id)
 	if err != nil {
-		log.Default().Println("DeleteCurso - Error: ", err)
+		slog.ErrorContext(r.Context(), "DeleteCurso", logging.Err(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -224,37 +178,23 @@ func (h *CursoHandlers) DeleteCurso(w http.ResponseWriter, r *http.Request) {
 // @Tags         cursos
 // @Accept       json
 // @Produce      json
-// @Param        page      query     string  false  "page number"
-// @Param        limit     query     string  false  "limit"
-// @Param        sort      query     string  false  "sort"
-// @Success      200
+// @Param        nome      query     string  false  "contém (sem diferenciar maiúsculas)"
+// @Param        descricao query     string  false  "contém (sem diferenciar maiúsculas)"
+// @Param        page      query     int     false  "página (padrão 1)"
+// @Param        limit     query     int     false  "registros por página (padrão 50, máximo 500)"
+// @Param        sort      query     string  false  "campos separados por vírgula, - para decrescente: nome, created_at, updated_at"
+// @Success      200  {object}  query.Page[dto.CursoOutputDTO]
+// @Failure      400  {object}  Error
 // @Failure      404
-// @Failure      500
 // @Router       /cursos [get]
 func (h *CursoHandlers) GetCursos(w http.ResponseWriter, r *http.Request) {
-	page := r.URL.Query().Get("page")
-	limit := r.URL.Query().Get("limit")
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		pageInt = 0
+	spec, ok := parseListQuery(w, r, cursosQuery)
+	if !ok {
+		return
 	}
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		limitInt = 0
-	}
-	sort := r.URL.Query().Get("sort")
-
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	itens, err := ucCurso.ExecuteGetCursos(pageInt, limitInt, sort)
+
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	itens, err := ucCurso.ExecuteGetCursos(spec)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -288,16 +228,8 @@ func (h *CursoHandlers) CreateModulo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteCreateModulo(dto)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	output, err := ucCurso.ExecuteCreateModulo(r.Context(), dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -335,16 +267,8 @@ func (h *CursoHandlers) UpdateModulo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteUpdateModulo(id, dto)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	output, err := ucCurso.ExecuteUpdateModulo(r.Context(), id, dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -381,15 +305,7 @@ func (h *CursoHandlers) GetModulo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	obj, err := ucCurso.ExecuteGetModulo(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -425,16 +341,8 @@ func (h *CursoHandlers) DeleteModulo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	err = ucCurso.ExecuteDeleteModulo(id)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	err = ucCurso.ExecuteDeleteModulo(r.Context(), id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -467,15 +375,7 @@ func (h *CursoHandlers) GetModulosDaCurso(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	itens, err := ucCurso.ExecuteGetModulosDeCurso(parent_id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -493,13 +393,23 @@ func (h *CursoHandlers) GetModulosDaCurso(w http.ResponseWriter, r *http.Request
 // @Tags         pessoas
 // @Accept       json
 // @Produce      json
-// @Success      200
+// @Param        nome      query     string  false  "contém (sem diferenciar maiúsculas)"
+// @Param        tipo      query     string  false  "FISICA ou JURIDICA"
+// @Param        page      query     int     false  "página (padrão 1)"
+// @Param        limit     query     int     false  "registros por página (padrão 50, máximo 500)"
+// @Param        sort      query     string  false  "campos separados por vírgula, - para decrescente: nome, tipo, created_at, updated_at"
+// @Success      200  {object}  query.Page[dto.PessoaOutputDTO]
+// @Failure      400  {object}  Error
 // @Failure      404
-// @Failure      500  {object} string
 // @Router       /pessoas [get]
 func (h *CursoHandlers) GetPessoas(w http.ResponseWriter, r *http.Request) {
+	spec, ok := parseListQuery(w, r, pessoasQuery)
+	if !ok {
+		return
+	}
+
 	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository)
-	itens, err := ucPessoa.ExecuteGetPessoas()
+	itens, err := ucPessoa.ExecuteGetPessoas(spec)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -555,16 +465,8 @@ func (h *CursoHandlers) CreateAluno(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteCreateAluno(dto)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	output, err := ucCurso.ExecuteCreateAluno(r.Context(), dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -592,7 +494,7 @@ func (h *CursoHandlers) UpdateAluno(w http.ResponseWriter, r *http.Request) {
 	// id := chi.URLParam(r, "id")
 	id := r.PathValue("id")
 
-	log.Default().Println("UpdateAluno - ID: ", id)
+	slog.DebugContext(r.Context(), "UpdateAluno", "id", id)
 
 	if id == "" {
 		w.WriteHeader(http.StatusBadRequest)
@@ -606,16 +508,8 @@ func (h *CursoHandlers) UpdateAluno(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteUpdateAluno(id, dto)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	output, err := ucCurso.ExecuteUpdateAluno(r.Context(), id, dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -641,22 +535,14 @@ func (h *CursoHandlers) UpdateAluno(w http.ResponseWriter, r *http.Request) {
 // @Router       /alunos/{id} [get]
 func (h *CursoHandlers) GetAluno(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	log.Default().Println("GetAluno - ID: ", id)
+	slog.DebugContext(r.Context(), "GetAluno", "id", id)
 
 	if id == "" {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	obj, err := ucCurso.ExecuteGetAluno(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -676,32 +562,29 @@ func (h *CursoHandlers) GetAluno(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path      string  true  "aluno ID" Format(uuid)
 // @Success      200
+// @Failure      403
 // @Failure      404
 // @Failure      500  {object} string
 // @Router       /alunos/by-wallet/{id} [get]
 func (h *CursoHandlers) GetAlunoByWallet(w http.ResponseWriter, r *http.Request) {
 	wallet := r.PathValue("wallet")
-	log.Default().Println("GetAlunoBayWallet - Wallet: ", wallet)
+	slog.DebugContext(r.Context(), "GetAlunoBayWallet", "wallet", wallet)
 
 	if wallet == "" {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	obj, err := ucCurso.ExecuteGetAlunoByWallet(wallet)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
+	if claims, ok := ClaimsFromContext(r.Context()); !ok || !claims.CanAccessAluno(obj.ID) {
+		http.Error(w, ErrForbidden.Error(), http.StatusForbidden)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -726,18 +609,10 @@ func (h *CursoHandlers) DeleteAluno(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	err := ucCurso.ExecuteDeleteAluno(id)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	err := ucCurso.ExecuteDeleteAluno(r.Context(), id)
 	if err != nil {
-		log.Default().Println("DeleteAluno - Error: ", err)
+		slog.ErrorContext(r.Context(), "DeleteAluno", logging.Err(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -751,37 +626,25 @@ func (h *CursoHandlers) DeleteAluno(w http.ResponseWriter, r *http.Request) {
 // @Tags         alunos
 // @Accept       json
 // @Produce      json
-// @Param        page      query     string  false  "page number"
-// @Param        limit     query     string  false  "limit"
-// @Param        sort      query     string  false  "sort"
-// @Success      200
+// @Param        status_aluno query     string  false  "ATIVO ou INATIVO"
+// @Param        pessoa_id query     string  false  "pessoa"
+// @Param        wallet    query     string  false  "wallet"
+// @Param        nft_id    query     string  false  "NFT"
+// @Param        page      query     int     false  "página (padrão 1)"
+// @Param        limit     query     int     false  "registros por página (padrão 50, máximo 500)"
+// @Param        sort      query     string  false  "campos separados por vírgula, - para decrescente: status_aluno, data_inicio, xp_total, created_at, updated_at"
+// @Success      200  {object}  query.Page[dto.AlunoOutputDTO]
+// @Failure      400  {object}  Error
 // @Failure      404
-// @Failure      500
 // @Router       /alunos [get]
 func (h *CursoHandlers) GetAlunos(w http.ResponseWriter, r *http.Request) {
-	page := r.URL.Query().Get("page")
-	limit := r.URL.Query().Get("limit")
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		pageInt = 0
+	spec, ok := parseListQuery(w, r, alunosQuery)
+	if !ok {
+		return
 	}
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		limitInt = 0
-	}
-	sort := r.URL.Query().Get("sort")
-
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	itens, err := ucCurso.ExecuteGetAlunos(pageInt, limitInt, sort)
+
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	itens, err := ucCurso.ExecuteGetAlunos(spec)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -814,16 +677,8 @@ func (h *CursoHandlers) CreateAlunoCurso(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteCreateAlunoCurso(dto)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	output, err := ucCurso.ExecuteCreateAlunoCurso(r.Context(), dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -851,7 +706,7 @@ func (h *CursoHandlers) UpdateAlunoCurso(w http.ResponseWriter, r *http.Request)
 	// id := chi.URLParam(r, "id")
 	id := r.PathValue("id")
 
-	log.Default().Println("UpdateAlunoCurso - ID: ", id)
+	slog.DebugContext(r.Context(), "UpdateAlunoCurso", "id", id)
 
 	if id == "" {
 		w.WriteHeader(http.StatusBadRequest)
@@ -865,16 +720,8 @@ func (h *CursoHandlers) UpdateAlunoCurso(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteUpdateAlunoCurso(id, dto)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	output, err := ucCurso.ExecuteUpdateAlunoCurso(r.Context(), id, dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -900,22 +747,14 @@ func (h *CursoHandlers) UpdateAlunoCurso(w http.ResponseWriter, r *http.Request)
 // @Router       /alunocursos/{id} [get]
 func (h *CursoHandlers) GetAlunoCurso(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	log.Default().Println("GetAlunoCurso - ID: ", id)
+	slog.DebugContext(r.Context(), "GetAlunoCurso", "id", id)
 
 	if id == "" {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	obj, err := ucCurso.ExecuteGetAlunoCurso(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -945,18 +784,10 @@ func (h *CursoHandlers) DeleteAlunoCurso(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	err := ucCurso.ExecuteDeleteAlunoCurso(id)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	err := ucCurso.ExecuteDeleteAlunoCurso(r.Context(), id)
 	if err != nil {
-		log.Default().Println("DeleteAlunoCurso - Error: ", err)
+		slog.ErrorContext(r.Context(), "DeleteAlunoCurso", logging.Err(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -970,37 +801,25 @@ func (h *CursoHandlers) DeleteAlunoCurso(w http.ResponseWriter, r *http.Request)
 // @Tags         alunocursos
 // @Accept       json
 // @Produce      json
-// @Param        page      query     string  false  "page number"
-// @Param        limit     query     string  false  "limit"
-// @Param        sort      query     string  false  "sort"
-// @Success      200
+// @Param        aluno_id  query     string  false  "aluno"
+// @Param        curso_id  query     string  false  "curso"
+// @Param        status_curso query     string  false  "status do curso"
+// @Param        status_pagamento query     string  false  "status do pagamento"
+// @Param        page      query     int     false  "página (padrão 1)"
+// @Param        limit     query     int     false  "registros por página (padrão 50, máximo 500)"
+// @Param        sort      query     string  false  "campos separados por vírgula, - para decrescente: status_curso, status_pagamento, data_matricula, percentual_concluido, xp_ganho, created_at, updated_at"
+// @Success      200  {object}  query.Page[dto.AlunoCursoOutputDTO]
+// @Failure      400  {object}  Error
 // @Failure      404
-// @Failure      500
 // @Router       /alunocursos [get]
 func (h *CursoHandlers) GetAlunosCursos(w http.ResponseWriter, r *http.Request) {
-	page := r.URL.Query().Get("page")
-	limit := r.URL.Query().Get("limit")
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		pageInt = 0
+	spec, ok := parseListQuery(w, r, alunoCursosQuery)
+	if !ok {
+		return
 	}
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		limitInt = 0
-	}
-	sort := r.URL.Query().Get("sort")
-
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	itens, err := ucCurso.ExecuteGetAlunoCursos(pageInt, limitInt, sort)
+
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	itens, err := ucCurso.ExecuteGetAlunoCursos(spec)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -1035,15 +854,7 @@ func (h *CursoHandlers) GetCursosDoAluno(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	itens, err := ucCurso.ExecuteGetCursosDoAluno(parent_id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -1079,15 +890,7 @@ func (h *CursoHandlers) GetAlunosDoCurso(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	itens, err := ucCurso.ExecuteGetAlunosDoCurso(parent_id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -1118,16 +921,8 @@ func (h *CursoHandlers) CreateItemModulo(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteCreateItemModulo(input)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	output, err := ucCurso.ExecuteCreateItemModulo(r.Context(), input)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1153,15 +948,7 @@ func (h *CursoHandlers) GetItemModulo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	output, err := ucCurso.ExecuteFindItemModuloByID(id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
@@ -1188,15 +975,7 @@ func (h *CursoHandlers) GetItensModulo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	output, err := ucCurso.ExecuteFindItemModulosByModulo(moduloID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -1229,16 +1008,8 @@ func (h *CursoHandlers) UpdateItemModulo(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	obj, err := ucCurso.ExecuteUpdateItemModulo(id, dto)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	obj, err := ucCurso.ExecuteUpdateItemModulo(r.Context(), id, dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1263,16 +1034,8 @@ func (h *CursoHandlers) DeleteItemModulo(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "missing id", http.StatusBadRequest)
 		return
 	}
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	err := ucCurso.ExecuteDeleteItemModulo(id)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	err := ucCurso.ExecuteDeleteItemModulo(r.Context(), id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1301,15 +1064,7 @@ func (h *CursoHandlers) MoveItemModulo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	err = ucCurso.ExecuteMoveItemModulo(id, action)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -1330,6 +1085,7 @@ func (h *CursoHandlers) MoveItemModulo(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path      string  true  "AlunoCurso ID" Format(uuid)
 // @Success      200
+// @Failure      403
 // @Failure      404
 // @Failure      500  {object} string
 // @Router       /alunocursos/{id}/itemmodulos [get]
@@ -1340,15 +1096,10 @@ func (h *CursoHandlers) GetAlunoCursoItemModulos(w http.ResponseWriter, r *http.
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	if !authorizeAlunoCurso(w, r, ucCurso, id) {
+		return
+	}
 	output, err := ucCurso.ExecuteFindAlunoCursoItemModulos(id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
@@ -1365,6 +1116,7 @@ func (h *CursoHandlers) GetAlunoCursoItemModulos(w http.ResponseWriter, r *http.
 // @Produce      json
 // @Param        id   path      string  true  "AlunoCursoItemModulo ID" Format(uuid)
 // @Success      200
+// @Failure      403
 // @Failure      404
 // @Failure      500  {object} string
 // @Router       /alunocursoitemmodulos/{id} [get]
@@ -1375,20 +1127,15 @@ func (h *CursoHandlers) GetAlunoCursoItemModulo(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
 	output, err := ucCurso.ExecuteGetAlunoCursoItemModulo(id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
+	if !authorizeAlunoCurso(w, r, ucCurso, output.AlunoCursoID.String()) {
+		return
+	}
 	json.NewEncoder(w).Encode(output)
 }
 
@@ -1402,6 +1149,7 @@ func (h *CursoHandlers) GetAlunoCursoItemModulo(w http.ResponseWriter, r *http.R
 // @Param        input body      dto.AlunoCursoItemModuloUpdateDTO true  "Campos para atualização"
 // @Success      200
 // @Failure      400
+// @Failure      403
 // @Failure      500  {object} string
 // @Router       /alunocursoitemmodulos/{id} [patch]
 func (h *CursoHandlers) UpdateAlunoCursoItemModulo(w http.ResponseWriter, r *http.Request) {
@@ -1417,16 +1165,22 @@ func (h *CursoHandlers) UpdateAlunoCursoItemModulo(w http.ResponseWriter, r *htt
 		return
 	}
 
-	ucCurso := usecase.NewSaveCursoUseCase(
-		h.CursoRepository,
-		h.PessoaRepository,
-		h.CursoChangedEvent,
-		h.ModuloChangedEvent,
-		h.AlunoChangedEvent,
-		h.AlunoCursoChangedEvent,
-		h.ItemModuloChangedEvent,
-		h.EventDispatcher)
-	output, err := ucCurso.ExecuteUpdateAlunoCursoItemModulo(id, input)
+	ucCurso := usecase.NewSaveCursoUseCase(h.CursoRepository, h.PessoaRepository, h.EventDispatcher)
+	current, err := ucCurso.ExecuteGetAlunoCursoItemModulo(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !authorizeAlunoCurso(w, r, ucCurso, current.AlunoCursoID.String()) {
+		return
+	}
+	// a validação do contrato é feita pela plataforma, nunca pelo próprio aluno
+	if claims, _ := ClaimsFromContext(r.Context()); claims.Role == entity.RoleAluno && input.StatusValidacaoContrato != nil {
+		http.Error(w, ErrForbidden.Error(), http.StatusForbidden)
+		return
+	}
+
+	output, err := ucCurso.ExecuteUpdateAlunoCursoItemModulo(r.Context(), id, input)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1435,3 +1189,26 @@ func (h *CursoHandlers) UpdateAlunoCursoItemModulo(w http.ResponseWriter, r *htt
 }
 
 // endregion
+
+// authorizeAlunoCurso verifica se o usuário autenticado pode acessar a matrícula informada.
+// Escreve a resposta de erro e retorna false quando o acesso é negado.
+func authorizeAlunoCurso(w http.ResponseWriter, r *http.Request, ucCurso *usecase.SaveCursoUseCase, alunoCursoID string) bool {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		http.Error(w, ErrForbidden.Error(), http.StatusForbidden)
+		return false
+	}
+	if claims.HasRole(entity.RoleAdmin, entity.RoleInstrutor) {
+		return true
+	}
+	alunoCurso, err := ucCurso.ExecuteGetAlunoCurso(alunoCursoID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return false
+	}
+	if !claims.CanAccessAluno(alunoCurso.AlunoID) {
+		http.Error(w, ErrForbidden.Error(), http.StatusForbidden)
+		return false
+	}
+	return true
+}
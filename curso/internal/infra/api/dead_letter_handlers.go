@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/google/uuid"
+)
+
+// DeadLetterReplayer republica uma dead letter no tópico original.
+type DeadLetterReplayer interface {
+	Replay(ctx context.Context, id uuid.UUID) (*entity.DeadLetter, error)
+}
+
+type DeadLetterHandlers struct {
+	DeadLetterRepository repository.DeadLetterRepositoryInterface
+	Replayer             DeadLetterReplayer
+}
+
+func NewDeadLetterHandlers(repo repository.DeadLetterRepositoryInterface, replayer DeadLetterReplayer) *DeadLetterHandlers {
+	return &DeadLetterHandlers{
+		DeadLetterRepository: repo,
+		Replayer:             replayer,
+	}
+}
+
+// GetDeadLetters godoc
+// @Summary      Lista as dead letters dos consumidores Kafka
+// @Description  List dead letters, filtered by topic and status, most recent first
+// @Tags         deadletters
+// @Accept       json
+// @Produce      json
+// @Param        topic   query     string  false  "tópico original"
+// @Param        status  query     string  false  "pendente ou reprocessada"
+// @Param        page    query     int     false  "página (padrão 1)"
+// @Param        limit   query     int     false  "registros por página (padrão 100)"
+// @Param        sort    query     string  false  "campos separados por vírgula, - para decrescente (ex.: -attempts)"
+// @Success      200  {object}  query.Page[entity.DeadLetter]
+// @Failure      400  {object}  Error
+// @Failure      500  {object}  string
+// @Router       /deadletters [get]
+func (h *DeadLetterHandlers) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	spec, ok := parseListQuery(w, r, deadLettersQuery)
+	if !ok {
+		return
+	}
+
+	objs, err := h.DeadLetterRepository.FindDeadLetters(spec)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "GetDeadLetters", logging.Err(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(objs)
+}
+
+// ReplayDeadLetter godoc
+// @Summary      Reprocessa uma dead letter
+// @Description  Republish a dead letter to its original topic
+// @Tags         deadletters
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "dead letter ID" Format(uuid)
+// @Success      200  {object}  entity.DeadLetter
+// @Failure      404
+// @Failure      409  {object}  Error
+// @Failure      500  {object}  string
+// @Router       /deadletters/{id}/replay [post]
+func (h *DeadLetterHandlers) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.DeadLetterRepository.GetDeadLetter(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	obj, err := h.Replayer.Replay(r.Context(), id)
+	if errors.Is(err, entity.ErrDeadLetterReprocessada) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(Error{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReplayDeadLetter", logging.Err(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(obj)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ggialluisi/nebula-back/platform/query"
+)
+
+// Campos de filtro e ordenação aceitos por cada listagem. Os demais parâmetros da
+// query string são ignorados.
+
+var cursosQuery = query.Schema{
+	Fields: map[string]query.Field{
+		"nome":       {Column: "nome", Filter: query.Contains, Sortable: true},
+		"descricao":  {Column: "descricao", Filter: query.Contains},
+		"created_at": {Column: "created_at", Sortable: true},
+		"updated_at": {Column: "updated_at", Sortable: true},
+	},
+	DefaultSort: []query.Sort{{Column: "created_at"}},
+}
+
+var alunosQuery = query.Schema{
+	Fields: map[string]query.Field{
+		"status_aluno": {Column: "status_aluno", Filter: query.Equals, Sortable: true},
+		"pessoa_id":    {Column: "pessoa_id", Filter: query.Equals},
+		"wallet":       {Column: "wallet", Filter: query.Equals},
+		"nft_id":       {Column: "nft_id", Filter: query.Equals},
+		"data_inicio":  {Column: "data_inicio", Sortable: true},
+		"xp_total":     {Column: "xp_total", Sortable: true},
+		"created_at":   {Column: "created_at", Sortable: true},
+		"updated_at":   {Column: "updated_at", Sortable: true},
+	},
+	DefaultSort: []query.Sort{{Column: "created_at"}},
+}
+
+var alunoCursosQuery = query.Schema{
+	Fields: map[string]query.Field{
+		"aluno_id":             {Column: "aluno_id", Filter: query.Equals},
+		"curso_id":             {Column: "curso_id", Filter: query.Equals},
+		"status_curso":         {Column: "status_curso", Filter: query.Equals, Sortable: true},
+		"status_pagamento":     {Column: "status_pagamento", Filter: query.Equals, Sortable: true},
+		"data_matricula":       {Column: "data_matricula", Sortable: true},
+		"percentual_concluido": {Column: "percentual_concluido", Sortable: true},
+		"xp_ganho":             {Column: "xp_ganho", Sortable: true},
+		"created_at":           {Column: "created_at", Sortable: true},
+		"updated_at":           {Column: "updated_at", Sortable: true},
+	},
+	DefaultSort: []query.Sort{{Column: "created_at"}},
+}
+
+var pessoasQuery = query.Schema{
+	Fields: map[string]query.Field{
+		"nome":       {Column: "nome", Filter: query.Contains, Sortable: true},
+		"tipo":       {Column: "tipo", Filter: query.Equals, Sortable: true},
+		"created_at": {Column: "created_at", Sortable: true},
+		"updated_at": {Column: "updated_at", Sortable: true},
+	},
+	DefaultSort: []query.Sort{{Column: "nome"}},
+}
+
+var deadLettersQuery = query.Schema{
+	Fields: map[string]query.Field{
+		"topic":      {Column: "topic", Filter: query.Equals, Sortable: true},
+		"status":     {Column: "status", Filter: query.Equals, Sortable: true},
+		"attempts":   {Column: "attempts", Sortable: true},
+		"created_at": {Column: "created_at", Sortable: true},
+	},
+	DefaultSort:  []query.Sort{{Column: "created_at", Desc: true}},
+	DefaultLimit: 100,
+}
+
+// parseListQuery lê os parâmetros da listagem; se forem inválidos, responde 400 e
+// devolve false.
+func parseListQuery(w http.ResponseWriter, r *http.Request, schema query.Schema) (query.Spec, bool) {
+	spec, err := schema.Parse(r.URL.Query())
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(Error{Message: err.Error()})
+		return query.Spec{}, false
+	}
+	return spec, true
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
 	"github.com/go-chi/jwtauth"
+	"github.com/google/uuid"
 )
 
 type Error struct {
@@ -77,10 +78,15 @@ func (h *UserHandlers) GetJWT(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, tokenString, _ := h.Jwt.Encode(map[string]interface{}{
-		"sub": u.ID,
-		"exp": time.Now().Add(time.Hour * time.Duration(h.JwtExpiresIn)).Unix(),
-	})
+	claims := map[string]interface{}{
+		"sub":  u.ID.String(),
+		"role": string(u.Role),
+		"exp":  time.Now().Add(time.Hour * time.Duration(h.JwtExpiresIn)).Unix(),
+	}
+	if u.AlunoID != nil {
+		claims["aluno_id"] = u.AlunoID.String()
+	}
+	_, tokenString, _ := h.Jwt.Encode(claims)
 
 	accessTokenSerializer := struct {
 		AccessToken string `json:"access_token"`
@@ -95,16 +101,19 @@ type CreateUserInput struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Role     string `json:"role"`
+	AlunoID  string `json:"aluno_id"`
 }
 
 // CreateUser godoc
 // @Summary      Create user
-// @Description  Create user
+// @Description  Create user. Sem token de admin, o usuário é sempre criado com o papel aluno e sem aluno vinculado.
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Param        request     body      string  true  "user request"
 // @Success      201
+// @Failure      403         {object}  Error
 // @Failure      500         {object}  Error
 // @Router       /users [post]
 func (h *UserHandlers) CreateUser(w http.ResponseWriter, r *http.Request) {
@@ -114,13 +123,38 @@ func (h *UserHandlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	u, err := entity.NewUser(user.Name, user.Email, user.Password)
+	role := entity.RoleAluno
+	if user.Role != "" {
+		role = entity.Role(user.Role)
+	}
+
+	// apenas admins podem criar usuários com outros papéis ou vincular alunos
+	claims, ok := ClaimsFromContext(r.Context())
+	isAdmin := ok && claims.HasRole(entity.RoleAdmin)
+	if !isAdmin && (role != entity.RoleAluno || user.AlunoID != "") {
+		w.WriteHeader(http.StatusForbidden)
+		error := Error{Message: ErrForbidden.Error()}
+		json.NewEncoder(w).Encode(error)
+		return
+	}
+
+	u, err := entity.NewUserWithRole(user.Name, user.Email, user.Password, role)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		error := Error{Message: err.Error()}
 		json.NewEncoder(w).Encode(error)
 		return
 	}
+	if user.AlunoID != "" {
+		alunoID, err := uuid.Parse(user.AlunoID)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			error := Error{Message: err.Error()}
+			json.NewEncoder(w).Encode(error)
+			return
+		}
+		u.AlunoID = &alunoID
+	}
 	err = h.UserDB.Create(u)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,131 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/query"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Verifica se essa IMPLEMENTAÇÃO implementa corretamente a INTERFACE
+var _ repository.BuscaRepositoryInterface = &BuscaRepositoryGorm{}
+
+// BuscaRepositoryGorm faz a busca textual do PostgreSQL sobre as colunas tsvector
+// "busca" criadas pela migração 0003_busca_catalogo.
+type BuscaRepositoryGorm struct {
+	DB *gorm.DB
+}
+
+func NewBuscaRepositoryGorm(db *gorm.DB) *BuscaRepositoryGorm {
+	return &BuscaRepositoryGorm{DB: db}
+}
+
+// buscaCatalogoSQL procura o texto nos dois dicionários (português e espanhol), ordena
+// pela relevância (ts_rank, com os pesos das colunas) e só então gera os trechos da
+// página. O texto é escapado antes do ts_headline, então o trecho só tem as tags <mark>.
+// O total é contado antes do LIMIT/OFFSET e sempre volta, numa linha sem resultado (tipo
+// vazio) quando a página está vazia.
+const buscaCatalogoSQL = `
+WITH consulta AS (
+	SELECT websearch_to_tsquery('portuguese', @texto) || websearch_to_tsquery('spanish', @texto) AS q
+),
+resultados AS (
+	SELECT 'curso' AS tipo, c.id, c.id AS curso_id, NULL::uuid AS modulo_id, c.nome AS titulo,
+		coalesce(c.descricao, '') AS texto, ts_rank(c.busca, consulta.q) AS rank
+	FROM cursos c, consulta
+	WHERE c.busca @@ consulta.q
+	UNION ALL
+	SELECT 'modulo', m.id, m.curso_id, m.id, m.nome,
+		coalesce(m.descricao, ''), ts_rank(m.busca, consulta.q)
+	FROM modulos m, consulta
+	WHERE m.busca @@ consulta.q
+	UNION ALL
+	SELECT 'item_modulo', i.id, m.curso_id, i.modulo_id, i.nome,
+		concat_ws(' ', i.descricao, a.texto), ts_rank(i.busca || coalesce(a.busca, ''::tsvector), consulta.q)
+	FROM item_modulos i
+	JOIN modulos m ON m.id = i.modulo_id
+	LEFT JOIN item_modulo_aulas a ON a.item_modulo_id = i.id
+	CROSS JOIN consulta
+	WHERE (i.busca || coalesce(a.busca, ''::tsvector)) @@ consulta.q
+),
+filtrados AS (
+	SELECT r.* FROM resultados r WHERE r.tipo IN @tipos
+),
+total AS (
+	SELECT count(*) AS total FROM filtrados
+),
+pagina AS (
+	SELECT f.*
+	FROM filtrados f
+	ORDER BY f.rank DESC, f.titulo, f.id
+	LIMIT @limit OFFSET @offset
+)
+SELECT coalesce(p.tipo, '') AS tipo, p.id, p.curso_id, p.modulo_id, coalesce(p.titulo, '') AS titulo,
+	coalesce(p.rank, 0) AS rank, t.total,
+	coalesce(ts_headline('portuguese',
+		replace(replace(replace(p.texto, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+		consulta.q,
+		'StartSel="<mark>", StopSel="</mark>", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "'
+	), '') AS trecho
+FROM total t
+CROSS JOIN consulta
+LEFT JOIN pagina p ON true
+ORDER BY p.rank DESC, p.titulo, p.id`
+
+// buscaLimitPadrao limita a página quando spec não traz limit.
+const buscaLimitPadrao = 20
+
+type resultadoBuscaRow struct {
+	Tipo     string
+	ID       uuid.UUID
+	CursoID  uuid.UUID
+	ModuloID *uuid.UUID
+	Titulo   string
+	Trecho   string
+	Rank     float64
+	Total    int64
+}
+
+func (r *BuscaRepositoryGorm) BuscarCatalogo(ctx context.Context, busca entity.BuscaCatalogo, spec query.Spec) (query.Page[entity.ResultadoBusca], error) {
+	if spec.Limit <= 0 {
+		spec.Limit = buscaLimitPadrao
+	}
+	tipos := make([]string, 0, len(busca.Tipos))
+	for _, tipo := range busca.Tipos {
+		tipos = append(tipos, string(tipo))
+	}
+
+	var rows []resultadoBuscaRow
+	err := r.DB.WithContext(ctx).Raw(buscaCatalogoSQL, map[string]interface{}{
+		"texto":  busca.Texto,
+		"tipos":  tipos,
+		"limit":  spec.Limit,
+		"offset": spec.Offset(),
+	}).Scan(&rows).Error
+	if err != nil {
+		return query.Page[entity.ResultadoBusca]{}, err
+	}
+
+	var total int64
+	itens := make([]entity.ResultadoBusca, 0, len(rows))
+	for _, row := range rows {
+		total = row.Total
+		if row.Tipo == "" {
+			// página depois do último resultado: a linha só traz o total
+			continue
+		}
+		itens = append(itens, entity.ResultadoBusca{
+			Tipo:     entity.TipoResultadoBusca(row.Tipo),
+			ID:       row.ID,
+			CursoID:  row.CursoID,
+			ModuloID: row.ModuloID,
+			Titulo:   row.Titulo,
+			Trecho:   row.Trecho,
+			Rank:     row.Rank,
+		})
+	}
+	return query.NewPage(itens, total, spec), nil
+}
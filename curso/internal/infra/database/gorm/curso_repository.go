@@ -1,12 +1,17 @@
 package gorm
 
 import (
+	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/query"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Verifica se essa IMPLEMENTAÇÃO implementa corretamente a INTERFACE
@@ -20,6 +25,20 @@ func NewCursoRepositoryGorm(db *gorm.DB) *CursoRepositoryGorm {
 	return &CursoRepositoryGorm{DB: db}
 }
 
+func (r *CursoRepositoryGorm) WithTransaction(ctx context.Context, fn func(repo repository.CursoRepositoryInterface) error) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewCursoRepositoryGorm(tx))
+	})
+}
+
+func (r *CursoRepositoryGorm) CreateOutboxEvent(obj *entity.OutboxEvent) error {
+	return NewOutboxRepositoryGorm(r.DB).CreateOutboxEvent(obj)
+}
+
+func (r *CursoRepositoryGorm) UpdatePessoa(obj *entity.Pessoa) (*entity.Pessoa, error) {
+	return NewPessoaRepositoryGorm(r.DB).UpdatePessoa(obj)
+}
+
 // region CRUD Curso
 
 func (r *CursoRepositoryGorm) CreateCurso(obj *entity.Curso) (*entity.Curso, error) {
@@ -67,19 +86,8 @@ func (r *CursoRepositoryGorm) GetCursoByDocumento(documento string) (*entity.Cur
 	return &curso, nil
 }
 
-func (r *CursoRepositoryGorm) FindAllCursos(page, limit int, sort string) ([]entity.Curso, error) {
-	var itens []entity.Curso
-	var err error
-	if sort != "" && sort != "asc" && sort != "desc" {
-		sort = "asc"
-	}
-	preloads := r.DB.Preload("Modulos")
-	if page != 0 && limit != 0 {
-		err = preloads.Limit(limit).Offset((page - 1) * limit).Order("created_at " + sort).Find(&itens).Error
-	} else {
-		err = preloads.Order("created_at " + sort).Find(&itens).Error
-	}
-	return itens, err
+func (r *CursoRepositoryGorm) FindAllCursos(spec query.Spec) (query.Page[entity.Curso], error) {
+	return query.Find[entity.Curso](r.DB.Preload("Modulos"), spec)
 }
 
 // endregion
@@ -147,6 +155,30 @@ func (r *CursoRepositoryGorm) UpdateAluno(obj *entity.Aluno) (*entity.Aluno, err
 	return obj, nil
 }
 
+// AddXpAluno soma (ou subtrai, se negativo) XP ao total do aluno de forma atômica.
+func (r *CursoRepositoryGorm) AddXpAluno(alunoID uuid.UUID, xp int64) error {
+	return r.DB.Model(&entity.Aluno{}).
+		Where("id = ?", alunoID).
+		Update("xp_total", gorm.Expr("xp_total + ?", xp)).Error
+}
+
+// FindAlunosByPessoa busca os alunos vinculados a uma pessoa.
+func (r *CursoRepositoryGorm) FindAlunosByPessoa(pessoaID uuid.UUID) ([]entity.Aluno, error) {
+	var itens []entity.Aluno
+	err := r.DB.Where("pessoa_id = ?", pessoaID).Find(&itens).Error
+	return itens, err
+}
+
+// UpdateAlunoStatusWallet grava status e carteira do aluno, inclusive quando vazios.
+func (r *CursoRepositoryGorm) UpdateAlunoStatusWallet(obj *entity.Aluno) error {
+	return r.DB.Model(&entity.Aluno{}).
+		Where("id = ?", obj.ID).
+		Updates(map[string]interface{}{
+			"status_aluno": obj.StatusAluno,
+			"wallet":       obj.Wallet,
+		}).Error
+}
+
 func (r *CursoRepositoryGorm) DeleteAluno(objID uuid.UUID) error {
 	obj, err := r.GetAluno(objID)
 	if err != nil {
@@ -182,19 +214,8 @@ func (r *CursoRepositoryGorm) GetAlunoByDocumento(documento string) (*entity.Alu
 	return &aluno, nil
 }
 
-func (r *CursoRepositoryGorm) FindAllAlunos(page, limit int, sort string) ([]entity.Aluno, error) {
-	var itens []entity.Aluno
-	var err error
-	if sort != "" && sort != "asc" && sort != "desc" {
-		sort = "asc"
-	}
-	preloads := r.DB.Preload("Pessoa")
-	if page != 0 && limit != 0 {
-		err = preloads.Limit(limit).Offset((page - 1) * limit).Order("created_at " + sort).Find(&itens).Error
-	} else {
-		err = preloads.Order("created_at " + sort).Find(&itens).Error
-	}
-	return itens, err
+func (r *CursoRepositoryGorm) FindAllAlunos(spec query.Spec) (query.Page[entity.Aluno], error) {
+	return query.Find[entity.Aluno](r.DB.Preload("Pessoa"), spec)
 }
 
 func (r *CursoRepositoryGorm) HasAlunoPagamentoPendente(alunoID uuid.UUID) (bool, error) {
@@ -229,26 +250,35 @@ func (r *CursoRepositoryGorm) UpdateAlunoCurso(obj *entity.AlunoCurso) (*entity.
 	}
 	return obj, nil
 }
+
+// UpdateAlunoCursoProgresso atualiza apenas os campos calculados pelo motor de progresso.
+func (r *CursoRepositoryGorm) UpdateAlunoCursoProgresso(obj *entity.AlunoCurso) error {
+	return r.DB.Model(&entity.AlunoCurso{}).
+		Where("id = ?", obj.ID).
+		Updates(map[string]interface{}{
+			"percentual_concluido": obj.PercentualConcluido,
+			"xp_ganho":             obj.XpGanho,
+			"xp_disponivel":        obj.XpDisponivel,
+			"status_curso":         obj.StatusCurso,
+		}).Error
+}
+
 func (r *CursoRepositoryGorm) DeleteAlunoCurso(objID uuid.UUID) error {
 	obj, err := r.GetAlunoCurso(objID)
 	if err != nil {
 		return err
 	}
-	tx := r.DB.Begin()
 
-	// deleta os filhos primeiro
-	if err := tx.Where("aluno_curso_id = ?", obj.ID).Delete(&entity.AlunoCursoItemModulo{}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	// depois deleta o pai
-	if err := tx.Delete(&entity.AlunoCurso{}, obj.ID).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+	// Transaction vira savepoint quando o repositório já está numa transação (WithTransaction)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		// deleta os filhos primeiro
+		if err := tx.Where("aluno_curso_id = ?", obj.ID).Delete(&entity.AlunoCursoItemModulo{}).Error; err != nil {
+			return err
+		}
 
-	return tx.Commit().Error
+		// depois deleta o pai
+		return tx.Delete(&entity.AlunoCurso{}, obj.ID).Error
+	})
 }
 func (r *CursoRepositoryGorm) GetAlunoCurso(objID uuid.UUID) (*entity.AlunoCurso, error) {
 	var obj entity.AlunoCurso
@@ -258,19 +288,19 @@ func (r *CursoRepositoryGorm) GetAlunoCurso(objID uuid.UUID) (*entity.AlunoCurso
 	}
 	return &obj, nil
 }
-func (r *CursoRepositoryGorm) FindAllAlunoCursos(page, limit int, sort string) ([]entity.AlunoCurso, error) {
-	var itens []entity.AlunoCurso
-	var err error
-	if sort != "" && sort != "asc" && sort != "desc" {
-		sort = "asc"
-	}
-	preloads := r.DB.Preload("Aluno").Preload("Curso")
-	if page != 0 && limit != 0 {
-		err = preloads.Limit(limit).Offset((page - 1) * limit).Order("created_at " + sort).Find(&itens).Error
-	} else {
-		err = preloads.Order("created_at " + sort).Find(&itens).Error
+
+// GetAlunoCursoForUpdate trava a matrícula (SELECT ... FOR UPDATE) até o fim da transação e a
+// carrega, para que atualizações concorrentes partam do XP já gravado pela anterior.
+func (r *CursoRepositoryGorm) GetAlunoCursoForUpdate(objID uuid.UUID) (*entity.AlunoCurso, error) {
+	err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").Where("id = ?", objID.String()).First(&entity.AlunoCurso{}).Error
+	if err != nil {
+		return nil, err
 	}
-	return itens, err
+	return r.GetAlunoCurso(objID)
+}
+func (r *CursoRepositoryGorm) FindAllAlunoCursos(spec query.Spec) (query.Page[entity.AlunoCurso], error) {
+	return query.Find[entity.AlunoCurso](r.DB.Preload("Aluno").Preload("Curso"), spec)
 }
 func (r *CursoRepositoryGorm) FindCursosDoAluno(alunoID uuid.UUID) ([]entity.AlunoCurso, error) {
 	var itens []entity.AlunoCurso
@@ -296,6 +326,16 @@ func (r *CursoRepositoryGorm) CountCursosDoAluno(alunoID uuid.UUID) (int64, erro
 	}
 	return count, nil
 }
+
+// CountMatriculasAtivasDoAluno conta as matrículas não iniciadas ou em andamento do aluno.
+func (r *CursoRepositoryGorm) CountMatriculasAtivasDoAluno(alunoID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.DB.Model(&entity.AlunoCurso{}).
+		Where("aluno_id = ? AND status_curso IN ?", alunoID, []entity.StatusCurso{entity.StatusNaoIniciado, entity.StatusEmAndamento}).
+		Count(&count).Error
+	return count, err
+}
+
 func (r *CursoRepositoryGorm) CountAlunosDoCurso(cursoID uuid.UUID) (int64, error) {
 	var count int64
 	err := r.DB.Model(&entity.AlunoCurso{}).Where("curso_id = ?", cursoID.String()).Count(&count).Error
@@ -340,53 +380,43 @@ func (r *CursoRepositoryGorm) FindItemModulosByModulo(moduloID uuid.UUID) ([]ent
 	return itens, nil
 }
 func (r *CursoRepositoryGorm) UpdateItemModulo(item *entity.ItemModulo) error {
-	tx := r.DB.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
 	s, err := r.FindItemModuloByID(item.ID)
 	if err != nil {
-		tx.Rollback()
 		return err
 	}
 	item.CreatedAt = s.CreatedAt
 
-	if err := tx.Save(item).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	if item.Tipo == entity.ItemAula && item.Aula != nil {
-		if err := tx.
-			Where("item_modulo_id = ?", item.ID).
-			Save(item.Aula).Error; err != nil {
-			tx.Rollback()
+	// Transaction vira savepoint quando o repositório já está numa transação (WithTransaction)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(item).Error; err != nil {
 			return err
 		}
-	}
 
-	if item.Tipo == entity.ItemContractValidate && item.ContractValidation != nil {
-		if err := tx.
-			Where("item_modulo_id = ?", item.ID).
-			Save(item.ContractValidation).Error; err != nil {
-			tx.Rollback()
-			return err
+		if item.Tipo == entity.ItemAula && item.Aula != nil {
+			if err := tx.
+				Where("item_modulo_id = ?", item.ID).
+				Save(item.Aula).Error; err != nil {
+				return err
+			}
 		}
-	}
 
-	if item.Tipo == entity.ItemVideo && item.Video != nil {
-		if err := tx.
-			Where("item_modulo_id = ?", item.ID).
-			Save(item.Video).Error; err != nil {
-			tx.Rollback()
-			return err
+		if item.Tipo == entity.ItemContractValidate && item.ContractValidation != nil {
+			if err := tx.
+				Where("item_modulo_id = ?", item.ID).
+				Save(item.ContractValidation).Error; err != nil {
+				return err
+			}
 		}
-	}
 
-	return tx.Commit().Error
+		if item.Tipo == entity.ItemVideo && item.Video != nil {
+			if err := tx.
+				Where("item_modulo_id = ?", item.ID).
+				Save(item.Video).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 func (r *CursoRepositoryGorm) DeleteItemModulo(id uuid.UUID) error {
 	item, err := r.FindItemModuloByID(id)
@@ -503,4 +533,51 @@ func (r *CursoRepositoryGorm) UpdateAlunoCursoItemModulo(item *entity.AlunoCurso
 		Updates(item).Error
 }
 
+// FindValidacoesContratoPendentes busca os itens de validação de contrato pendentes (ou com
+// erro, que podem ser validados por um novo evento) cuja transação de envio ou endereço de
+// contrato corresponde ao evento recebido.
+func (r *CursoRepositoryGorm) FindValidacoesContratoPendentes(txHash string, enderecos []string) ([]entity.AlunoCursoItemModulo, error) {
+	var itens []entity.AlunoCursoItemModulo
+	err := r.DB.
+		Joins("JOIN item_modulos im ON im.id = aluno_curso_item_modulos.item_modulo_id").
+		Preload("ItemModulo").
+		Preload("ItemModulo.ContractValidation").
+		Where("im.tipo = ?", entity.ItemContractValidate).
+		Where("aluno_curso_item_modulos.status_validacao_contrato IN ?", []entity.TipoStatusValidacaoContrato{entity.TipoStatusValidacaoContratoPendente, entity.TipoStatusValidacaoContratoErro, ""}).
+		Where("LOWER(aluno_curso_item_modulos.blockchain_tx_envio) = ? OR LOWER(aluno_curso_item_modulos.endereco_contrato_validar) IN ?", strings.ToLower(txHash), enderecos).
+		Find(&itens).Error
+	return itens, err
+}
+
+// FindValidacoesContratoPorTx busca os itens de validação de contrato já resolvidos
+// (concluídos ou com erro) pela transação informada.
+func (r *CursoRepositoryGorm) FindValidacoesContratoPorTx(txHash string) ([]entity.AlunoCursoItemModulo, error) {
+	var itens []entity.AlunoCursoItemModulo
+	err := r.DB.
+		Joins("JOIN item_modulos im ON im.id = aluno_curso_item_modulos.item_modulo_id").
+		Preload("ItemModulo").
+		Where("im.tipo = ?", entity.ItemContractValidate).
+		Where("aluno_curso_item_modulos.status_validacao_contrato IN ?", []entity.TipoStatusValidacaoContrato{entity.TipoStatusValidacaoContratoConcluido, entity.TipoStatusValidacaoContratoErro}).
+		Where("LOWER(aluno_curso_item_modulos.blockchain_tx_envio) = ?", strings.ToLower(txHash)).
+		Find(&itens).Error
+	return itens, err
+}
+
+// ResetValidacaoContrato grava status, progresso e status da validação do item,
+// inclusive quando zerados.
+func (r *CursoRepositoryGorm) ResetValidacaoContrato(item *entity.AlunoCursoItemModulo) error {
+	return r.DB.Model(&entity.AlunoCursoItemModulo{}).
+		Where("id = ?", item.ID).
+		Updates(map[string]interface{}{
+			"status":                    item.Status,
+			"progresso":                 item.Progresso,
+			"status_validacao_contrato": item.StatusValidacaoContrato,
+			"updated_at":                time.Now(),
+		}).Error
+}
+
+func (r *CursoRepositoryGorm) CreateValidacaoContratoEvento(obj *entity.ValidacaoContratoEvento) error {
+	return r.DB.Create(obj).Error
+}
+
 // endregion
@@ -0,0 +1,49 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/query"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Verifica se essa IMPLEMENTAÇÃO implementa corretamente a INTERFACE
+var _ repository.DeadLetterRepositoryInterface = &DeadLetterRepositoryGorm{}
+
+type DeadLetterRepositoryGorm struct {
+	DB *gorm.DB
+}
+
+func NewDeadLetterRepositoryGorm(db *gorm.DB) *DeadLetterRepositoryGorm {
+	return &DeadLetterRepositoryGorm{DB: db}
+}
+
+func (r *DeadLetterRepositoryGorm) CreateDeadLetter(obj *entity.DeadLetter) error {
+	return r.DB.Create(obj).Error
+}
+
+func (r *DeadLetterRepositoryGorm) GetDeadLetter(objID uuid.UUID) (*entity.DeadLetter, error) {
+	var obj entity.DeadLetter
+	err := r.DB.First(&obj, "id = ?", objID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+func (r *DeadLetterRepositoryGorm) FindDeadLetters(spec query.Spec) (query.Page[entity.DeadLetter], error) {
+	return query.Find[entity.DeadLetter](r.DB, spec)
+}
+
+func (r *DeadLetterRepositoryGorm) MarkDeadLetterReplayed(objID uuid.UUID) error {
+	now := time.Now()
+	return r.DB.Model(&entity.DeadLetter{}).
+		Where("id = ?", objID).
+		Updates(map[string]interface{}{
+			"status":      entity.DeadLetterReprocessada,
+			"replayed_at": &now,
+		}).Error
+}
@@ -0,0 +1,55 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/query"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDeadLetterRepository_FindAndMarkReplayed(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+	db.AutoMigrate(&entity.DeadLetter{})
+
+	repo := NewDeadLetterRepositoryGorm(db)
+	pessoa, err := entity.NewDeadLetter("pessoa.saved", 0, 1, "k1", "{}", map[string]string{"schema_version": "1"}, "erro", 3)
+	assert.NoError(t, err)
+	eth, err := entity.NewDeadLetter("eth-transactions", 0, 2, "k2", "{}", nil, "erro", 1)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.CreateDeadLetter(pessoa))
+	assert.NoError(t, repo.CreateDeadLetter(eth))
+
+	page, err := repo.FindDeadLetters(query.Spec{
+		Filters: []query.Filter{{Column: "topic", Op: query.Equals, Value: "pessoa.saved"}},
+		Page:    1,
+		Limit:   10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, int64(1), page.Total)
+	headers, err := page.Items[0].HeadersMap()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", headers["schema_version"])
+
+	assert.NoError(t, repo.MarkDeadLetterReplayed(pessoa.ID))
+	page, err = repo.FindDeadLetters(query.Spec{
+		Filters: []query.Filter{{Column: "status", Op: query.Equals, Value: string(entity.DeadLetterPendente)}},
+		Page:    1,
+		Limit:   10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, eth.ID, page.Items[0].ID)
+
+	obj, err := repo.GetDeadLetter(pessoa.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.DeadLetterReprocessada, obj.Status)
+	assert.NotNil(t, obj.ReplayedAt)
+}
@@ -0,0 +1,81 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Verifica se essa IMPLEMENTAÇÃO implementa corretamente a INTERFACE
+var _ repository.OutboxRepositoryInterface = &OutboxRepositoryGorm{}
+
+type OutboxRepositoryGorm struct {
+	DB *gorm.DB
+}
+
+func NewOutboxRepositoryGorm(db *gorm.DB) *OutboxRepositoryGorm {
+	return &OutboxRepositoryGorm{DB: db}
+}
+
+func (r *OutboxRepositoryGorm) WithTransaction(ctx context.Context, fn func(repo repository.OutboxRepositoryInterface) error) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewOutboxRepositoryGorm(tx))
+	})
+}
+
+func (r *OutboxRepositoryGorm) CreateOutboxEvent(obj *entity.OutboxEvent) error {
+	return r.DB.Create(obj).Error
+}
+
+// FindPendingOutboxEvents retorna os eventos pendentes na ordem em que foram gravados.
+// Dentro de WithTransaction os eventos ficam travados (FOR UPDATE SKIP LOCKED), e outra
+// instância do relay pula esses eventos em vez de publicá-los de novo.
+func (r *OutboxRepositoryGorm) FindPendingOutboxEvents(limit int) ([]entity.OutboxEvent, error) {
+	var objs []entity.OutboxEvent
+	err := r.DB.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ?", entity.OutboxPendente).
+		Order("created_at asc").
+		Limit(limit).
+		Find(&objs).Error
+	if err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+func (r *OutboxRepositoryGorm) MarkOutboxEventPublished(objID uuid.UUID) error {
+	now := time.Now()
+	return r.DB.Model(&entity.OutboxEvent{}).
+		Where("id = ?", objID).
+		Updates(map[string]interface{}{
+			"status":       entity.OutboxPublicado,
+			"published_at": &now,
+			"last_error":   "",
+		}).Error
+}
+
+func (r *OutboxRepositoryGorm) MarkOutboxEventFailed(objID uuid.UUID, errMsg string) error {
+	return r.DB.Model(&entity.OutboxEvent{}).
+		Where("id = ?", objID).
+		Updates(map[string]interface{}{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": errMsg,
+		}).Error
+}
+
+// MarkOutboxEventDead registra a última falha e tira o evento da fila de publicação
+func (r *OutboxRepositoryGorm) MarkOutboxEventDead(objID uuid.UUID, errMsg string) error {
+	return r.DB.Model(&entity.OutboxEvent{}).
+		Where("id = ?", objID).
+		Updates(map[string]interface{}{
+			"status":     entity.OutboxFalhou,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": errMsg,
+		}).Error
+}
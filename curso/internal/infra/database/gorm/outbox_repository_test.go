@@ -0,0 +1,233 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	domain_event "github.com/ggialluisi/nebula-back/curso/internal/domain/event"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/usecase"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupOutboxDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+	db.AutoMigrate(&entity.Pessoa{}, &entity.Curso{}, &entity.Modulo{}, &entity.OutboxEvent{})
+	return db
+}
+
+func TestOutbox_FindPendingAndMarkPublished(t *testing.T) {
+	db := setupOutboxDB(t)
+	outboxDB := NewOutboxRepositoryGorm(db)
+
+	evt, err := entity.NewOutboxEvent("CursoChanged", uuid.New(), map[string]string{"nome": "Curso 1"})
+	assert.NoError(t, err)
+	assert.NoError(t, outboxDB.CreateOutboxEvent(evt))
+
+	pending, err := outboxDB.FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, evt.ID, pending[0].ID)
+	assert.JSONEq(t, `{"nome":"Curso 1"}`, pending[0].Payload)
+
+	assert.NoError(t, outboxDB.MarkOutboxEventFailed(evt.ID, "broker down"))
+	pending, err = outboxDB.FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, "broker down", pending[0].LastError)
+
+	assert.NoError(t, outboxDB.MarkOutboxEventPublished(evt.ID))
+	pending, err = outboxDB.FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 0)
+}
+
+func TestOutbox_MarkDeadRemovesEventFromPending(t *testing.T) {
+	db := setupOutboxDB(t)
+	outboxDB := NewOutboxRepositoryGorm(db)
+
+	e1, err := entity.NewOutboxEvent("EventoSemTopico", uuid.New(), map[string]string{})
+	assert.NoError(t, err)
+	assert.NoError(t, outboxDB.CreateOutboxEvent(e1))
+	e2, err := entity.NewOutboxEvent("CursoChanged", uuid.New(), map[string]string{})
+	assert.NoError(t, err)
+	assert.NoError(t, outboxDB.CreateOutboxEvent(e2))
+
+	err = outboxDB.WithTransaction(context.Background(), func(repo repository.OutboxRepositoryInterface) error {
+		pending, err := repo.FindPendingOutboxEvents(10)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, pending, 2)
+		return repo.MarkOutboxEventDead(e1.ID, "nenhum producer")
+	})
+	assert.NoError(t, err)
+
+	pending, err := outboxDB.FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, e2.ID, pending[0].ID)
+
+	var dead entity.OutboxEvent
+	assert.NoError(t, db.First(&dead, "id = ?", e1.ID).Error)
+	assert.Equal(t, entity.OutboxFalhou, dead.Status)
+	assert.Equal(t, 1, dead.Attempts)
+	assert.Equal(t, "nenhum producer", dead.LastError)
+}
+
+func TestOutbox_WithTransactionCommitsCursoAndEvent(t *testing.T) {
+	db := setupOutboxDB(t)
+	cursoDB := NewCursoRepositoryGorm(db)
+
+	curso, err := entity.NewCurso(nil, "nome curso 1", "descricao 1")
+	assert.NoError(t, err)
+
+	err = cursoDB.WithTransaction(context.Background(), func(repo repository.CursoRepositoryInterface) error {
+		if _, err := repo.CreateCurso(curso); err != nil {
+			return err
+		}
+		evt, err := entity.NewOutboxEvent("CursoChanged", curso.ID, curso)
+		if err != nil {
+			return err
+		}
+		return repo.CreateOutboxEvent(evt)
+	})
+	assert.NoError(t, err)
+
+	_, err = cursoDB.GetCurso(curso.ID)
+	assert.NoError(t, err)
+	pending, err := NewOutboxRepositoryGorm(db).FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, curso.ID, pending[0].AggregateID)
+}
+
+func TestOutbox_WithTransactionRollbackDiscardsEvent(t *testing.T) {
+	db := setupOutboxDB(t)
+	cursoDB := NewCursoRepositoryGorm(db)
+
+	curso, err := entity.NewCurso(nil, "nome curso 1", "descricao 1")
+	assert.NoError(t, err)
+
+	err = cursoDB.WithTransaction(context.Background(), func(repo repository.CursoRepositoryInterface) error {
+		if _, err := repo.CreateCurso(curso); err != nil {
+			return err
+		}
+		evt, err := entity.NewOutboxEvent("CursoChanged", curso.ID, curso)
+		if err != nil {
+			return err
+		}
+		if err := repo.CreateOutboxEvent(evt); err != nil {
+			return err
+		}
+		return errors.New("falha depois de gravar")
+	})
+	assert.Error(t, err)
+
+	_, err = cursoDB.GetCurso(curso.ID)
+	assert.Error(t, err)
+	pending, err := NewOutboxRepositoryGorm(db).FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 0)
+}
+
+func TestOutbox_SaveAndDeleteModuloRecordEvents(t *testing.T) {
+	db := setupOutboxDB(t)
+	cursoDB := NewCursoRepositoryGorm(db)
+	uc := usecase.NewSaveCursoUseCase(cursoDB, NewPessoaRepositoryGorm(db), event_dispatcher.NewEventDispatcher())
+
+	curso, err := uc.ExecuteCreateCurso(context.Background(), dto.CursoInputDTO{Nome: "nome curso 1", Descricao: "descricao 1"})
+	assert.NoError(t, err)
+	modulo, err := uc.ExecuteCreateModulo(context.Background(), dto.ModuloInputDTO{CursoID: curso.ID.String(), Nome: "modulo 1", Descricao: "descricao"})
+	assert.NoError(t, err)
+	assert.NoError(t, uc.ExecuteDeleteModulo(context.Background(), modulo.ID.String()))
+
+	pending, err := NewOutboxRepositoryGorm(db).FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	var names []string
+	for _, evt := range pending {
+		names = append(names, evt.EventName)
+	}
+	assert.Equal(t, []string{"CursoChanged", "ModuloChanged", "ModuloDeleted"}, names)
+	assert.Equal(t, modulo.ID, pending[2].AggregateID)
+	assert.Contains(t, pending[2].Payload, `"deleted_at"`)
+	assert.Contains(t, pending[2].Payload, `"nome":"modulo 1"`)
+}
+
+type eventosRecebidos struct {
+	mu      sync.Mutex
+	eventos []event_dispatcher.EventInterface
+}
+
+func (h *eventosRecebidos) Handle(ctx context.Context, evt event_dispatcher.EventInterface) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.eventos = append(h.eventos, evt)
+	return nil
+}
+
+// Rodar com -race: o usecase é compartilhado entre as goroutines, como no consumidor Kafka,
+// e cada gravação precisa despachar o seu próprio evento.
+func TestOutbox_ConcurrentSavesDispatchIsolatedEvents(t *testing.T) {
+	db := setupOutboxDB(t)
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	// o banco em memória existe só na conexão que o criou
+	sqlDB.SetMaxOpenConns(1)
+
+	recebidos := &eventosRecebidos{}
+	dispatcher := event_dispatcher.NewEventDispatcher()
+	assert.NoError(t, dispatcher.Register(domain_event.CursoChangedName, recebidos))
+	uc := usecase.NewSaveCursoUseCase(NewCursoRepositoryGorm(db), NewPessoaRepositoryGorm(db), dispatcher)
+
+	const total = 20
+	var wg sync.WaitGroup
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := event_dispatcher.WithCorrelationID(context.Background(), fmt.Sprintf("req-%d", i))
+			_, err := uc.ExecuteCreateCurso(ctx, dto.CursoInputDTO{Nome: fmt.Sprintf("curso %d", i), Descricao: "descricao"})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, recebidos.eventos, total)
+	ids := map[string]bool{}
+	for _, evt := range recebidos.eventos {
+		out, ok := evt.GetPayload().(dto.CursoOutputDTO)
+		assert.True(t, ok)
+		assert.Equal(t, out.ID.String(), evt.GetAggregateID())
+		// o payload é o da requisição que gerou o evento
+		var i int
+		_, err := fmt.Sscanf(out.Nome, "curso %d", &i)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("req-%d", i), evt.GetCorrelationID())
+		ids[evt.GetID()] = true
+	}
+	assert.Len(t, ids, total)
+
+	// o outbox guarda a mesma ocorrência que foi despachada localmente
+	pending, err := NewOutboxRepositoryGorm(db).FindPendingOutboxEvents(total)
+	assert.NoError(t, err)
+	assert.Len(t, pending, total)
+	for _, evt := range pending {
+		assert.True(t, ids[evt.ID.String()])
+		assert.NotEmpty(t, evt.CorrelationID)
+	}
+}
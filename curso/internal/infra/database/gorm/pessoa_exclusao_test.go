@@ -0,0 +1,51 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestExclusaoPessoa_AlunosEMatriculas(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+	db.AutoMigrate(&entity.Aluno{}, &entity.AlunoCurso{})
+
+	pessoaID := uuid.New()
+	aluno := entity.Aluno{ID: uuid.New(), PessoaID: pessoaID, NftId: "nft", StatusAluno: entity.StatusAlunoAtivo, Wallet: "0xabc"}
+	outro := entity.Aluno{ID: uuid.New(), PessoaID: uuid.New(), NftId: "nft", StatusAluno: entity.StatusAlunoAtivo}
+	for _, obj := range []*entity.Aluno{&aluno, &outro} {
+		assert.NoError(t, db.Omit("Pessoa").Create(obj).Error)
+	}
+	ativa := entity.AlunoCurso{ID: uuid.New(), AlunoID: aluno.ID, CursoID: uuid.New(), StatusCurso: entity.StatusEmAndamento}
+	aprovada := entity.AlunoCurso{ID: uuid.New(), AlunoID: aluno.ID, CursoID: uuid.New(), StatusCurso: entity.StatusAprovado}
+	for _, obj := range []*entity.AlunoCurso{&ativa, &aprovada} {
+		assert.NoError(t, db.Omit("Aluno", "Curso").Create(obj).Error)
+	}
+
+	cursoDB := NewCursoRepositoryGorm(db)
+	alunos, err := cursoDB.FindAlunosByPessoa(pessoaID)
+	assert.NoError(t, err)
+	assert.Len(t, alunos, 1)
+	assert.Equal(t, aluno.ID, alunos[0].ID)
+
+	count, err := cursoDB.CountMatriculasAtivasDoAluno(aluno.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	aluno.Desativar()
+	aluno.Anonimizar()
+	assert.NoError(t, cursoDB.UpdateAlunoStatusWallet(&aluno))
+
+	var salvo entity.Aluno
+	assert.NoError(t, db.First(&salvo, "id = ?", aluno.ID).Error)
+	assert.Equal(t, entity.StatusAlunoInativo, salvo.StatusAluno)
+	assert.Empty(t, salvo.Wallet)
+}
@@ -1,8 +1,11 @@
 package gorm
 
 import (
+	"context"
+
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/query"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -18,6 +21,12 @@ func NewPessoaRepositoryGorm(db *gorm.DB) *PessoaRepositoryGorm {
 	return &PessoaRepositoryGorm{DB: db}
 }
 
+func (r *PessoaRepositoryGorm) WithTransaction(ctx context.Context, fn func(repo repository.PessoaRepositoryInterface) error) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewPessoaRepositoryGorm(tx))
+	})
+}
+
 func (r *PessoaRepositoryGorm) CreatePessoa(obj *entity.Pessoa) (*entity.Pessoa, error) {
 	if err := r.DB.Create(obj).Error; err != nil {
 		return nil, err
@@ -50,8 +59,6 @@ func (r *PessoaRepositoryGorm) DeletePessoa(objID uuid.UUID) error {
 	return r.DB.Delete(&entity.Pessoa{}, objID).Error
 }
 
-func (r *PessoaRepositoryGorm) GetPessoas() ([]entity.Pessoa, error) {
-	var itens []entity.Pessoa
-	err := r.DB.Find(&itens).Error
-	return itens, err
+func (r *PessoaRepositoryGorm) FindAllPessoas(spec query.Spec) (query.Page[entity.Pessoa], error) {
+	return query.Find[entity.Pessoa](r.DB, spec)
 }
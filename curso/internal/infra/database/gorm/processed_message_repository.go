@@ -0,0 +1,33 @@
+package gorm
+
+import (
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Verifica se essa IMPLEMENTAÇÃO implementa corretamente a INTERFACE
+var _ repository.ProcessedMessageRepositoryInterface = &ProcessedMessageRepositoryGorm{}
+
+type ProcessedMessageRepositoryGorm struct {
+	DB *gorm.DB
+}
+
+func NewProcessedMessageRepositoryGorm(db *gorm.DB) *ProcessedMessageRepositoryGorm {
+	return &ProcessedMessageRepositoryGorm{DB: db}
+}
+
+func (r *ProcessedMessageRepositoryGorm) IsProcessed(topic, eventID string) (bool, error) {
+	var count int64
+	err := r.DB.Model(&entity.ProcessedMessage{}).
+		Where("topic = ? AND event_id = ?", topic, eventID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// MarkProcessed é idempotente: registrar o mesmo evento de novo não gera erro
+func (r *ProcessedMessageRepositoryGorm) MarkProcessed(topic, eventID string) error {
+	return r.DB.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.ProcessedMessage{Topic: topic, EventID: eventID}).Error
+}
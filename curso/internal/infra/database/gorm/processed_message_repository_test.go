@@ -0,0 +1,35 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestProcessedMessageRepository(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+	db.AutoMigrate(&entity.ProcessedMessage{})
+
+	repo := NewProcessedMessageRepositoryGorm(db)
+	processed, err := repo.IsProcessed("pessoa.saved", "evt-1")
+	assert.NoError(t, err)
+	assert.False(t, processed)
+
+	assert.NoError(t, repo.MarkProcessed("pessoa.saved", "evt-1"))
+	assert.NoError(t, repo.MarkProcessed("pessoa.saved", "evt-1"))
+
+	processed, err = repo.IsProcessed("pessoa.saved", "evt-1")
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	processed, err = repo.IsProcessed("pessoa.deleted", "evt-1")
+	assert.NoError(t, err)
+	assert.False(t, processed)
+}
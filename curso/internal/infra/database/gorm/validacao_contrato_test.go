@@ -0,0 +1,81 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestFindValidacoesContratoPendentes(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+	db.AutoMigrate(&entity.ItemModulo{}, &entity.ItemModuloContractValidation{}, &entity.AlunoCursoItemModulo{}, &entity.ValidacaoContratoEvento{})
+
+	itemContrato := entity.ItemModulo{ID: uuid.New(), ModuloID: uuid.New(), Nome: "deploy", Tipo: entity.ItemContractValidate, EstimativaTempoMin: 10, Ordem: 1}
+	itemAula := entity.ItemModulo{ID: uuid.New(), ModuloID: itemContrato.ModuloID, Nome: "aula", Tipo: entity.ItemAula, EstimativaTempoMin: 10, Ordem: 2}
+	assert.NoError(t, db.Create(&itemContrato).Error)
+	assert.NoError(t, db.Create(&itemAula).Error)
+
+	porTx := entity.AlunoCursoItemModulo{ID: uuid.New(), ItemModuloID: itemContrato.ID, BlockchainTxEnvio: "0xABC", StatusValidacaoContrato: entity.TipoStatusValidacaoContratoPendente}
+	porEndereco := entity.AlunoCursoItemModulo{ID: uuid.New(), ItemModuloID: itemContrato.ID, EnderecoContratoValidar: "0xd47b84cd828538ee33264911e117e3557af39231", StatusValidacaoContrato: entity.TipoStatusValidacaoContratoPendente}
+	jaConcluido := entity.AlunoCursoItemModulo{ID: uuid.New(), ItemModuloID: itemContrato.ID, BlockchainTxEnvio: "0xabc", StatusValidacaoContrato: entity.TipoStatusValidacaoContratoConcluido}
+	comErro := entity.AlunoCursoItemModulo{ID: uuid.New(), ItemModuloID: itemContrato.ID, BlockchainTxEnvio: "0x999", EnderecoContratoValidar: "0xd47b84cd828538ee33264911e117e3557af39231", StatusValidacaoContrato: entity.TipoStatusValidacaoContratoErro}
+	aula := entity.AlunoCursoItemModulo{ID: uuid.New(), ItemModuloID: itemAula.ID, BlockchainTxEnvio: "0xabc"}
+	for _, obj := range []*entity.AlunoCursoItemModulo{&porTx, &porEndereco, &jaConcluido, &comErro, &aula} {
+		assert.NoError(t, db.Omit("AlunoCurso", "ItemModulo").Create(obj).Error)
+	}
+
+	cursoDB := NewCursoRepositoryGorm(db)
+	itens, err := cursoDB.FindValidacoesContratoPendentes("0xabc", []string{})
+	assert.NoError(t, err)
+	assert.Len(t, itens, 1)
+	assert.Equal(t, porTx.ID, itens[0].ID)
+
+	// itens com erro podem ser validados por um novo evento
+	itens, err = cursoDB.FindValidacoesContratoPendentes("0xdef", []string{"0xd47b84cd828538ee33264911e117e3557af39231"})
+	assert.NoError(t, err)
+	assert.Len(t, itens, 2)
+	assert.ElementsMatch(t, []uuid.UUID{porEndereco.ID, comErro.ID}, []uuid.UUID{itens[0].ID, itens[1].ID})
+	assert.Equal(t, entity.ItemContractValidate, itens[0].ItemModulo.Tipo)
+}
+
+func TestFindValidacoesContratoPorTx_ResetValidacaoContrato(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+	db.AutoMigrate(&entity.ItemModulo{}, &entity.ItemModuloContractValidation{}, &entity.AlunoCursoItemModulo{}, &entity.ValidacaoContratoEvento{})
+
+	itemContrato := entity.ItemModulo{ID: uuid.New(), ModuloID: uuid.New(), Nome: "deploy", Tipo: entity.ItemContractValidate, EstimativaTempoMin: 10, Ordem: 1}
+	assert.NoError(t, db.Create(&itemContrato).Error)
+
+	concluido := entity.AlunoCursoItemModulo{ID: uuid.New(), ItemModuloID: itemContrato.ID, BlockchainTxEnvio: "0xAAA", Status: entity.TipoStatusItemModuloConcluido, Progresso: 100, StatusValidacaoContrato: entity.TipoStatusValidacaoContratoConcluido}
+	pendente := entity.AlunoCursoItemModulo{ID: uuid.New(), ItemModuloID: itemContrato.ID, BlockchainTxEnvio: "0xaaa", StatusValidacaoContrato: entity.TipoStatusValidacaoContratoPendente}
+	outraTx := entity.AlunoCursoItemModulo{ID: uuid.New(), ItemModuloID: itemContrato.ID, BlockchainTxEnvio: "0xbbb", StatusValidacaoContrato: entity.TipoStatusValidacaoContratoConcluido}
+	for _, obj := range []*entity.AlunoCursoItemModulo{&concluido, &pendente, &outraTx} {
+		assert.NoError(t, db.Omit("AlunoCurso", "ItemModulo").Create(obj).Error)
+	}
+
+	cursoDB := NewCursoRepositoryGorm(db)
+	itens, err := cursoDB.FindValidacoesContratoPorTx("0xaaa")
+	assert.NoError(t, err)
+	assert.Len(t, itens, 1)
+	assert.Equal(t, concluido.ID, itens[0].ID)
+
+	itens[0].ReverterValidacaoContrato()
+	assert.NoError(t, cursoDB.ResetValidacaoContrato(&itens[0]))
+
+	var salvo entity.AlunoCursoItemModulo
+	assert.NoError(t, db.First(&salvo, "id = ?", concluido.ID).Error)
+	assert.Equal(t, entity.TipoStatusValidacaoContratoPendente, salvo.StatusValidacaoContrato)
+	assert.Equal(t, entity.TipoStatusItemModuloEmAndamento, salvo.Status)
+	assert.Equal(t, float32(0), salvo.Progresso)
+	assert.Equal(t, "0xAAA", salvo.BlockchainTxEnvio)
+}
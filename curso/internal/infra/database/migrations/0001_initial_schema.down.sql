@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS "processed_messages";
+DROP TABLE IF EXISTS "dead_letters";
+DROP TABLE IF EXISTS "validacao_contrato_eventos";
+DROP TABLE IF EXISTS "outbox_events";
+DROP TABLE IF EXISTS "aluno_curso_item_modulos";
+DROP TABLE IF EXISTS "item_modulo_videos";
+DROP TABLE IF EXISTS "item_modulo_contract_validations";
+DROP TABLE IF EXISTS "item_modulo_aulas";
+DROP TABLE IF EXISTS "item_modulos";
+DROP TABLE IF EXISTS "aluno_cursos";
+DROP TABLE IF EXISTS "alunos";
+DROP TABLE IF EXISTS "modulos";
+DROP TABLE IF EXISTS "cursos";
+DROP TABLE IF EXISTS "pessoas";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,188 @@
+-- Schema inicial, gerado a partir das entidades que o AutoMigrate criava.
+-- IF NOT EXISTS permite adotar bancos já criados pelo AutoMigrate.
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" uuid,
+    "name" varchar(100),
+    "email" varchar(100) NOT NULL,
+    "password" varchar(100),
+    "role" varchar(20) DEFAULT 'aluno',
+    "aluno_id" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email")
+);
+
+CREATE TABLE IF NOT EXISTS "pessoas" (
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "id" uuid,
+    "tipo" varchar(20),
+    "nome" varchar(100),
+    "atualizado_na_origem_em" timestamptz,
+    "exclusao_solicitada_em" timestamptz,
+    "anonimizada" boolean,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "cursos" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "nome" varchar(100),
+    "descricao" varchar(1000),
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "modulos" (
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "id" uuid,
+    "curso_id" uuid,
+    "nome" varchar(100),
+    "descricao" varchar(1000),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_cursos_modulos" FOREIGN KEY ("curso_id") REFERENCES "cursos"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "alunos" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "pessoa_id" uuid,
+    "data_inicio" date,
+    "xp_total" bigint,
+    "nft_id" varchar(100),
+    "status_aluno" varchar(20),
+    "wallet" varchar(200),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_alunos_pessoa" FOREIGN KEY ("pessoa_id") REFERENCES "pessoas"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "aluno_cursos" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "aluno_id" uuid,
+    "curso_id" uuid,
+    "data_matricula" date,
+    "percentual_concluido" numeric,
+    "status_curso" varchar(20),
+    "status_pagamento" varchar(20),
+    "xp_ganho" bigint,
+    "xp_disponivel" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_aluno_cursos_aluno" FOREIGN KEY ("aluno_id") REFERENCES "alunos"("id"),
+    CONSTRAINT "fk_aluno_cursos_curso" FOREIGN KEY ("curso_id") REFERENCES "cursos"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "item_modulos" (
+    "id" uuid,
+    "modulo_id" uuid,
+    "nome" varchar(200),
+    "descricao" varchar(1000),
+    "estimativa_tempo_min" bigint,
+    "ordem" bigint,
+    "tipo" varchar(30),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "item_modulo_aulas" (
+    "item_modulo_id" uuid,
+    "texto" text,
+    PRIMARY KEY ("item_modulo_id"),
+    CONSTRAINT "fk_item_modulos_aula" FOREIGN KEY ("item_modulo_id") REFERENCES "item_modulos"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "item_modulo_contract_validations" (
+    "item_modulo_id" uuid,
+    "rede" varchar(20),
+    "endereco_contrato" varchar(100),
+    PRIMARY KEY ("item_modulo_id"),
+    CONSTRAINT "fk_item_modulos_contract_validation" FOREIGN KEY ("item_modulo_id") REFERENCES "item_modulos"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "item_modulo_videos" (
+    "item_modulo_id" uuid,
+    "video_url" varchar(255),
+    PRIMARY KEY ("item_modulo_id"),
+    CONSTRAINT "fk_item_modulos_video" FOREIGN KEY ("item_modulo_id") REFERENCES "item_modulos"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "aluno_curso_item_modulos" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "aluno_curso_id" uuid,
+    "item_modulo_id" uuid,
+    "status" varchar(50),
+    "progresso" numeric,
+    "tempo_assistido" bigint,
+    "endereco_contrato_validar" varchar(255),
+    "blockchain_rede_validacao" varchar(20),
+    "blockchain_tx_envio" varchar(255),
+    "status_validacao_contrato" varchar(50),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_aluno_curso_item_modulos_aluno_curso" FOREIGN KEY ("aluno_curso_id") REFERENCES "aluno_cursos"("id"),
+    CONSTRAINT "fk_aluno_curso_item_modulos_item_modulo" FOREIGN KEY ("item_modulo_id") REFERENCES "item_modulos"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+    "created_at" timestamptz,
+    "id" uuid,
+    "event_name" varchar(100) NOT NULL,
+    "aggregate_id" uuid,
+    "correlation_id" varchar(100),
+    "request_id" varchar(100),
+    "trace_parent" varchar(100),
+    "payload" text,
+    "status" varchar(20) DEFAULT 'pendente',
+    "attempts" bigint,
+    "last_error" text,
+    "published_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_created_at" ON "outbox_events" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_status" ON "outbox_events" ("status");
+
+CREATE TABLE IF NOT EXISTS "validacao_contrato_eventos" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "aluno_curso_item_modulo_id" uuid,
+    "tx_hash" varchar(100),
+    "contrato" varchar(100),
+    "evento" varchar(100),
+    "bloco" bigint,
+    "resultado" varchar(50),
+    "payload" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_validacao_contrato_eventos_tx_hash" ON "validacao_contrato_eventos" ("tx_hash");
+CREATE INDEX IF NOT EXISTS "idx_validacao_contrato_eventos_aluno_curso_item_modulo_id" ON "validacao_contrato_eventos" ("aluno_curso_item_modulo_id");
+
+CREATE TABLE IF NOT EXISTS "dead_letters" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "topic" varchar(200) NOT NULL,
+    "partition" integer,
+    "offset" bigint,
+    "key" text,
+    "value" text,
+    "headers" text,
+    "error" text,
+    "attempts" bigint,
+    "status" varchar(20) DEFAULT 'pendente',
+    "replayed_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_dead_letters_status" ON "dead_letters" ("status");
+CREATE INDEX IF NOT EXISTS "idx_dead_letters_topic" ON "dead_letters" ("topic");
+CREATE INDEX IF NOT EXISTS "idx_dead_letters_created_at" ON "dead_letters" ("created_at");
+
+CREATE TABLE IF NOT EXISTS "processed_messages" (
+    "topic" varchar(200),
+    "event_id" varchar(100),
+    "processed_at" timestamptz,
+    PRIMARY KEY ("topic","event_id")
+);
@@ -0,0 +1,4 @@
+-- Uma wallet pertence a um único aluno: é por ela que os eventos da blockchain chegam
+-- ao aluno. Falha se já houver wallets repetidas: resolva-as antes.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_alunos_wallet" ON "alunos" ("wallet")
+    WHERE "wallet" <> '';
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "idx_item_modulo_aulas_busca";
+ALTER TABLE "item_modulo_aulas" DROP COLUMN IF EXISTS "busca";
+
+DROP INDEX IF EXISTS "idx_item_modulos_busca";
+ALTER TABLE "item_modulos" DROP COLUMN IF EXISTS "busca";
+
+DROP INDEX IF EXISTS "idx_modulos_busca";
+ALTER TABLE "modulos" DROP COLUMN IF EXISTS "busca";
+
+DROP INDEX IF EXISTS "idx_cursos_busca";
+ALTER TABLE "cursos" DROP COLUMN IF EXISTS "busca";
@@ -0,0 +1,33 @@
+-- Busca textual do catálogo (GET /search). Cada tabela ganha uma coluna tsvector gerada
+-- pelo próprio banco, indexada com GIN. O conteúdo mistura português e espanhol, então
+-- o texto é indexado com os dois dicionários. Pesos: A nome, B descrição, C texto da aula.
+
+ALTER TABLE "cursos" ADD COLUMN IF NOT EXISTS "busca" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('portuguese', coalesce("nome", '')), 'A') ||
+    setweight(to_tsvector('spanish', coalesce("nome", '')), 'A') ||
+    setweight(to_tsvector('portuguese', coalesce("descricao", '')), 'B') ||
+    setweight(to_tsvector('spanish', coalesce("descricao", '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS "idx_cursos_busca" ON "cursos" USING GIN ("busca");
+
+ALTER TABLE "modulos" ADD COLUMN IF NOT EXISTS "busca" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('portuguese', coalesce("nome", '')), 'A') ||
+    setweight(to_tsvector('spanish', coalesce("nome", '')), 'A') ||
+    setweight(to_tsvector('portuguese', coalesce("descricao", '')), 'B') ||
+    setweight(to_tsvector('spanish', coalesce("descricao", '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS "idx_modulos_busca" ON "modulos" USING GIN ("busca");
+
+ALTER TABLE "item_modulos" ADD COLUMN IF NOT EXISTS "busca" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('portuguese', coalesce("nome", '')), 'A') ||
+    setweight(to_tsvector('spanish', coalesce("nome", '')), 'A') ||
+    setweight(to_tsvector('portuguese', coalesce("descricao", '')), 'B') ||
+    setweight(to_tsvector('spanish', coalesce("descricao", '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS "idx_item_modulos_busca" ON "item_modulos" USING GIN ("busca");
+
+ALTER TABLE "item_modulo_aulas" ADD COLUMN IF NOT EXISTS "busca" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('portuguese', coalesce("texto", '')), 'C') ||
+    setweight(to_tsvector('spanish', coalesce("texto", '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS "idx_item_modulo_aulas_busca" ON "item_modulo_aulas" USING GIN ("busca");
@@ -0,0 +1,11 @@
+// Package migrations contém as migrações SQL versionadas do banco do serviço, aplicadas
+// pelo platform/migrate na subida ou pelo subcomando migrate.
+//
+// Novas migrações seguem a numeração: NNNN_nome.up.sql e NNNN_nome.down.sql. As que só
+// rodam no PostgreSQL (tsvector, GIN) levam o dialeto no nome: NNNN_nome.postgres.up.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
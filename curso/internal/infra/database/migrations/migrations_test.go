@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/migrate"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var entities = []interface{}{
+	&entity.User{},
+	&entity.Pessoa{},
+	&entity.Curso{},
+	&entity.Modulo{},
+	&entity.Aluno{},
+	&entity.AlunoCurso{},
+	&entity.ItemModulo{},
+	&entity.ItemModuloAula{},
+	&entity.ItemModuloContractValidation{},
+	&entity.ItemModuloVideo{},
+	&entity.AlunoCursoItemModulo{},
+	&entity.OutboxEvent{},
+	&entity.ValidacaoContratoEvento{},
+	&entity.DeadLetter{},
+	&entity.ProcessedMessage{},
+}
+
+func newMigratedDB(t *testing.T) (*gorm.DB, *migrate.Migrator) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	m, err := migrate.New(sqlDB, "sqlite", FS, migrate.NoLock)
+	require.NoError(t, err)
+	_, err = m.Up(context.Background())
+	require.NoError(t, err)
+	return db, m
+}
+
+// As migrações precisam acompanhar as entidades: toda coluna mapeada pelo GORM deve existir.
+func TestMigrations_CobremAsEntidades(t *testing.T) {
+	db, _ := newMigratedDB(t)
+
+	for _, e := range entities {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(e))
+		require.True(t, db.Migrator().HasTable(e), "tabela %s", stmt.Schema.Table)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			assert.True(t, db.Migrator().HasColumn(e, field.DBName), "coluna %s.%s", stmt.Schema.Table, field.DBName)
+		}
+	}
+}
+
+func TestMigrations_WalletUnica(t *testing.T) {
+	db, _ := newMigratedDB(t)
+
+	assert.NoError(t, db.Create(&entity.Aluno{ID: uuid.New(), Wallet: "0xabc"}).Error)
+	assert.Error(t, db.Create(&entity.Aluno{ID: uuid.New(), Wallet: "0xabc"}).Error)
+	assert.NoError(t, db.Create(&entity.Aluno{ID: uuid.New()}).Error)
+	assert.NoError(t, db.Create(&entity.Aluno{ID: uuid.New()}).Error)
+}
+
+func TestMigrations_DownRemoveTudo(t *testing.T) {
+	db, m := newMigratedDB(t)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	reverted, err := m.Down(context.Background(), len(statuses))
+	require.NoError(t, err)
+	assert.Equal(t, len(statuses), reverted)
+
+	for _, e := range entities {
+		assert.False(t, db.Migrator().HasTable(e))
+	}
+}
@@ -0,0 +1,239 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/metrics"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Headers adicionados às mensagens enviadas para a DLQ e às reprocessadas
+const (
+	HeaderDLQError             = "x-dlq-error"
+	HeaderDLQAttempts          = "x-dlq-attempts"
+	HeaderDLQOriginalTopic     = "x-dlq-original-topic"
+	HeaderDLQOriginalPartition = "x-dlq-original-partition"
+	HeaderDLQOriginalOffset    = "x-dlq-original-offset"
+	HeaderReplayOf             = "x-replay-of"
+)
+
+// DeadLetterTopic retorna o tópico de dead letters de um tópico.
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+// MessagePublisher publica mensagens com headers em qualquer tópico (DLQ e replay).
+type MessagePublisher interface {
+	PublishToTopic(ctx context.Context, topic, key string, value []byte, headers map[string]string) error
+}
+
+// permanentError marca erros que não adianta tentar de novo (ex.: payload inválido).
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent faz a mensagem ir direto para a DLQ, sem novas tentativas.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent informa se o erro foi marcado com Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// RetryPolicy define quantas vezes uma mensagem é processada antes de ir para a DLQ
+// e o intervalo entre as tentativas, que dobra a cada falha até MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// Backoff retorna a espera antes da próxima tentativa, depois de attempt falhas.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if wait >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return wait
+}
+
+// MessageHandleFunc processa uma mensagem. O contexto leva o correlation_id, o request_id
+// e o span do processamento, continuando o trace de quem publicou a mensagem.
+type MessageHandleFunc func(ctx context.Context, msg *sarama.ConsumerMessage) error
+
+// RetryingConsumerHandler é o handler de ConsumerGroup dos consumidores do curso.
+// Cada mensagem é processada até Policy.MaxAttempts vezes; se continuar falhando é
+// publicada em <topic>.dlq com o erro e os headers originais, registrada no repositório
+// e marcada como consumida, para não travar a partição.
+type RetryingConsumerHandler struct {
+	Handle     MessageHandleFunc
+	Policy     RetryPolicy
+	Publisher  MessagePublisher
+	Repository repository.DeadLetterRepositoryInterface
+}
+
+func NewRetryingConsumerHandler(
+	handle MessageHandleFunc,
+	policy RetryPolicy,
+	publisher MessagePublisher,
+	repo repository.DeadLetterRepositoryInterface,
+) *RetryingConsumerHandler {
+	return &RetryingConsumerHandler{
+		Handle:     handle,
+		Policy:     policy,
+		Publisher:  publisher,
+		Repository: repo,
+	}
+}
+
+func (h *RetryingConsumerHandler) Setup(_ sarama.ConsumerGroupSession) error   { return nil }
+func (h *RetryingConsumerHandler) Cleanup(_ sarama.ConsumerGroupSession) error { return nil }
+func (h *RetryingConsumerHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		err := h.Process(sess.Context(), msg)
+		if err != nil {
+			// sem marcar: a mensagem volta a ser entregue na próxima sessão
+			return err
+		}
+		sess.MarkMessage(msg, "")
+	}
+	return nil
+}
+
+// Process executa o handler com novas tentativas e envia a mensagem para a DLQ quando esgotadas.
+// Retorna erro apenas quando a mensagem não pode ser marcada como consumida.
+func (h *RetryingConsumerHandler) Process(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	maxAttempts := h.Policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	msgCtx, span := tracing.StartConsumer(messageContext(msg), msg.Topic, consumerHeaders(msg))
+	defer span.End()
+
+	var err error
+	attempt := 0
+	for attempt < maxAttempts {
+		attempt++
+		err = h.Handle(msgCtx, msg)
+		if err == nil {
+			return nil
+		}
+		span.RecordError(err)
+		metrics.KafkaConsumeErrors.WithLabelValues(msg.Topic, "retry").Inc()
+		if IsPermanent(err) || attempt == maxAttempts {
+			break
+		}
+
+		wait := h.Policy.Backoff(attempt)
+		slog.WarnContext(msgCtx, "falha ao processar mensagem",
+			"topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset,
+			"attempt", attempt, "max_attempts", maxAttempts, "retry_in", wait.String(), logging.Err(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+
+	span.SetStatus(codes.Error, err.Error())
+	return h.sendToDeadLetter(msgCtx, msg, attempt, err)
+}
+
+func (h *RetryingConsumerHandler) sendToDeadLetter(ctx context.Context, msg *sarama.ConsumerMessage, attempts int, cause error) error {
+	headers := consumerHeaders(msg)
+
+	dlqHeaders := make(map[string]string, len(headers)+5)
+	for k, v := range headers {
+		dlqHeaders[k] = v
+	}
+	dlqHeaders[HeaderDLQError] = cause.Error()
+	dlqHeaders[HeaderDLQAttempts] = strconv.Itoa(attempts)
+	dlqHeaders[HeaderDLQOriginalTopic] = msg.Topic
+	dlqHeaders[HeaderDLQOriginalPartition] = strconv.Itoa(int(msg.Partition))
+	dlqHeaders[HeaderDLQOriginalOffset] = strconv.FormatInt(msg.Offset, 10)
+
+	dlqTopic := DeadLetterTopic(msg.Topic)
+	err := h.Publisher.PublishToTopic(ctx, dlqTopic, string(msg.Key), msg.Value, dlqHeaders)
+	if err != nil {
+		return fmt.Errorf("publicar em %s: %w", dlqTopic, err)
+	}
+	metrics.KafkaConsumeErrors.WithLabelValues(msg.Topic, "dead_letter").Inc()
+	slog.ErrorContext(ctx, "mensagem enviada para a DLQ",
+		"topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset,
+		"dlq_topic", dlqTopic, "attempts", attempts, logging.Err(cause))
+
+	if h.Repository == nil {
+		return nil
+	}
+	obj, err := entity.NewDeadLetter(msg.Topic, msg.Partition, msg.Offset, string(msg.Key), string(msg.Value), headers, cause.Error(), attempts)
+	if err == nil {
+		err = h.Repository.CreateDeadLetter(obj)
+	}
+	if err != nil {
+		// a mensagem já está na DLQ do Kafka; só não aparece na listagem
+		slog.ErrorContext(ctx, "erro ao registrar dead letter",
+			"topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset, logging.Err(err))
+	}
+	return nil
+}
+
+// messageContext devolve o contexto usado para processar a mensagem, levando adiante
+// o correlation_id e o request_id recebidos para os eventos e os logs que o
+// processamento gerar.
+func messageContext(msg *sarama.ConsumerMessage) context.Context {
+	ctx := context.Background()
+	headers := consumerHeaders(msg)
+	if correlationID := headers[HeaderCorrelationID]; correlationID != "" {
+		ctx = event_dispatcher.WithCorrelationID(ctx, correlationID)
+	}
+	if requestID := headers[HeaderRequestID]; requestID != "" {
+		ctx = event_dispatcher.WithRequestID(ctx, requestID)
+	}
+	return ctx
+}
+
+func consumerHeaders(msg *sarama.ConsumerMessage) map[string]string {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		if h == nil {
+			continue
+		}
+		headers[string(h.Key)] = string(h.Value)
+	}
+	return headers
+}
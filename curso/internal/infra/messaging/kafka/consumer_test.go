@@ -0,0 +1,174 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/query"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type publishedMessage struct {
+	topic   string
+	key     string
+	value   string
+	headers map[string]string
+}
+
+type fakePublisher struct {
+	err      error
+	messages []publishedMessage
+}
+
+func (f *fakePublisher) PublishToTopic(ctx context.Context, topic, key string, value []byte, headers map[string]string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.messages = append(f.messages, publishedMessage{topic: topic, key: key, value: string(value), headers: headers})
+	return nil
+}
+
+type fakeDeadLetterRepository struct {
+	objs map[uuid.UUID]*entity.DeadLetter
+}
+
+func newFakeDeadLetterRepository() *fakeDeadLetterRepository {
+	return &fakeDeadLetterRepository{objs: map[uuid.UUID]*entity.DeadLetter{}}
+}
+
+func (f *fakeDeadLetterRepository) CreateDeadLetter(obj *entity.DeadLetter) error {
+	f.objs[obj.ID] = obj
+	return nil
+}
+
+func (f *fakeDeadLetterRepository) GetDeadLetter(objID uuid.UUID) (*entity.DeadLetter, error) {
+	obj, ok := f.objs[objID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return obj, nil
+}
+
+func (f *fakeDeadLetterRepository) FindDeadLetters(spec query.Spec) (query.Page[entity.DeadLetter], error) {
+	var objs []entity.DeadLetter
+	for _, obj := range f.objs {
+		objs = append(objs, *obj)
+	}
+	return query.NewPage(objs, int64(len(objs)), spec), nil
+}
+
+func (f *fakeDeadLetterRepository) MarkDeadLetterReplayed(objID uuid.UUID) error {
+	f.objs[objID].Status = entity.DeadLetterReprocessada
+	return nil
+}
+
+func newConsumerMessage() *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Topic:     "pessoa.saved",
+		Partition: 0,
+		Offset:    42,
+		Key:       []byte("chave"),
+		Value:     []byte(`{"id":"1"}`),
+		Headers:   []*sarama.RecordHeader{{Key: []byte("schema_version"), Value: []byte("1")}},
+	}
+}
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+func TestRetryingConsumerHandler_RetriesUntilSuccess(t *testing.T) {
+	calls := 0
+	publisher := &fakePublisher{}
+	h := NewRetryingConsumerHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		calls++
+		if calls < 3 {
+			return errors.New("banco indisponível")
+		}
+		return nil
+	}, testRetryPolicy, publisher, newFakeDeadLetterRepository())
+
+	err := h.Process(context.Background(), newConsumerMessage())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Empty(t, publisher.messages)
+}
+
+func TestRetryingConsumerHandler_SendsToDeadLetterAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	publisher := &fakePublisher{}
+	repo := newFakeDeadLetterRepository()
+	h := NewRetryingConsumerHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		calls++
+		return errors.New("banco indisponível")
+	}, testRetryPolicy, publisher, repo)
+
+	err := h.Process(context.Background(), newConsumerMessage())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Len(t, publisher.messages, 1)
+
+	dlq := publisher.messages[0]
+	assert.Equal(t, "pessoa.saved.dlq", dlq.topic)
+	assert.Equal(t, "chave", dlq.key)
+	assert.Equal(t, "1", dlq.headers["schema_version"])
+	assert.Equal(t, "banco indisponível", dlq.headers[HeaderDLQError])
+	assert.Equal(t, "3", dlq.headers[HeaderDLQAttempts])
+	assert.Equal(t, "42", dlq.headers[HeaderDLQOriginalOffset])
+	assert.Len(t, repo.objs, 1)
+}
+
+func TestRetryingConsumerHandler_PermanentErrorSkipsRetries(t *testing.T) {
+	calls := 0
+	publisher := &fakePublisher{}
+	h := NewRetryingConsumerHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		calls++
+		return Permanent(errors.New("json inválido"))
+	}, testRetryPolicy, publisher, newFakeDeadLetterRepository())
+
+	err := h.Process(context.Background(), newConsumerMessage())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Len(t, publisher.messages, 1)
+}
+
+func TestRetryingConsumerHandler_DeadLetterPublishFailureKeepsMessage(t *testing.T) {
+	publisher := &fakePublisher{err: errors.New("broker indisponível")}
+	h := NewRetryingConsumerHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		return Permanent(errors.New("json inválido"))
+	}, testRetryPolicy, publisher, newFakeDeadLetterRepository())
+
+	err := h.Process(context.Background(), newConsumerMessage())
+	assert.Error(t, err)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second}
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2))
+	assert.Equal(t, 3*time.Second, policy.Backoff(3))
+}
+
+func TestDeadLetterReplayer_Replay(t *testing.T) {
+	repo := newFakeDeadLetterRepository()
+	obj, err := entity.NewDeadLetter("pessoa.saved", 0, 42, "chave", `{"id":"1"}`, map[string]string{"schema_version": "1"}, "erro", 3)
+	assert.NoError(t, err)
+	repo.CreateDeadLetter(obj)
+
+	publisher := &fakePublisher{}
+	replayer := NewDeadLetterReplayer(repo, publisher)
+	replayed, err := replayer.Replay(context.Background(), obj.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.DeadLetterReprocessada, replayed.Status)
+	assert.Len(t, publisher.messages, 1)
+	assert.Equal(t, "pessoa.saved", publisher.messages[0].topic)
+	assert.Equal(t, "1", publisher.messages[0].headers["schema_version"])
+	assert.Equal(t, obj.ID.String(), publisher.messages[0].headers[HeaderReplayOf])
+
+	_, err = replayer.Replay(context.Background(), obj.ID)
+	assert.ErrorIs(t, err, entity.ErrDeadLetterReprocessada)
+	assert.Len(t, publisher.messages, 1)
+}
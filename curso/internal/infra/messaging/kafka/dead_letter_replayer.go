@@ -0,0 +1,49 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// DeadLetterReplayer republica uma dead letter no tópico original, com os headers originais.
+type DeadLetterReplayer struct {
+	Repository repository.DeadLetterRepositoryInterface
+	Publisher  MessagePublisher
+}
+
+func NewDeadLetterReplayer(repo repository.DeadLetterRepositoryInterface, publisher MessagePublisher) *DeadLetterReplayer {
+	return &DeadLetterReplayer{
+		Repository: repo,
+		Publisher:  publisher,
+	}
+}
+
+func (r *DeadLetterReplayer) Replay(ctx context.Context, id uuid.UUID) (*entity.DeadLetter, error) {
+	obj, err := r.Repository.GetDeadLetter(id)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Status == entity.DeadLetterReprocessada {
+		return obj, entity.ErrDeadLetterReprocessada
+	}
+
+	headers, err := obj.HeadersMap()
+	if err != nil {
+		return nil, err
+	}
+	headers[HeaderReplayOf] = obj.ID.String()
+
+	err = r.Publisher.PublishToTopic(ctx, obj.Topic, obj.Key, []byte(obj.Value), headers)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Repository.MarkDeadLetterReplayed(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.Repository.GetDeadLetter(obj.ID)
+}
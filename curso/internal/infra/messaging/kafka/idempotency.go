@@ -0,0 +1,36 @@
+package kafka
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/IBM/sarama"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
+)
+
+// NewIdempotentHandler descarta mensagens cujo event_id já foi processado no tópico
+// e registra o event_id depois que o handler termina sem erro.
+// Mensagens sem event_id (ex.: eventos on-chain) são sempre processadas.
+func NewIdempotentHandler(handle MessageHandleFunc, repo repository.ProcessedMessageRepositoryInterface) MessageHandleFunc {
+	return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		eventID := consumerHeaders(msg)[HeaderEventID]
+		if eventID == "" {
+			return handle(ctx, msg)
+		}
+
+		processed, err := repo.IsProcessed(msg.Topic, eventID)
+		if err != nil {
+			return err
+		}
+		if processed {
+			slog.InfoContext(ctx, "evento já processado, ignorado", "event_id", eventID, "topic", msg.Topic)
+			return nil
+		}
+
+		err = handle(ctx, msg)
+		if err != nil {
+			return err
+		}
+		return repo.MarkProcessed(msg.Topic, eventID)
+	}
+}
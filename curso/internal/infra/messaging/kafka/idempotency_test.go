@@ -0,0 +1,69 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProcessedMessageRepository struct {
+	processed map[string]bool
+}
+
+func (f *fakeProcessedMessageRepository) IsProcessed(topic, eventID string) (bool, error) {
+	return f.processed[topic+"/"+eventID], nil
+}
+
+func (f *fakeProcessedMessageRepository) MarkProcessed(topic, eventID string) error {
+	f.processed[topic+"/"+eventID] = true
+	return nil
+}
+
+func TestIdempotentHandler_SkipsDuplicatedEvent(t *testing.T) {
+	repo := &fakeProcessedMessageRepository{processed: map[string]bool{}}
+	calls := 0
+	handle := NewIdempotentHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		calls++
+		return nil
+	}, repo)
+
+	msg := newConsumerMessage()
+	msg.Headers = append(msg.Headers, &sarama.RecordHeader{Key: []byte(HeaderEventID), Value: []byte("evt-1")})
+
+	assert.NoError(t, handle(context.Background(), msg))
+	assert.NoError(t, handle(context.Background(), msg))
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotentHandler_FailureIsNotMarked(t *testing.T) {
+	repo := &fakeProcessedMessageRepository{processed: map[string]bool{}}
+	calls := 0
+	handle := NewIdempotentHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		calls++
+		if calls == 1 {
+			return errors.New("banco indisponível")
+		}
+		return nil
+	}, repo)
+
+	msg := newConsumerMessage()
+	msg.Headers = append(msg.Headers, &sarama.RecordHeader{Key: []byte(HeaderEventID), Value: []byte("evt-1")})
+
+	assert.Error(t, handle(context.Background(), msg))
+	assert.NoError(t, handle(context.Background(), msg))
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotentHandler_WithoutEventIDAlwaysProcesses(t *testing.T) {
+	repo := &fakeProcessedMessageRepository{processed: map[string]bool{}}
+	h := NewRetryingConsumerHandler(NewIdempotentHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		return nil
+	}, repo), testRetryPolicy, &fakePublisher{}, nil)
+
+	assert.NoError(t, h.Process(context.Background(), newConsumerMessage()))
+	assert.NoError(t, h.Process(context.Background(), newConsumerMessage()))
+	assert.Empty(t, repo.processed)
+}
@@ -0,0 +1,69 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/IBM/sarama"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/usecase"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+)
+
+type EthEventKafkaHandlers struct {
+	CursoUseCase *usecase.SaveCursoUseCase
+}
+
+func NewEthEventKafkaHandlers(
+	CursoUseCase *usecase.SaveCursoUseCase,
+) *EthEventKafkaHandlers {
+	return &EthEventKafkaHandlers{
+		CursoUseCase: CursoUseCase,
+	}
+}
+
+// Handler para mensagens do eth-listener: valida os itens de contrato pendentes
+func (h *EthEventKafkaHandlers) ValidarContrato(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	var inputDto dto.EthEventInputDTO
+
+	err := json.Unmarshal(msg.Value, &inputDto)
+	if err != nil {
+		// mensagem inválida vai direto para a DLQ
+		slog.ErrorContext(ctx, "erro ao decodificar evento on-chain", logging.Err(err))
+		return Permanent(err)
+	}
+	if inputDto.SchemaVersion != dto.EthEventSchemaVersion {
+		slog.WarnContext(ctx, "evento on-chain com schema_version não suportada ignorado",
+			"schema_version", inputDto.SchemaVersion, logging.Payload(msg.Value))
+		return nil
+	}
+	if inputDto.TxHash == "" {
+		slog.WarnContext(ctx, "evento on-chain sem TxHash ignorado", logging.Payload(msg.Value))
+		return nil
+	}
+
+	if inputDto.Revertido {
+		n, err := h.CursoUseCase.ExecuteReverterValidacaoContratoPorEvento(ctx, inputDto, string(msg.Value))
+		if err != nil {
+			slog.ErrorContext(ctx, "erro ao reverter validação de contrato", "tx_hash", inputDto.TxHash, logging.Err(err))
+			return err
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "evento revertido na validação de contrato", "tx_hash", inputDto.TxHash, "itens", n)
+		}
+		return nil
+	}
+
+	n, err := h.CursoUseCase.ExecuteValidarContratoPorEvento(ctx, inputDto, string(msg.Value))
+	if err != nil {
+		slog.ErrorContext(ctx, "erro ao validar contrato", "tx_hash", inputDto.TxHash, logging.Err(err))
+		return err
+	}
+
+	if n > 0 {
+		slog.InfoContext(ctx, "evento aplicado na validação de contrato",
+			"evento", inputDto.Evento, "tx_hash", inputDto.TxHash, "itens", n)
+	}
+	return nil
+}
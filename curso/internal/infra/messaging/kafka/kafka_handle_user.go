@@ -1,45 +1,85 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
 
 	"github.com/IBM/sarama"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/dto"
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/repository"
 	"github.com/ggialluisi/nebula-back/curso/internal/domain/usecase"
+	"github.com/ggialluisi/nebula-back/platform/logging"
 )
 
 type PessoaKafkaHandlers struct {
-	PessoaRepository repository.PessoaRepositoryInterface
+	PessoaRepository       repository.PessoaRepositoryInterface
+	CursoUseCase           *usecase.SaveCursoUseCase
+	PoliticaExclusaoPessoa entity.PoliticaExclusaoPessoa
 }
 
 func NewPessoaKafkaHandlers(
 	PessoaRepository repository.PessoaRepositoryInterface,
+	CursoUseCase *usecase.SaveCursoUseCase,
+	PoliticaExclusaoPessoa entity.PoliticaExclusaoPessoa,
 ) *PessoaKafkaHandlers {
 	return &PessoaKafkaHandlers{
-		PessoaRepository: PessoaRepository,
+		PessoaRepository:       PessoaRepository,
+		CursoUseCase:           CursoUseCase,
+		PoliticaExclusaoPessoa: PoliticaExclusaoPessoa,
 	}
 }
 
 // Handler para mensagens Sarama
-func (h *PessoaKafkaHandlers) CreateOrUpdatePessoa(msg *sarama.ConsumerMessage) error {
+func (h *PessoaKafkaHandlers) CreateOrUpdatePessoa(ctx context.Context, msg *sarama.ConsumerMessage) error {
 	var inputDto dto.PessoaInputDTO
 
 	err := json.Unmarshal(msg.Value, &inputDto)
 	if err != nil {
-		log.Printf("❌ Erro ao decodificar mensagem: %v", err)
-		return err
+		slog.ErrorContext(ctx, "erro ao decodificar mensagem", "topic", msg.Topic, logging.Err(err))
+		return Permanent(err)
 	}
 
 	uc := usecase.NewSavePessoaUseCase(h.PessoaRepository)
 
-	_, err = uc.ExecuteCreateOrUpdatePessoa(inputDto)
+	_, err = uc.ExecuteCreateOrUpdatePessoa(ctx, inputDto)
+	if errors.Is(err, entity.ErrPessoaVersaoDesatualizada) {
+		slog.InfoContext(ctx, "pessoa ignorada: versão antiga", "pessoa_id", inputDto.ID, "updated_at", inputDto.UpdatedAt)
+		return nil
+	}
 	if err != nil {
-		log.Printf("❌ Erro ao executar usecase Pessoa: %v", err)
+		slog.ErrorContext(ctx, "erro ao executar usecase Pessoa", "pessoa_id", inputDto.ID, logging.Err(err))
 		return err
 	}
 
-	log.Printf("✅ Pessoa criada/atualizada: %+v", inputDto)
+	slog.InfoContext(ctx, "pessoa criada/atualizada", "pessoa_id", inputDto.ID)
+	slog.DebugContext(ctx, "pessoa recebida", logging.Payload(msg.Value))
+	return nil
+}
+
+// Handler para pessoa.deleted: aplica a política de exclusão na réplica local
+func (h *PessoaKafkaHandlers) DeletePessoa(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	var inputDto dto.PessoaDeletedInputDTO
+
+	err := json.Unmarshal(msg.Value, &inputDto)
+	if err != nil {
+		// mensagem inválida vai direto para a DLQ
+		slog.ErrorContext(ctx, "erro ao decodificar mensagem", "topic", msg.Topic, logging.Err(err))
+		return Permanent(err)
+	}
+
+	resultado, err := h.CursoUseCase.ExecuteExcluirPessoa(ctx, inputDto, h.PoliticaExclusaoPessoa)
+	if err != nil {
+		slog.ErrorContext(ctx, "erro ao excluir Pessoa", "pessoa_id", inputDto.ID, logging.Err(err))
+		return err
+	}
+
+	if resultado == entity.ResultadoExclusaoBloqueada {
+		slog.WarnContext(ctx, "exclusão da Pessoa bloqueada: há matrículas ativas", "pessoa_id", inputDto.ID)
+		return nil
+	}
+	slog.InfoContext(ctx, "pessoa excluída na origem", "pessoa_id", inputDto.ID, "resultado", resultado)
 	return nil
 }
@@ -1,10 +1,30 @@
 package kafka
 
-import "context"
+import (
+	"context"
+
+	platform_kafka "github.com/ggialluisi/nebula-back/platform/kafka"
+)
 
 //go:generate mockgen -destination=../../mocks/mock_messaging.go -package=mocks fdqf01/curso/internal/infrastructure/messaging KafkaProducerInterface
 
+// Headers que identificam os eventos publicados pelo outbox; os consumidores usam
+// event_id para descartar entregas repetidas, correlation_id para encadear os
+// eventos que derivam de uma mesma requisição e request_id para achar nos logs a
+// requisição HTTP que originou o evento.
+const (
+	HeaderEventID       = "event_id"
+	HeaderEventName     = "event_name"
+	HeaderOccurredAt    = "occurred_at"
+	HeaderCorrelationID = "correlation_id"
+	HeaderRequestID     = "request_id"
+)
+
 type KafkaProducerInterface interface {
-	PublishMessage(ctx context.Context, key, value string) error
+	PublishMessage(ctx context.Context, key, value string, headers map[string]string) error
 	Close() error
 }
+
+// Verifica se o producer da platform implementa as interfaces usadas pelo curso
+var _ KafkaProducerInterface = &platform_kafka.Producer{}
+var _ MessagePublisher = &platform_kafka.Producer{}
@@ -10,7 +10,13 @@ import (
 	"github.com/go-chi/jwtauth"
 	httpSwagger "github.com/swaggo/http-swagger"
 
+	"github.com/ggialluisi/nebula-back/curso/internal/domain/entity"
 	"github.com/ggialluisi/nebula-back/curso/internal/infra/api"
+	"github.com/ggialluisi/nebula-back/platform/health"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/metrics"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
+	"github.com/ggialluisi/nebula-back/platform/webserver"
 )
 
 // SetupRoutes configura todas as rotas da aplicação.
@@ -21,12 +27,17 @@ func SetupRoutes(
 	jwtExpiresIn int,
 	cursoApiHandlers *api.CursoHandlers,
 	userApiHandlers *api.UserHandlers,
+	deadLetterApiHandlers *api.DeadLetterHandlers,
+	buscaApiHandlers *api.BuscaHandlers,
 	adminPanel http.Handler,
+	healthChecks *health.Health,
 ) http.Handler {
 
 	// Configurar o roteador Chi
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(logging.RequestID, tracing.HTTP, logging.AccessLog)
+	r.Use(metrics.HTTP)
+	r.Use(api.CorrelationID)
 	// r.Use(middleware.Recoverer)
 	r.Use(middleware.WithValue("jwt", tokenAuth))
 	r.Use(middleware.WithValue("JwtExperesIn", jwtExpiresIn))
@@ -35,63 +46,101 @@ func SetupRoutes(
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{frontendURL}, // ou []string{"*"} para permitir todas as origens (cuidado em produção)
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", api.HeaderCorrelationID, logging.HeaderRequestID, tracing.HeaderTraceParent},
+		ExposedHeaders:   []string{"Link", api.HeaderCorrelationID, logging.HeaderRequestID},
 		AllowCredentials: true,
 		MaxAge:           300, // Tempo em segundos para cachear a preflight request
 	}))
 
 	//autenticação
-	r.Post("/users", userApiHandlers.CreateUser)
+	// POST /users aceita token opcional: apenas admins criam usuários com papéis elevados
+	r.With(jwtauth.Verifier(tokenAuth)).Post("/users", userApiHandlers.CreateUser)
 	r.Post("/users/generate_token", userApiHandlers.GetJWT)
 
-	//rotas do microserviço
-	r.Post("/alunos", cursoApiHandlers.CreateAluno)
-	r.Get("/alunos", cursoApiHandlers.GetAlunos)
-	r.Get("/alunos/{id}", cursoApiHandlers.GetAluno)
-	r.Get("/alunos/by-wallet/{wallet}", cursoApiHandlers.GetAlunoByWallet)
-	r.Put("/alunos/{id}", cursoApiHandlers.UpdateAluno)
-	r.Delete("/alunos/{id}", cursoApiHandlers.DeleteAluno)
-
-	r.Post("/cursos", cursoApiHandlers.CreateCurso)
-	r.Get("/cursos", cursoApiHandlers.GetCursos)
-	r.Get("/cursos/{id}", cursoApiHandlers.GetCurso)
-	r.Put("/cursos/{id}", cursoApiHandlers.UpdateCurso)
-	r.Delete("/cursos/{id}", cursoApiHandlers.DeleteCurso)
-
-	r.Post("/modulos", cursoApiHandlers.CreateModulo)
-	r.Put("/modulos/{id}", cursoApiHandlers.UpdateModulo)
-	r.Get("/modulos/{id}", cursoApiHandlers.GetModulo)
-	r.Delete("/modulos/{id}", cursoApiHandlers.DeleteModulo)
-	r.Get("/cursos/{parent}/modulos", cursoApiHandlers.GetModulosDaCurso)
-
-	r.Post("/alunocursos", cursoApiHandlers.CreateAlunoCurso)
-	r.Put("/alunocursos/{id}", cursoApiHandlers.UpdateAlunoCurso)
-	r.Get("/alunocursos/{id}", cursoApiHandlers.GetAlunoCurso)
-	r.Delete("/alunocursos/{id}", cursoApiHandlers.DeleteAlunoCurso)
-	r.Get("/alunocursos", cursoApiHandlers.GetAlunosCursos)
-	r.Get("/alunocursos/aluno/{parent}", cursoApiHandlers.GetAlunosDoCurso)
-	r.Get("/alunocursos/curso/{parent}", cursoApiHandlers.GetCursosDoAluno)
-
-	r.Post("/modulos/{modulo_id}/itens", cursoApiHandlers.CreateItemModulo)
-	r.Get("/modulos/{modulo_id}/itens", cursoApiHandlers.GetItensModulo)
-	r.Get("/itensmodulo/{id}", cursoApiHandlers.GetItemModulo)
-	r.Put("/itensmodulo/{id}", cursoApiHandlers.UpdateItemModulo)
-	r.Delete("/itensmodulo/{id}", cursoApiHandlers.DeleteItemModulo)
-	r.Post("/itensmodulo/{id}/mover", cursoApiHandlers.MoveItemModulo)
-
-	r.Get("/alunocursos/{id}/itemmodulos", cursoApiHandlers.GetAlunoCursoItemModulos)
-	r.Get("/alunocursoitemmodulos/{id}", cursoApiHandlers.GetAlunoCursoItemModulo)
-	r.Patch("/alunocursoitemmodulos/{id}", cursoApiHandlers.UpdateAlunoCursoItemModulo)
-
-	// Pessoas
-	r.Get("/pessoas", cursoApiHandlers.GetPessoas)
-
 	// Swagger
 	r.Get("/docs/*", httpSwagger.Handler(httpSwagger.URL("/docs/doc.json")))
 
-	// Admin (QOR)
-	r.Mount("/admin", adminPanel)
-
-	return r
+	//rotas do microserviço (exigem token válido)
+	r.Group(func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(jwtauth.Authenticator)
+
+		// Leituras do catálogo: qualquer usuário autenticado
+		r.Get("/cursos", cursoApiHandlers.GetCursos)
+		r.Get("/cursos/{id}", cursoApiHandlers.GetCurso)
+		r.Get("/modulos/{id}", cursoApiHandlers.GetModulo)
+		r.Get("/cursos/{parent}/modulos", cursoApiHandlers.GetModulosDaCurso)
+		r.Get("/modulos/{modulo_id}/itens", cursoApiHandlers.GetItensModulo)
+		r.Get("/itensmodulo/{id}", cursoApiHandlers.GetItemModulo)
+		r.Get("/search", buscaApiHandlers.BuscarCatalogo)
+
+		// Rotas do aluno: o handler valida se o aluno é o dono do registro
+		r.Get("/alunos/by-wallet/{wallet}", cursoApiHandlers.GetAlunoByWallet)
+		r.Get("/alunocursos/{id}/itemmodulos", cursoApiHandlers.GetAlunoCursoItemModulos)
+		r.Get("/alunocursoitemmodulos/{id}", cursoApiHandlers.GetAlunoCursoItemModulo)
+		r.Patch("/alunocursoitemmodulos/{id}", cursoApiHandlers.UpdateAlunoCursoItemModulo)
+
+		// Consultas administrativas: admin e instrutor
+		r.Group(func(r chi.Router) {
+			r.Use(webserver.RequireRoles(api.RoleFromContext, entity.RoleAdmin, entity.RoleInstrutor))
+
+			r.Get("/alunos", cursoApiHandlers.GetAlunos)
+			r.Get("/alunos/{id}", cursoApiHandlers.GetAluno)
+
+			r.Get("/alunocursos/{id}", cursoApiHandlers.GetAlunoCurso)
+			r.Get("/alunocursos", cursoApiHandlers.GetAlunosCursos)
+			r.Get("/alunocursos/aluno/{parent}", cursoApiHandlers.GetAlunosDoCurso)
+			r.Get("/alunocursos/curso/{parent}", cursoApiHandlers.GetCursosDoAluno)
+
+			// Pessoas
+			r.Get("/pessoas", cursoApiHandlers.GetPessoas)
+		})
+
+		// Alterações do catálogo: apenas admin
+		r.Group(func(r chi.Router) {
+			r.Use(webserver.RequireRoles(api.RoleFromContext, entity.RoleAdmin))
+
+			r.Post("/alunos", cursoApiHandlers.CreateAluno)
+			r.Put("/alunos/{id}", cursoApiHandlers.UpdateAluno)
+			r.Delete("/alunos/{id}", cursoApiHandlers.DeleteAluno)
+
+			r.Post("/cursos", cursoApiHandlers.CreateCurso)
+			r.Put("/cursos/{id}", cursoApiHandlers.UpdateCurso)
+			r.Delete("/cursos/{id}", cursoApiHandlers.DeleteCurso)
+
+			r.Post("/modulos", cursoApiHandlers.CreateModulo)
+			r.Put("/modulos/{id}", cursoApiHandlers.UpdateModulo)
+			r.Delete("/modulos/{id}", cursoApiHandlers.DeleteModulo)
+
+			r.Post("/alunocursos", cursoApiHandlers.CreateAlunoCurso)
+			r.Put("/alunocursos/{id}", cursoApiHandlers.UpdateAlunoCurso)
+			r.Delete("/alunocursos/{id}", cursoApiHandlers.DeleteAlunoCurso)
+
+			r.Post("/modulos/{modulo_id}/itens", cursoApiHandlers.CreateItemModulo)
+			r.Put("/itensmodulo/{id}", cursoApiHandlers.UpdateItemModulo)
+			r.Delete("/itensmodulo/{id}", cursoApiHandlers.DeleteItemModulo)
+			r.Post("/itensmodulo/{id}/mover", cursoApiHandlers.MoveItemModulo)
+
+			// Dead letters dos consumidores Kafka
+			r.Get("/deadletters", deadLetterApiHandlers.GetDeadLetters)
+			r.Post("/deadletters/{id}/replay", deadLetterApiHandlers.ReplayDeadLetter)
+		})
+	})
+
+	// Admin (QOR): apenas admin
+	r.Group(func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(jwtauth.Authenticator)
+		r.Use(webserver.RequireRoles(api.RoleFromContext, entity.RoleAdmin))
+		r.Mount("/admin", adminPanel)
+	})
+
+	// Liveness, readiness e métricas fora dos middlewares, sem autenticação e sem poluir o log
+	root := chi.NewRouter()
+	root.Get("/healthz", healthChecks.Liveness)
+	root.Get("/readyz", healthChecks.Readiness)
+	root.Handle("/metrics", metrics.Handler())
+	root.Mount("/", r)
+
+	return root
 }
@@ -1,49 +1,200 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
+	"time"
 
 	"eth-listener/config"
-	"eth-listener/internal/ethereum"
+	"eth-listener/internal/abiregistry"
+	"eth-listener/internal/checkpoint"
 	myethereum "eth-listener/internal/ethereum"
 	mykafka "eth-listener/internal/kafka"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ggialluisi/nebula-back/platform/health"
+	"github.com/ggialluisi/nebula-back/platform/lifecycle"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/metrics"
+	"github.com/ggialluisi/nebula-back/platform/webserver"
 )
 
 func main() {
-	fmt.Println("🚀 Iniciando serviço de escuta de eventos Ethereum...")
-	fmt.Println("🔧 Carregando configurações do ambiente...")
+	// Logs estruturados em JSON (LOG_LEVEL)
+	logging.Setup("eth-listener")
+	slog.Info("iniciando serviço de escuta de eventos Ethereum")
 
-	// Carregar configurações do ambiente (Docker Compose)
-	cfg := config.LoadConfig()
+	// Carregar configurações do ambiente (Docker Compose) e o arquivo de redes
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logging.Fatal("erro ao carregar configurações", logging.Err(err))
+	}
+
+	// SIGTERM interrompe a escuta das redes e então fecha o checkpoint store e os
+	// produtores Kafka, enviando as mensagens pendentes
+	lc := lifecycle.New(lifecycle.ConfigFromEnv())
+	ctx := lc.Context()
+
+	// /healthz e /readyz: Kafka, checkpoint store e lag de cada rede; /metrics na mesma porta
+	healthChecks := health.New("eth-listener", 5*time.Second)
+	healthChecks.Add(health.Check{Name: "lifecycle", Probe: lc.Ready})
 
-	// Conectar ao nó Geth
-	client := myethereum.NewEthereumClient(cfg.EthereumWSURL)
-	contractAddress := common.HexToAddress(cfg.ContractAddress)
+	// Criar tópicos Kafka necessários
+	err = mykafka.EnsureTopics(cfg.KafkaBroker, []string{cfg.KafkaTopic, cfg.KafkaDLQTopic})
+	if err != nil {
+		logging.Fatal("erro ao criar tópicos Kafka", logging.Err(err))
+	}
 
-	// Obter a ABI do contrato
-	contractABI, err := myethereum.GetContractABI(contractAddress.Hex())
+	// Configurar Kafka (eventos + DLQ)
+	publisher, err := mykafka.NewPublisher(cfg.KafkaBroker, cfg.KafkaTopic, cfg.KafkaDLQTopic)
 	if err != nil {
-		log.Fatalf("❌ Erro ao obter ABI do contrato: %v", err)
+		logging.Fatal("erro ao inicializar Kafka Writer", logging.Err(err))
 	}
+	lc.OnStop("produtores Kafka", func(ctx context.Context) error {
+		return publisher.Close()
+	})
+	healthChecks.Add(health.Check{Name: "kafka", Probe: publisher.Ping})
 
-	// Criar tópicos Kafka necessários
-	err = mykafka.EnsureTopics(cfg.KafkaBroker, []string{cfg.KafkaTopic})
+	// Checkpoints por rede + contrato
+	store, err := checkpoint.NewStore(ctx, cfg.CheckpointStore, cfg.CheckpointFile, cfg.CheckpointDSN)
+	if err != nil {
+		logging.Fatal("erro ao inicializar checkpoint store", logging.Err(err))
+	}
+	lc.OnStop("checkpoint store", func(ctx context.Context) error {
+		return store.Close()
+	})
+	if pinger, ok := store.(health.Pinger); ok {
+		healthChecks.Add(health.Database("checkpoint_store", pinger))
+	}
+
+	// ABIs locais, com cache das consultas ao Etherscan
+	registry := abiregistry.NewRegistry(cfg.ABIDir, cfg.ABICacheDir, cfg.EtherscanEnabled, cfg.EtherscanAPIKey)
+
+	// Uma goroutine de escuta por rede; o erro de uma rede encerra o serviço
+	for _, chainCfg := range cfg.Chains {
+		status := myethereum.NewChainStatus(chainCfg.Name)
+		healthChecks.Add(chainCheck(cfg, chainCfg, status))
+		lc.Go("escuta "+chainCfg.Name, func(ctx context.Context) error {
+			return escutarRede(ctx, cfg, chainCfg, status, store, registry, publisher)
+		})
+	}
+
+	mux := http.NewServeMux()
+	healthChecks.Register(mux)
+	mux.Handle("GET /metrics", metrics.Handler())
+	healthServer := webserver.NewServer(":"+cfg.HealthPort, mux)
+	lc.Go("servidor de health", func(ctx context.Context) error {
+		return webserver.Serve(ctx, healthServer, lc.Config.DrainTimeout)
+	})
+	slog.Info("health e métricas disponíveis", "port", cfg.HealthPort)
+
+	if err := lc.Run(); err != nil {
+		logging.Fatal("escuta de eventos encerrada", logging.Err(err))
+	}
+	slog.Info("serviço encerrado")
+}
+
+// escutarRede carrega ABIs e checkpoints dos contratos da rede, busca os eventos passados
+// e mantém a escuta em tempo real, com reconexão e fallback para polling
+func escutarRede(ctx context.Context, cfg *config.Config, chainCfg config.ChainConfig, status *myethereum.ChainStatus, store checkpoint.Store, registry *abiregistry.Registry, publisher *mykafka.Publisher) error {
+	// Conectar ao nó para consultas (HTTP, se configurado; senão WebSocket)
+	nodeURL := chainCfg.RPCURL
+	if nodeURL == "" {
+		nodeURL = chainCfg.WSURL
+	}
+	client, err := myethereum.NewEthereumClient(ctx, nodeURL)
 	if err != nil {
-		log.Fatalf("❌ Erro ao criar tópicos Kafka: %v", err)
+		return err
 	}
+	defer client.Close()
 
-	// Configurar Kafka
-	writer, err := mykafka.NewKafkaWriter(cfg.KafkaBroker, cfg.KafkaTopic)
+	nodeChainID, err := client.ChainID(ctx)
 	if err != nil {
-		log.Fatalf("❌ Erro ao inicializar Kafka Writer: %v", err)
+		return fmt.Errorf("Erro ao obter chain id: %v", err)
+	}
+	if chainCfg.ChainID != 0 && chainCfg.ChainID != nodeChainID.Uint64() {
+		return fmt.Errorf("chain id do nó (%d) diferente do configurado (%d)", nodeChainID.Uint64(), chainCfg.ChainID)
+	}
+	chain := myethereum.NewChain(chainCfg.Name, nodeChainID.Uint64())
+	chain.Status = status
+
+	for _, contractCfg := range chainCfg.Contracts {
+		contractAddress := common.HexToAddress(contractCfg.Address)
+
+		// Obter a ABI do contrato
+		contractABI, err := registry.Get(abiregistry.Lookup{
+			Rede:            chain.Name,
+			ChainID:         chain.ChainID,
+			Address:         contractAddress.Hex(),
+			File:            contractCfg.ABIFile,
+			EtherscanAPIURL: chainCfg.EtherscanAPIURL,
+		})
+		if err != nil {
+			return err
+		}
+
+		tracker, err := checkpoint.NewTracker(ctx, store, checkpoint.Key(chain.ChainID, contractAddress.Hex()))
+		if err != nil {
+			return fmt.Errorf("Erro ao carregar checkpoint: %v", err)
+		}
+
+		chain.AddContract(&myethereum.Contract{
+			Address:    contractAddress,
+			ABI:        contractABI,
+			Tracker:    tracker,
+			StartBlock: contractCfg.StartBlock,
+		})
 	}
 
 	// Buscar eventos passados antes de iniciar a escuta em tempo real
-	lastProcessedBlock := ethereum.BuscarEventosPassados(client, contractAddress, contractABI, writer)
+	slog.Info("buscando eventos passados", "chain", chain.Name)
+	err = myethereum.BuscarEventosPassados(ctx, client, chain, publisher, cfg.BackfillBatchSize, *chainCfg.ConfirmationDepth)
+	if err != nil {
+		// o supervisor continua a partir do checkpoint
+		slog.Warn("erro no backfill de eventos", "chain", chain.Name, logging.Err(err))
+	}
 
-	// Iniciar escuta de eventos em tempo real
-	ethereum.ListenEvents(client, contractAddress, contractABI, lastProcessedBlock, writer)
+	supervisor := &myethereum.Supervisor{
+		WSURL:             chainCfg.WSURL,
+		RPCURL:            chainCfg.RPCURL,
+		Chain:             chain,
+		Publisher:         publisher,
+		BatchSize:         cfg.BackfillBatchSize,
+		ConfirmationDepth: *chainCfg.ConfirmationDepth,
+		PollInterval:      cfg.PollInterval,
+		WSRetryInterval:   cfg.WSRetryInterval,
+		MaxWSFailures:     cfg.MaxWSFailures,
+	}
+	return supervisor.Run(ctx)
+}
+
+// chainCheck verifica se a rede está acompanhando o nó: recebeu um bloco recentemente e
+// o lag dos blocos confirmados está dentro do limite
+func chainCheck(cfg *config.Config, chainCfg config.ChainConfig, status *myethereum.ChainStatus) health.Check {
+	return health.Check{
+		Name: "chain_" + chainCfg.Name,
+		Probe: func(ctx context.Context) error {
+			snap := status.Snapshot()
+			switch {
+			case snap.HeadAt.IsZero():
+				return fmt.Errorf("aguardando o primeiro bloco do nó")
+			case time.Since(snap.HeadAt) > cfg.HealthMaxHeadAge:
+				return fmt.Errorf("nenhum bloco novo há %s", time.Since(snap.HeadAt).Round(time.Second))
+			case snap.Lag(*chainCfg.ConfirmationDepth) > cfg.HealthMaxBlockLag:
+				return fmt.Errorf("lag de %d blocos (limite %d)", snap.Lag(*chainCfg.ConfirmationDepth), cfg.HealthMaxBlockLag)
+			}
+			return nil
+		},
+		Details: func() interface{} {
+			snap := status.Snapshot()
+			return map[string]interface{}{
+				"head":      snap.Head,
+				"processed": snap.Processed,
+				"head_at":   snap.HeadAt,
+				"lag":       snap.Lag(*chainCfg.ConfirmationDepth),
+			}
+		},
+	}
 }
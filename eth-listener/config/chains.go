@@ -0,0 +1,147 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EtherscanSepoliaAPIURL é a API usada para buscar ABIs da Sepolia quando a rede não informa outra
+const EtherscanSepoliaAPIURL = "https://api-sepolia.etherscan.io/api"
+
+// SepoliaChainID identifica a Sepolia, única rede com padrões para etherscan_api_url e
+// start_block; as demais precisam informá-los no arquivo de redes
+const SepoliaChainID = 11155111
+
+// ChainConfig descreve uma rede observada e seus contratos.
+// O nome deve corresponder ao RedeValidacao do curso (sepolia, avalancheFuji, ethereum, scroll).
+type ChainConfig struct {
+	Name              string           `json:"name"`
+	ChainID           uint64           `json:"chain_id"` // 0: obtido do nó
+	WSURL             string           `json:"ws_url"`
+	RPCURL            string           `json:"rpc_url"`
+	EtherscanAPIURL   string           `json:"etherscan_api_url"`
+	StartBlock        uint64           `json:"start_block"`
+	ConfirmationDepth *uint64          `json:"confirmation_depth"`
+	Contracts         []ContractConfig `json:"contracts"`
+}
+
+// ContractConfig descreve um contrato observado em uma rede
+type ContractConfig struct {
+	Address    string `json:"address"`
+	ABIFile    string `json:"abi_file"`    // ABI local; vazio: busca no registro de ABIs
+	StartBlock uint64 `json:"start_block"` // 0: start_block da rede
+}
+
+type chainsFile struct {
+	Chains []ChainConfig `json:"chains"`
+}
+
+// LoadChains lê o arquivo de redes. Se o arquivo não existir, monta uma única rede a partir
+// das variáveis CHAIN_NAME, ETHEREUM_WS_URL, ETHEREUM_RPC_URL, ETHERSCAN_API_URL, START_BLOCK e
+// CONTRACT_ADDRESS. A profundidade de confirmação não informada usa o padrão de cfg. A API do
+// Etherscan e o bloco inicial (START_BLOCK) só têm padrão na Sepolia: as outras redes precisam
+// informá-los.
+func LoadChains(path string, cfg *Config) ([]ChainConfig, error) {
+	var chains []ChainConfig
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		slog.Warn("arquivo de redes não encontrado, usando variáveis de ambiente", "path", path)
+		chains = []ChainConfig{chainFromEnv()}
+	case err != nil:
+		return nil, fmt.Errorf("Erro ao ler arquivo de redes %s: %v", path, err)
+	default:
+		var file chainsFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("Erro ao parsear arquivo de redes %s: %v", path, err)
+		}
+		chains = file.Chains
+	}
+
+	ativas := []ChainConfig{}
+	for _, chain := range chains {
+		if len(chain.Contracts) == 0 {
+			slog.Warn("rede sem contratos, ignorada", "chain", chain.Name)
+			continue
+		}
+		chain.applyDefaults(cfg)
+		if err := chain.validate(cfg); err != nil {
+			return nil, err
+		}
+		ativas = append(ativas, chain)
+	}
+	if len(ativas) == 0 {
+		return nil, errors.New("Nenhuma rede com contratos configurada")
+	}
+	return ativas, nil
+}
+
+func chainFromEnv() ChainConfig {
+	chain := ChainConfig{
+		Name:            getEnv("CHAIN_NAME", "sepolia"),
+		WSURL:           os.Getenv("ETHEREUM_WS_URL"),
+		RPCURL:          os.Getenv("ETHEREUM_RPC_URL"),
+		EtherscanAPIURL: os.Getenv("ETHERSCAN_API_URL"),
+		StartBlock:      getEnvUint("START_BLOCK", 0), // sem a variável, só a Sepolia tem padrão
+	}
+	if address := os.Getenv("CONTRACT_ADDRESS"); address != "" {
+		chain.Contracts = []ContractConfig{{Address: address}}
+	}
+	return chain
+}
+
+// IsSepolia informa se a rede é a Sepolia, pelo chain id ou, se ele não foi informado, pelo nome
+func (c *ChainConfig) IsSepolia() bool {
+	if c.ChainID != 0 {
+		return c.ChainID == SepoliaChainID
+	}
+	return c.Name == "sepolia"
+}
+
+func (c *ChainConfig) validate(cfg *Config) error {
+	if c.Name == "" {
+		return errors.New("Rede sem nome no arquivo de redes")
+	}
+	if c.WSURL == "" && c.RPCURL == "" {
+		return fmt.Errorf("Rede %s sem ws_url nem rpc_url", c.Name)
+	}
+	for _, contract := range c.Contracts {
+		if !common.IsHexAddress(contract.Address) {
+			return fmt.Errorf("Endereço de contrato inválido na rede %s: %q", c.Name, contract.Address)
+		}
+		if contract.StartBlock == 0 {
+			return fmt.Errorf("Rede %s sem start_block (na rede ou no contrato %s)", c.Name, contract.Address)
+		}
+		// sem a API da rede, a ABI seria buscada (e guardada em cache) no Etherscan de outra rede
+		if contract.ABIFile == "" && cfg.EtherscanEnabled && c.EtherscanAPIURL == "" {
+			return fmt.Errorf("Rede %s sem etherscan_api_url para buscar a ABI do contrato %s (informe abi_file ou desabilite ETHERSCAN_ENABLED)", c.Name, contract.Address)
+		}
+	}
+	return nil
+}
+
+func (c *ChainConfig) applyDefaults(cfg *Config) {
+	if c.IsSepolia() {
+		if c.EtherscanAPIURL == "" {
+			c.EtherscanAPIURL = EtherscanSepoliaAPIURL
+		}
+		if c.StartBlock == 0 {
+			c.StartBlock = cfg.StartBlock
+		}
+	}
+	if c.ConfirmationDepth == nil {
+		depth := cfg.ConfirmationDepth
+		c.ConfirmationDepth = &depth
+	}
+	for i := range c.Contracts {
+		if c.Contracts[i].StartBlock == 0 {
+			c.Contracts[i].StartBlock = c.StartBlock
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const contratoTeste = "0xd47B84cD828538eE33264911E117e3557af39231"
+
+func escreverRedes(t *testing.T, conteudo string) string {
+	path := filepath.Join(t.TempDir(), "chains.json")
+	require.NoError(t, os.WriteFile(path, []byte(conteudo), 0o644))
+	return path
+}
+
+func configTeste() *Config {
+	return &Config{StartBlock: 7361255, ConfirmationDepth: 12, EtherscanEnabled: true}
+}
+
+func TestLoadChains_VariasRedes(t *testing.T) {
+	path := escreverRedes(t, `{"chains": [
+		{"name": "sepolia", "ws_url": "wss://sepolia", "contracts": [{"address": "`+contratoTeste+`"}]},
+		{"name": "avalancheFuji", "chain_id": 43113, "rpc_url": "https://fuji", "etherscan_api_url": "https://fuji-api",
+		 "start_block": 100, "confirmation_depth": 0,
+		 "contracts": [{"address": "`+contratoTeste+`"}, {"address": "`+contratoTeste+`", "start_block": 200}]},
+		{"name": "scroll", "rpc_url": "https://scroll", "contracts": []}
+	]}`)
+
+	chains, err := LoadChains(path, configTeste())
+	require.NoError(t, err)
+	require.Len(t, chains, 2, "rede sem contratos é ignorada")
+
+	sepolia := chains[0]
+	assert.Equal(t, EtherscanSepoliaAPIURL, sepolia.EtherscanAPIURL)
+	assert.Equal(t, uint64(7361255), sepolia.StartBlock)
+	assert.Equal(t, uint64(7361255), sepolia.Contracts[0].StartBlock)
+	require.NotNil(t, sepolia.ConfirmationDepth)
+	assert.Equal(t, uint64(12), *sepolia.ConfirmationDepth)
+
+	fuji := chains[1]
+	assert.Equal(t, "https://fuji-api", fuji.EtherscanAPIURL)
+	assert.Equal(t, uint64(100), fuji.Contracts[0].StartBlock)
+	assert.Equal(t, uint64(200), fuji.Contracts[1].StartBlock)
+	require.NotNil(t, fuji.ConfirmationDepth)
+	assert.Equal(t, uint64(0), *fuji.ConfirmationDepth, "confirmation_depth 0 informado é mantido")
+}
+
+func TestLoadChains_SepoliaPeloChainID(t *testing.T) {
+	path := escreverRedes(t, `{"chains": [
+		{"name": "teste", "chain_id": 11155111, "ws_url": "wss://sepolia", "contracts": [{"address": "`+contratoTeste+`"}]}
+	]}`)
+
+	chains, err := LoadChains(path, configTeste())
+	require.NoError(t, err)
+	assert.Equal(t, EtherscanSepoliaAPIURL, chains[0].EtherscanAPIURL)
+	assert.Equal(t, uint64(7361255), chains[0].Contracts[0].StartBlock)
+}
+
+func TestLoadChains_Validacao(t *testing.T) {
+	casos := []struct {
+		nome      string
+		rede      string
+		etherscan bool
+		erro      string
+	}{
+		{
+			nome: "sem nome",
+			rede: `{"ws_url": "wss://x", "contracts": [{"address": "` + contratoTeste + `"}]}`,
+			erro: "sem nome",
+		},
+		{
+			nome: "sem urls",
+			rede: `{"name": "sepolia", "contracts": [{"address": "` + contratoTeste + `"}]}`,
+			erro: "sem ws_url nem rpc_url",
+		},
+		{
+			nome: "endereço inválido",
+			rede: `{"name": "sepolia", "ws_url": "wss://x", "contracts": [{"address": "0x123"}]}`,
+			erro: "Endereço de contrato inválido",
+		},
+		{
+			nome:      "outra rede sem start_block",
+			rede:      `{"name": "ethereum", "chain_id": 1, "ws_url": "wss://x", "etherscan_api_url": "https://api", "contracts": [{"address": "` + contratoTeste + `"}]}`,
+			etherscan: true,
+			erro:      "sem start_block",
+		},
+		{
+			nome:      "outra rede sem etherscan_api_url",
+			rede:      `{"name": "scroll", "ws_url": "wss://x", "start_block": 1, "contracts": [{"address": "` + contratoTeste + `"}]}`,
+			etherscan: true,
+			erro:      "sem etherscan_api_url",
+		},
+		{
+			nome:      "outra rede com abi_file dispensa etherscan_api_url",
+			rede:      `{"name": "scroll", "ws_url": "wss://x", "start_block": 1, "contracts": [{"address": "` + contratoTeste + `", "abi_file": "abi.json"}]}`,
+			etherscan: true,
+		},
+		{
+			nome: "outra rede sem etherscan habilitado dispensa etherscan_api_url",
+			rede: `{"name": "scroll", "ws_url": "wss://x", "start_block": 1, "contracts": [{"address": "` + contratoTeste + `"}]}`,
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			cfg := configTeste()
+			cfg.EtherscanEnabled = caso.etherscan
+			_, err := LoadChains(escreverRedes(t, `{"chains": [`+caso.rede+`]}`), cfg)
+			if caso.erro == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), caso.erro)
+		})
+	}
+}
+
+func TestLoadChains_NenhumaRedeComContratos(t *testing.T) {
+	_, err := LoadChains(escreverRedes(t, `{"chains": [{"name": "sepolia", "ws_url": "wss://x"}]}`), configTeste())
+	assert.Error(t, err)
+}
+
+func TestLoadChains_VariaveisDeAmbiente(t *testing.T) {
+	t.Setenv("CHAIN_NAME", "avalancheFuji")
+	t.Setenv("ETHEREUM_WS_URL", "wss://fuji")
+	t.Setenv("ETHERSCAN_API_URL", "https://fuji-api")
+	t.Setenv("START_BLOCK", "500")
+	t.Setenv("CONTRACT_ADDRESS", contratoTeste)
+
+	chains, err := LoadChains(filepath.Join(t.TempDir(), "inexistente.json"), configTeste())
+	require.NoError(t, err)
+	require.Len(t, chains, 1)
+	assert.Equal(t, "avalancheFuji", chains[0].Name)
+	assert.Equal(t, "https://fuji-api", chains[0].EtherscanAPIURL)
+	assert.Equal(t, uint64(500), chains[0].Contracts[0].StartBlock)
+
+	// sem START_BLOCK, o bloco padrão da Sepolia não vale para outra rede
+	t.Setenv("START_BLOCK", "")
+	_, err = LoadChains(filepath.Join(t.TempDir(), "inexistente.json"), configTeste())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sem start_block")
+}
@@ -2,24 +2,115 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 // Config armazena as variáveis do serviço
 type Config struct {
-	EthereumRPCURL  string
-	EthereumWSURL   string
-	ContractAddress string
-	KafkaBroker     string
-	KafkaTopic      string
+	KafkaBroker   string
+	KafkaTopic    string
+	KafkaDLQTopic string // logs que não puderam ser decodificados
+
+	// Redes e contratos observados (arquivo CHAINS_CONFIG_FILE ou variáveis de ambiente)
+	ChainsConfigFile string
+	Chains           []ChainConfig
+
+	// Registro de ABIs: diretório local, cache em disco e consulta opcional ao Etherscan
+	ABIDir           string
+	ABICacheDir      string
+	EtherscanEnabled bool
+	EtherscanAPIKey  string
+
+	// Checkpoint e backfill
+	CheckpointStore   string // file ou postgres
+	CheckpointFile    string
+	CheckpointDSN     string
+	StartBlock        uint64 // bloco inicial da Sepolia (ou da rede das variáveis de ambiente) sem checkpoint
+	BackfillBatchSize uint64 // quantidade de blocos por FilterLogs no backfill
+
+	// Blocos acima de um log para considerá-lo confirmado (proteção contra reorgs)
+	ConfirmationDepth uint64
+
+	// Reconexão e fallback para polling HTTP
+	PollInterval    time.Duration
+	WSRetryInterval time.Duration // tempo em polling antes de tentar o WebSocket de novo
+	MaxWSFailures   int           // falhas seguidas do WebSocket antes do fallback
+
+	// /healthz e /readyz: a rede fica indisponível se o lag ou o tempo sem novos blocos
+	// passar dos limites
+	HealthPort        string
+	HealthMaxBlockLag uint64
+	HealthMaxHeadAge  time.Duration
+}
+
+// LoadConfig carrega as configurações do ambiente e o arquivo de redes
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		KafkaBroker:   os.Getenv("KAFKA_BROKER"),
+		KafkaTopic:    os.Getenv("KAFKA_TOPIC"),
+		KafkaDLQTopic: getEnv("KAFKA_DLQ_TOPIC", os.Getenv("KAFKA_TOPIC")+".dlq"),
+
+		ChainsConfigFile: getEnv("CHAINS_CONFIG_FILE", "config/chains.json"),
+
+		ABIDir:           getEnv("ABI_DIR", "abis"),
+		ABICacheDir:      getEnv("ABI_CACHE_DIR", "data/abi-cache"),
+		EtherscanEnabled: getEnv("ETHERSCAN_ENABLED", "true") == "true",
+		EtherscanAPIKey:  os.Getenv("API_KEY_ETHERSCAN"),
+
+		CheckpointStore:   getEnv("CHECKPOINT_STORE", "file"),
+		CheckpointFile:    getEnv("CHECKPOINT_FILE", "data/checkpoint.json"),
+		CheckpointDSN:     os.Getenv("CHECKPOINT_DSN"),
+		StartBlock:        getEnvUint("START_BLOCK", 7361255),
+		BackfillBatchSize: getEnvUint("BACKFILL_BATCH_SIZE", 50000),
+
+		ConfirmationDepth: getEnvUintOrZero("CONFIRMATION_DEPTH", 12),
+
+		PollInterval:    getEnvDuration("POLL_INTERVAL", 15*time.Second),
+		WSRetryInterval: getEnvDuration("WS_RETRY_INTERVAL", 5*time.Minute),
+		MaxWSFailures:   int(getEnvUint("WS_MAX_FAILURES", 3)),
+
+		HealthPort:        getEnv("HEALTH_PORT", "8090"),
+		HealthMaxBlockLag: getEnvUint("HEALTH_MAX_BLOCK_LAG", 100),
+		HealthMaxHeadAge:  getEnvDuration("HEALTH_MAX_HEAD_AGE", 2*time.Minute),
+	}
+
+	chains, err := LoadChains(cfg.ChainsConfigFile, cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Chains = chains
+	return cfg, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+func getEnvUint(key string, defaultValue uint64) uint64 {
+	v, err := strconv.ParseUint(os.Getenv(key), 10, 64)
+	if err != nil || v == 0 {
+		return defaultValue
+	}
+	return v
+}
+
+// getEnvUintOrZero é como getEnvUint, mas aceita 0 como valor informado
+func getEnvUintOrZero(key string, defaultValue uint64) uint64 {
+	v, err := strconv.ParseUint(os.Getenv(key), 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return v
 }
 
-// LoadConfig carrega as configurações do ambiente
-func LoadConfig() *Config {
-	return &Config{
-		EthereumRPCURL:  os.Getenv("ETHEREUM_RPC_URL"),
-		EthereumWSURL:   os.Getenv("ETHEREUM_WS_URL"),
-		ContractAddress: os.Getenv("CONTRACT_ADDRESS"),
-		KafkaBroker:     os.Getenv("KAFKA_BROKER"),
-		KafkaTopic:      os.Getenv("KAFKA_TOPIC"),
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	v, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || v <= 0 {
+		return defaultValue
 	}
+	return v
 }
@@ -0,0 +1,164 @@
+package abiregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Registry resolve a ABI de um contrato sem depender do Etherscan na inicialização.
+// Ordem de busca:
+//  1. arquivo informado na configuração do contrato
+//  2. <Dir>/<rede>/<endereço>.json
+//  3. <Dir>/<endereço>.json
+//  4. cache em disco de consultas anteriores ao Etherscan (<CacheDir>/<chain id>/<endereço>.json)
+//  5. API do Etherscan, se habilitada; o resultado é gravado no cache
+type Registry struct {
+	Dir              string
+	CacheDir         string
+	EtherscanEnabled bool
+	EtherscanAPIKey  string
+	HTTPClient       *http.Client
+}
+
+// Lookup identifica o contrato cuja ABI deve ser resolvida
+type Lookup struct {
+	Rede            string
+	ChainID         uint64
+	Address         string
+	File            string // ABI explícita na configuração do contrato
+	EtherscanAPIURL string
+}
+
+// NewRegistry cria o registro de ABIs
+func NewRegistry(dir, cacheDir string, etherscanEnabled bool, etherscanAPIKey string) *Registry {
+	return &Registry{
+		Dir:              dir,
+		CacheDir:         cacheDir,
+		EtherscanEnabled: etherscanEnabled,
+		EtherscanAPIKey:  etherscanAPIKey,
+		HTTPClient:       &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Get retorna a ABI do contrato
+func (r *Registry) Get(l Lookup) (abi.ABI, error) {
+	address := strings.ToLower(l.Address)
+
+	if l.File != "" {
+		return LoadFile(l.File)
+	}
+
+	candidatos := []string{}
+	if r.Dir != "" {
+		if l.Rede != "" {
+			candidatos = append(candidatos, filepath.Join(r.Dir, l.Rede, address+".json"))
+		}
+		candidatos = append(candidatos, filepath.Join(r.Dir, address+".json"))
+	}
+	cacheFile := ""
+	if r.CacheDir != "" {
+		cacheFile = filepath.Join(r.CacheDir, fmt.Sprint(l.ChainID), address+".json")
+		candidatos = append(candidatos, cacheFile)
+	}
+
+	for _, path := range candidatos {
+		contractABI, err := LoadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return abi.ABI{}, err
+		}
+		slog.Info("ABI carregada do arquivo", "contract", l.Address, "path", path)
+		return contractABI, nil
+	}
+
+	if !r.EtherscanEnabled {
+		return abi.ABI{}, fmt.Errorf("ABI do contrato %s não encontrada em %s e Etherscan desabilitado", l.Address, r.Dir)
+	}
+
+	raw, err := r.fetchEtherscan(l.EtherscanAPIURL, l.Address)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	contractABI, err := abi.JSON(strings.NewReader(raw))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("Erro ao carregar ABI: %v", err)
+	}
+	slog.Info("ABI carregada do Etherscan", "contract", l.Address)
+
+	if cacheFile != "" {
+		if err := writeFile(cacheFile, []byte(raw)); err != nil {
+			slog.Warn("erro ao gravar cache da ABI", "contract", l.Address, "error", err.Error())
+		}
+	}
+	return contractABI, nil
+}
+
+// LoadFile carrega uma ABI JSON de um arquivo local
+func LoadFile(path string) (abi.ABI, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	defer file.Close()
+
+	contractABI, err := abi.JSON(file)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("Erro ao carregar ABI %s: %v", path, err)
+	}
+	return contractABI, nil
+}
+
+// fetchEtherscan busca a ABI (JSON) do contrato na API do Etherscan (ou compatível) informada
+func (r *Registry) fetchEtherscan(apiURL, address string) (string, error) {
+	if r.EtherscanAPIKey == "" {
+		return "", fmt.Errorf("ABI do contrato %s não encontrada localmente e API_KEY_ETHERSCAN não definida", address)
+	}
+	url := fmt.Sprintf("%s?module=contract&action=getabi&address=%s&apikey=%s", apiURL, address, r.EtherscanAPIKey)
+
+	resp, err := r.HTTPClient.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("Erro ao acessar API do Etherscan: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Erro ao ler resposta da API: %v", err)
+	}
+
+	var result struct {
+		Status string `json:"status"`
+		Result string `json:"result"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("Erro ao parsear resposta da API: %v", err)
+	}
+	if result.Status != "1" {
+		return "", fmt.Errorf("ABI não encontrada no Etherscan para o contrato %s: %s", address, result.Result)
+	}
+	return result.Result, nil
+}
+
+// writeFile grava o arquivo de forma atômica (arquivo temporário + rename)
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,63 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// BlocoCompleto é usado como LogIndex quando todos os logs do bloco já foram processados
+const BlocoCompleto = math.MaxUint32
+
+// Checkpoint indica o último log processado: todos os logs até (BlockNumber, LogIndex),
+// inclusive, já foram publicados no Kafka.
+type Checkpoint struct {
+	BlockNumber uint64 `json:"block_number"`
+	LogIndex    uint   `json:"log_index"`
+}
+
+// Store persiste checkpoints por chave (rede + contrato)
+type Store interface {
+	Load(ctx context.Context, key string) (*Checkpoint, error) // nil se ainda não existe
+	Save(ctx context.Context, key string, cp Checkpoint) error
+	Close() error
+}
+
+// Key monta a chave do checkpoint para um contrato em uma rede
+func Key(chainID uint64, contractAddress string) string {
+	return fmt.Sprintf("%d:%s", chainID, strings.ToLower(contractAddress))
+}
+
+// Covers informa se o log (block, index) já está coberto pelo checkpoint
+func (c *Checkpoint) Covers(block uint64, index uint) bool {
+	if c == nil {
+		return false
+	}
+	if block != c.BlockNumber {
+		return block < c.BlockNumber
+	}
+	return index <= c.LogIndex
+}
+
+// NextBlock retorna o bloco a partir do qual a busca deve continuar.
+// Se o bloco do checkpoint não foi concluído, ele é buscado novamente e os logs já
+// processados são ignorados por Covers.
+func (c *Checkpoint) NextBlock() uint64 {
+	if c.LogIndex == BlocoCompleto {
+		return c.BlockNumber + 1
+	}
+	return c.BlockNumber
+}
+
+// NewStore cria o store configurado: "file" (padrão) ou "postgres"
+func NewStore(ctx context.Context, kind, filePath, dsn string) (Store, error) {
+	switch kind {
+	case "", "file":
+		return NewFileStore(filePath)
+	case "postgres":
+		return NewPostgresStore(ctx, dsn)
+	default:
+		return nil, fmt.Errorf("CHECKPOINT_STORE inválido: %s", kind)
+	}
+}
@@ -0,0 +1,46 @@
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoint_Covers(t *testing.T) {
+	cp := &Checkpoint{BlockNumber: 100, LogIndex: 5}
+	completo := &Checkpoint{BlockNumber: 100, LogIndex: BlocoCompleto}
+
+	casos := []struct {
+		nome  string
+		cp    *Checkpoint
+		block uint64
+		index uint
+		want  bool
+	}{
+		{"sem checkpoint", nil, 0, 0, false},
+		{"bloco anterior", cp, 99, 1000, true},
+		{"mesmo bloco, índice anterior", cp, 100, 4, true},
+		{"mesmo bloco, mesmo índice", cp, 100, 5, true},
+		{"mesmo bloco, índice seguinte", cp, 100, 6, false},
+		{"bloco seguinte", cp, 101, 0, false},
+		{"bloco completo cobre qualquer índice", completo, 100, 100000, true},
+		{"bloco completo não cobre o seguinte", completo, 101, 0, false},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			assert.Equal(t, caso.want, caso.cp.Covers(caso.block, caso.index))
+		})
+	}
+}
+
+func TestCheckpoint_NextBlock(t *testing.T) {
+	// bloco incompleto é buscado de novo; os logs já publicados são ignorados por Covers
+	assert.Equal(t, uint64(100), (&Checkpoint{BlockNumber: 100, LogIndex: 5}).NextBlock())
+	assert.Equal(t, uint64(100), (&Checkpoint{BlockNumber: 100, LogIndex: 0}).NextBlock())
+	assert.Equal(t, uint64(101), (&Checkpoint{BlockNumber: 100, LogIndex: BlocoCompleto}).NextBlock())
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "11155111:0xd47b84cd828538ee33264911e117e3557af39231",
+		Key(11155111, "0xd47B84cD828538eE33264911E117e3557af39231"))
+}
@@ -0,0 +1,81 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore guarda os checkpoints em um arquivo JSON (chave -> checkpoint)
+type FileStore struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{Path: path}, nil
+}
+
+func (s *FileStore) Load(ctx context.Context, key string) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+	cp, ok := all[key]
+	if !ok {
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+func (s *FileStore) Save(ctx context.Context, key string, cp Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	all[key] = cp
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// grava em arquivo temporário e renomeia para não corromper o checkpoint
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.Path)
+}
+
+// Close não faz nada: cada Save já grava o arquivo
+func (s *FileStore) Close() error {
+	return nil
+}
+
+func (s *FileStore) readAll() (map[string]Checkpoint, error) {
+	all := make(map[string]Checkpoint)
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,51 @@
+package checkpoint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore_SaveLoad(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data", "checkpoint.json")
+
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	cp, err := store.Load(ctx, "1:0xabc")
+	require.NoError(t, err)
+	assert.Nil(t, cp, "chave sem checkpoint")
+
+	require.NoError(t, store.Save(ctx, "1:0xabc", Checkpoint{BlockNumber: 10, LogIndex: 2}))
+	require.NoError(t, store.Save(ctx, "2:0xdef", Checkpoint{BlockNumber: 20, LogIndex: BlocoCompleto}))
+	require.NoError(t, store.Save(ctx, "1:0xabc", Checkpoint{BlockNumber: 11, LogIndex: 0}))
+	require.NoError(t, store.Close())
+
+	// outra instância lê o que foi gravado no arquivo
+	reaberto, err := NewFileStore(path)
+	require.NoError(t, err)
+	cp, err = reaberto.Load(ctx, "1:0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, &Checkpoint{BlockNumber: 11, LogIndex: 0}, cp)
+	cp, err = reaberto.Load(ctx, "2:0xdef")
+	require.NoError(t, err)
+	assert.Equal(t, &Checkpoint{BlockNumber: 20, LogIndex: BlocoCompleto}, cp)
+
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err), "arquivo temporário renomeado")
+}
+
+func TestFileStore_ArquivoInvalido(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+	_, err = store.Load(context.Background(), "k")
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package checkpoint
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresStore guarda os checkpoints na tabela eth_listener_checkpoints
+type PostgresStore struct {
+	DB *sql.DB
+}
+
+func NewPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return nil, err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS eth_listener_checkpoints (
+			key          VARCHAR(120) PRIMARY KEY,
+			block_number BIGINT      NOT NULL,
+			log_index    BIGINT      NOT NULL,
+			updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`)
+	if err != nil {
+		return nil, err
+	}
+	return &PostgresStore{DB: db}, nil
+}
+
+func (s *PostgresStore) Load(ctx context.Context, key string) (*Checkpoint, error) {
+	var cp Checkpoint
+	err := s.DB.QueryRowContext(ctx,
+		`SELECT block_number, log_index FROM eth_listener_checkpoints WHERE key = $1`, key,
+	).Scan(&cp.BlockNumber, &cp.LogIndex)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+func (s *PostgresStore) Save(ctx context.Context, key string, cp Checkpoint) error {
+	_, err := s.DB.ExecContext(ctx, `
+		INSERT INTO eth_listener_checkpoints (key, block_number, log_index, updated_at)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT (key) DO UPDATE
+		SET block_number = EXCLUDED.block_number, log_index = EXCLUDED.log_index, updated_at = now()`,
+		key, cp.BlockNumber, cp.LogIndex)
+	return err
+}
+
+// PingContext verifica a conexão com o banco, para a prontidão do serviço
+func (s *PostgresStore) PingContext(ctx context.Context) error {
+	return s.DB.PingContext(ctx)
+}
+
+func (s *PostgresStore) Close() error {
+	return s.DB.Close()
+}
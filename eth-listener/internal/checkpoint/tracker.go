@@ -0,0 +1,71 @@
+package checkpoint
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Tracker mantém o checkpoint corrente de um contrato e o persiste no Store
+type Tracker struct {
+	Store   Store
+	Key     string
+	Current *Checkpoint
+}
+
+// NewTracker carrega o checkpoint salvo para a chave informada
+func NewTracker(ctx context.Context, store Store, key string) (*Tracker, error) {
+	cp, err := store.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if cp != nil {
+		slog.Info("checkpoint carregado", "key", key, "block", cp.BlockNumber, "log_index", cp.LogIndex)
+	} else {
+		slog.Info("nenhum checkpoint salvo", "key", key)
+	}
+	return &Tracker{Store: store, Key: key, Current: cp}, nil
+}
+
+// ShouldProcess informa se o log ainda não foi processado
+func (t *Tracker) ShouldProcess(block uint64, index uint) bool {
+	return !t.Current.Covers(block, index)
+}
+
+// Commit registra o log como processado
+func (t *Tracker) Commit(ctx context.Context, block uint64, index uint) error {
+	return t.save(ctx, Checkpoint{BlockNumber: block, LogIndex: index})
+}
+
+// CommitBlock registra todos os logs até o bloco informado como processados
+func (t *Tracker) CommitBlock(ctx context.Context, block uint64) error {
+	if t.Current.Covers(block, BlocoCompleto) {
+		return nil
+	}
+	return t.save(ctx, Checkpoint{BlockNumber: block, LogIndex: BlocoCompleto})
+}
+
+// StartBlock retorna o bloco inicial da busca: o seguinte ao checkpoint ou o padrão informado
+func (t *Tracker) StartBlock(defaultBlock uint64) uint64 {
+	if t.Current == nil {
+		return defaultBlock
+	}
+	return t.Current.NextBlock()
+}
+
+func (t *Tracker) save(ctx context.Context, cp Checkpoint) error {
+	if err := t.Store.Save(ctx, t.Key, cp); err != nil {
+		return err
+	}
+	t.Current = &cp
+	return nil
+}
+
+// Rewind volta o checkpoint para antes do bloco informado, para que os logs desse bloco
+// em diante sejam processados novamente (ex.: após uma reorganização da rede)
+func (t *Tracker) Rewind(ctx context.Context, block uint64) error {
+	if !t.Current.Covers(block, 0) || block == 0 {
+		return nil
+	}
+	slog.Warn("checkpoint voltando", "key", t.Key, "block", block-1)
+	return t.save(ctx, Checkpoint{BlockNumber: block - 1, LogIndex: BlocoCompleto})
+}
@@ -0,0 +1,82 @@
+package checkpoint
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTrackerTeste(t *testing.T, atual *Checkpoint) *Tracker {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	require.NoError(t, err)
+	if atual != nil {
+		require.NoError(t, store.Save(context.Background(), "k", *atual))
+	}
+	tracker, err := NewTracker(context.Background(), store, "k")
+	require.NoError(t, err)
+	return tracker
+}
+
+func TestTracker_StartBlock(t *testing.T) {
+	assert.Equal(t, uint64(50), newTrackerTeste(t, nil).StartBlock(50))
+	assert.Equal(t, uint64(100), newTrackerTeste(t, &Checkpoint{BlockNumber: 100, LogIndex: 3}).StartBlock(50))
+	assert.Equal(t, uint64(101), newTrackerTeste(t, &Checkpoint{BlockNumber: 100, LogIndex: BlocoCompleto}).StartBlock(50))
+}
+
+func TestTracker_CommitEShouldProcess(t *testing.T) {
+	ctx := context.Background()
+	tracker := newTrackerTeste(t, nil)
+	assert.True(t, tracker.ShouldProcess(10, 0))
+
+	require.NoError(t, tracker.Commit(ctx, 10, 2))
+	assert.False(t, tracker.ShouldProcess(10, 2))
+	assert.True(t, tracker.ShouldProcess(10, 3))
+
+	require.NoError(t, tracker.CommitBlock(ctx, 10))
+	assert.False(t, tracker.ShouldProcess(10, 3))
+	assert.True(t, tracker.ShouldProcess(11, 0))
+
+	// CommitBlock de um bloco já coberto não volta o checkpoint
+	require.NoError(t, tracker.CommitBlock(ctx, 5))
+	assert.Equal(t, Checkpoint{BlockNumber: 10, LogIndex: BlocoCompleto}, *tracker.Current)
+}
+
+func TestTracker_Rewind(t *testing.T) {
+	casos := []struct {
+		nome  string
+		atual *Checkpoint
+		block uint64
+		want  *Checkpoint
+	}{
+		{"sem checkpoint não faz nada", nil, 10, nil},
+		{"bloco ainda não processado não faz nada", &Checkpoint{BlockNumber: 10, LogIndex: 3}, 11, &Checkpoint{BlockNumber: 10, LogIndex: 3}},
+		{"bloco do checkpoint volta para o anterior completo", &Checkpoint{BlockNumber: 10, LogIndex: 3}, 10, &Checkpoint{BlockNumber: 9, LogIndex: BlocoCompleto}},
+		{"bloco anterior ao checkpoint", &Checkpoint{BlockNumber: 10, LogIndex: BlocoCompleto}, 7, &Checkpoint{BlockNumber: 6, LogIndex: BlocoCompleto}},
+		{"bloco zero não volta", &Checkpoint{BlockNumber: 10, LogIndex: 3}, 0, &Checkpoint{BlockNumber: 10, LogIndex: 3}},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			tracker := newTrackerTeste(t, caso.atual)
+			require.NoError(t, tracker.Rewind(context.Background(), caso.block))
+			assert.Equal(t, caso.want, tracker.Current)
+
+			// o checkpoint salvo acompanha o corrente
+			salvo, err := tracker.Store.Load(context.Background(), "k")
+			require.NoError(t, err)
+			assert.Equal(t, caso.want, salvo)
+		})
+	}
+}
+
+func TestTracker_RewindReprocessaOBloco(t *testing.T) {
+	ctx := context.Background()
+	tracker := newTrackerTeste(t, &Checkpoint{BlockNumber: 10, LogIndex: 3})
+
+	require.NoError(t, tracker.Rewind(ctx, 10))
+	assert.True(t, tracker.ShouldProcess(10, 0))
+	assert.False(t, tracker.ShouldProcess(9, 100))
+	assert.Equal(t, uint64(10), tracker.StartBlock(0))
+}
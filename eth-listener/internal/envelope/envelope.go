@@ -0,0 +1,128 @@
+package envelope
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SchemaVersion é a versão do envelope publicado no Kafka (schema/eth-event.v1.schema.json).
+// Mudanças incompatíveis devem incrementar a versão.
+const SchemaVersion = 1
+
+// EthEvent é a mensagem publicada para cada log decodificado de um contrato observado
+type EthEvent struct {
+	SchemaVersion  int                    `json:"schema_version"`
+	ChainID        uint64                 `json:"chain_id"`
+	Network        string                 `json:"network"`
+	Contract       string                 `json:"contract"`
+	BlockNumber    uint64                 `json:"block_number"`
+	BlockHash      string                 `json:"block_hash"`
+	BlockTimestamp time.Time              `json:"block_timestamp"`
+	TxHash         string                 `json:"tx_hash"`
+	LogIndex       uint                   `json:"log_index"`
+	Event          string                 `json:"event"`
+	Args           map[string]interface{} `json:"args"`
+	Removed        bool                   `json:"removed"`                 // true: log removido por reorganização
+	GasPriceWei    string                 `json:"gas_price_wei,omitempty"` // inteiro decimal
+	TxFeeWei       string                 `json:"tx_fee_wei,omitempty"`    // inteiro decimal
+}
+
+// DeadLetter é publicada no tópico de DLQ para logs que não puderam ser decodificados
+type DeadLetter struct {
+	SchemaVersion int       `json:"schema_version"`
+	ChainID       uint64    `json:"chain_id"`
+	Network       string    `json:"network"`
+	Contract      string    `json:"contract"`
+	BlockNumber   uint64    `json:"block_number"`
+	BlockHash     string    `json:"block_hash"`
+	TxHash        string    `json:"tx_hash"`
+	LogIndex      uint      `json:"log_index"`
+	Topics        []string  `json:"topics"`
+	Data          string    `json:"data"`              // hex 0x...
+	Removed       bool      `json:"removed,omitempty"` // true: o log não decodificado era a reversão de um evento
+	Error         string    `json:"error"`
+	FailedAt      time.Time `json:"failed_at"`
+}
+
+// EncodeArgs converte os argumentos decodificados pela ABI para tipos seguros em JSON:
+// inteiros (inclusive *big.Int) viram strings decimais, endereços usam o checksum EIP-55,
+// bytes viram hex 0x e tuplas viram objetos.
+func EncodeArgs(args map[string]interface{}) map[string]interface{} {
+	encoded := make(map[string]interface{}, len(args))
+	for name, value := range args {
+		encoded[name] = EncodeValue(value)
+	}
+	return encoded
+}
+
+// EncodeValue converte um valor decodificado pela ABI (ver EncodeArgs)
+func EncodeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case string, bool:
+		return v
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprint(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(rv.Uint())
+	case reflect.Array:
+		// bytesN
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return "0x" + hex.EncodeToString(b)
+		}
+		return encodeList(rv)
+	case reflect.Slice:
+		return encodeList(rv)
+	case reflect.Struct:
+		obj := make(map[string]interface{}, rv.NumField())
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if tag := field.Tag.Get("json"); tag != "" && tag != "-" {
+				name = tag
+			}
+			obj[name] = EncodeValue(rv.Field(i).Interface())
+		}
+		return obj
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+		return EncodeValue(rv.Elem().Interface())
+	}
+	return value
+}
+
+func encodeList(rv reflect.Value) []interface{} {
+	list := make([]interface{}, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		list[i] = EncodeValue(rv.Index(i).Interface())
+	}
+	return list
+}
@@ -0,0 +1,86 @@
+package ethereum
+
+import (
+	"context"
+	"sort"
+
+	"eth-listener/internal/checkpoint"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Contract é um contrato observado, com sua ABI e seu checkpoint
+type Contract struct {
+	Address    common.Address
+	ABI        abi.ABI
+	Tracker    *checkpoint.Tracker
+	StartBlock uint64 // bloco inicial quando não há checkpoint
+}
+
+// ShouldProcess informa se o log ainda não foi processado para este contrato
+func (c *Contract) ShouldProcess(block uint64, index uint) bool {
+	return block >= c.StartBlock && c.Tracker.ShouldProcess(block, index)
+}
+
+// Chain agrupa os contratos observados em uma rede
+type Chain struct {
+	Name      string
+	ChainID   uint64
+	Contracts map[common.Address]*Contract
+	Status    *ChainStatus
+}
+
+// NewChain cria uma rede sem contratos
+func NewChain(name string, chainID uint64) *Chain {
+	return &Chain{
+		Name:      name,
+		ChainID:   chainID,
+		Contracts: make(map[common.Address]*Contract),
+		Status:    NewChainStatus(name),
+	}
+}
+
+// AddContract adiciona um contrato à rede
+func (c *Chain) AddContract(contract *Contract) {
+	c.Contracts[contract.Address] = contract
+}
+
+// Addresses retorna os endereços dos contratos observados, para os filtros de log
+func (c *Chain) Addresses() []common.Address {
+	addresses := make([]common.Address, 0, len(c.Contracts))
+	for address := range c.Contracts {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	return addresses
+}
+
+// StartBlock retorna o menor bloco a partir do qual algum contrato ainda precisa ser buscado
+func (c *Chain) StartBlock() uint64 {
+	first := true
+	var start uint64
+	for _, contract := range c.Contracts {
+		block := contract.Tracker.StartBlock(contract.StartBlock)
+		if first || block < start {
+			start = block
+			first = false
+		}
+	}
+	return start
+}
+
+// CommitBlock registra, para todos os contratos, os logs até o bloco informado como processados
+func (c *Chain) CommitBlock(ctx context.Context, block uint64) error {
+	for _, contract := range c.Contracts {
+		if block < contract.StartBlock {
+			continue
+		}
+		if err := contract.Tracker.CommitBlock(ctx, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,16 +1,56 @@
 package ethereum
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ggialluisi/nebula-back/platform/logging"
 )
 
-func NewEthereumClient(wsURL string) *ethclient.Client {
-	client, err := ethclient.Dial(wsURL)
-	if err != nil {
-		log.Fatalf("Erro ao conectar no nó Ethereum: %v", err)
+const (
+	// BackoffInicial é a espera após a primeira falha de conexão
+	BackoffInicial = time.Second
+	// BackoffMaximo limita a espera entre tentativas de conexão
+	BackoffMaximo = time.Minute
+)
+
+// NewEthereumClient conecta ao nó Ethereum (WebSocket ou HTTP), tentando novamente com
+// backoff exponencial até conseguir. Só retorna erro se o contexto for cancelado.
+func NewEthereumClient(ctx context.Context, url string) (*ethclient.Client, error) {
+	backoff := BackoffInicial
+	for {
+		client, err := ethclient.DialContext(ctx, url)
+		if err == nil {
+			slog.Info("conectado ao nó Ethereum")
+			return client, nil
+		}
+
+		slog.Error("erro ao conectar no nó Ethereum", "retry_in", backoff.String(), logging.Err(err))
+		if err := esperar(ctx, backoff); err != nil {
+			return nil, err
+		}
+		backoff = proximoBackoff(backoff)
+	}
+}
+
+// esperar aguarda a duração informada ou o cancelamento do contexto
+func esperar(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// proximoBackoff dobra a espera atual, até BackoffMaximo
+func proximoBackoff(atual time.Duration) time.Duration {
+	if atual*2 > BackoffMaximo {
+		return BackoffMaximo
 	}
-	log.Println("✅ Conectado ao nó Geth por WebService!")
-	return client
+	return atual * 2
 }
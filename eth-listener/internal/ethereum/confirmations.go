@@ -0,0 +1,75 @@
+package ethereum
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// logID identifica um log em um bloco específico da cadeia
+type logID struct {
+	BlockHash common.Hash
+	TxHash    common.Hash
+	Index     uint
+}
+
+func newLogID(vLog types.Log) logID {
+	return logID{BlockHash: vLog.BlockHash, TxHash: vLog.TxHash, Index: vLog.Index}
+}
+
+// ConfirmationBuffer retém os logs recebidos em tempo real até que tenham Depth blocos
+// acima deles, evitando publicar eventos que ainda podem ser desfeitos por uma reorganização
+type ConfirmationBuffer struct {
+	Depth   uint64
+	pending map[logID]types.Log
+}
+
+// NewConfirmationBuffer cria um buffer com a profundidade de confirmação informada
+func NewConfirmationBuffer(depth uint64) *ConfirmationBuffer {
+	return &ConfirmationBuffer{
+		Depth:   depth,
+		pending: make(map[logID]types.Log),
+	}
+}
+
+// Add guarda o log até que ele seja confirmado
+func (b *ConfirmationBuffer) Add(vLog types.Log) {
+	b.pending[newLogID(vLog)] = vLog
+}
+
+// Remove descarta um log removido da cadeia. Retorna true se o log ainda estava no buffer,
+// ou seja, se nunca foi publicado
+func (b *ConfirmationBuffer) Remove(vLog types.Log) bool {
+	id := newLogID(vLog)
+	if _, ok := b.pending[id]; !ok {
+		return false
+	}
+	delete(b.pending, id)
+	return true
+}
+
+// Confirmed retira do buffer e retorna, em ordem de bloco e índice, os logs com ao menos
+// Depth blocos acima deles no head informado
+func (b *ConfirmationBuffer) Confirmed(head uint64) []types.Log {
+	confirmed := []types.Log{}
+	for id, vLog := range b.pending {
+		if vLog.BlockNumber+b.Depth <= head {
+			confirmed = append(confirmed, vLog)
+			delete(b.pending, id)
+		}
+	}
+
+	sort.Slice(confirmed, func(i, j int) bool {
+		if confirmed[i].BlockNumber != confirmed[j].BlockNumber {
+			return confirmed[i].BlockNumber < confirmed[j].BlockNumber
+		}
+		return confirmed[i].Index < confirmed[j].Index
+	})
+	return confirmed
+}
+
+// Len retorna a quantidade de logs aguardando confirmação
+func (b *ConfirmationBuffer) Len() int {
+	return len(b.pending)
+}
@@ -0,0 +1,105 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logTeste(block uint64, index uint) types.Log {
+	return types.Log{
+		BlockNumber: block,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(block)),
+		TxHash:      common.HexToHash("0xaa"),
+		Index:       index,
+	}
+}
+
+func TestConfirmationBuffer_Profundidade(t *testing.T) {
+	casos := []struct {
+		nome       string
+		depth      uint64
+		head       uint64
+		confirmado bool
+	}{
+		{"head igual ao bloco sem profundidade", 0, 100, true},
+		{"head antes do bloco sem profundidade", 0, 99, false},
+		{"um bloco antes da profundidade", 12, 111, false},
+		{"exatamente na profundidade", 12, 112, true},
+		{"além da profundidade", 12, 200, true},
+		{"head anterior ao bloco", 12, 50, false},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			buffer := NewConfirmationBuffer(caso.depth)
+			buffer.Add(logTeste(100, 0))
+
+			confirmados := buffer.Confirmed(caso.head)
+			if caso.confirmado {
+				require.Len(t, confirmados, 1)
+				assert.Equal(t, uint64(100), confirmados[0].BlockNumber)
+				assert.Equal(t, 0, buffer.Len())
+				return
+			}
+			assert.Empty(t, confirmados)
+			assert.Equal(t, 1, buffer.Len())
+		})
+	}
+}
+
+func TestConfirmationBuffer_ConfirmadosEmOrdemESoUmaVez(t *testing.T) {
+	buffer := NewConfirmationBuffer(2)
+	buffer.Add(logTeste(11, 1))
+	buffer.Add(logTeste(10, 3))
+	buffer.Add(logTeste(10, 1))
+	buffer.Add(logTeste(12, 0))
+
+	confirmados := buffer.Confirmed(13)
+	require.Len(t, confirmados, 3)
+	assert.Equal(t, []uint64{10, 10, 11}, []uint64{confirmados[0].BlockNumber, confirmados[1].BlockNumber, confirmados[2].BlockNumber})
+	assert.Equal(t, []uint{1, 3, 1}, []uint{confirmados[0].Index, confirmados[1].Index, confirmados[2].Index})
+
+	assert.Empty(t, buffer.Confirmed(13), "logs confirmados saem do buffer")
+	assert.Len(t, buffer.Confirmed(14), 1)
+}
+
+func TestConfirmationBuffer_RemovidoAntesDaConfirmacao(t *testing.T) {
+	buffer := NewConfirmationBuffer(12)
+	vLog := logTeste(100, 0)
+	buffer.Add(vLog)
+
+	removido := vLog
+	removido.Removed = true
+	assert.True(t, buffer.Remove(removido), "log pendente nunca foi publicado")
+	assert.Equal(t, 0, buffer.Len())
+	assert.Empty(t, buffer.Confirmed(200))
+
+	// log já confirmado (ou desconhecido) precisa de um evento de reversão
+	assert.False(t, buffer.Remove(removido))
+}
+
+func TestConfirmationBuffer_RemoveSoOLogDoBlocoRemovido(t *testing.T) {
+	buffer := NewConfirmationBuffer(0)
+	original := logTeste(100, 0)
+	buffer.Add(original)
+
+	// mesmo tx e índice, mas em outro bloco após a reorganização
+	reorganizado := original
+	reorganizado.BlockHash = common.HexToHash("0xbeef")
+	assert.False(t, buffer.Remove(reorganizado))
+	assert.Equal(t, 1, buffer.Len())
+}
+
+func TestConfirmationBuffer_LogDuplicado(t *testing.T) {
+	buffer := NewConfirmationBuffer(1)
+	vLog := logTeste(100, 0)
+	buffer.Add(vLog)
+	buffer.Add(vLog) // reentregue pela inscrição e pelo catch-up
+
+	assert.Equal(t, 1, buffer.Len())
+	assert.Len(t, buffer.Confirmed(101), 1)
+}
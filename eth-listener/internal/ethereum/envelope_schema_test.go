@@ -0,0 +1,126 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const abiTeste = `[{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+	{"name":"from","type":"address","indexed":true},
+	{"name":"to","type":"address","indexed":true},
+	{"name":"value","type":"uint256","indexed":false},
+	{"name":"memo","type":"bytes","indexed":false}
+]}]`
+
+func compilarSchema(t *testing.T, arquivo string) *jsonschema.Schema {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.AssertFormat = true
+	schema, err := compiler.Compile("../../schema/" + arquivo)
+	require.NoError(t, err)
+	return schema
+}
+
+// validarContraSchema serializa o envelope como é publicado no Kafka e valida contra o schema
+func validarContraSchema(t *testing.T, schema *jsonschema.Schema, envelope interface{}) {
+	payload, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	var documento interface{}
+	require.NoError(t, json.Unmarshal(payload, &documento))
+	assert.NoError(t, schema.Validate(documento), string(payload))
+}
+
+func logTransferTeste(t *testing.T) (abi.ABI, types.Log) {
+	contractABI, err := abi.JSON(strings.NewReader(abiTeste))
+	require.NoError(t, err)
+
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0xd47B84cD828538eE33264911E117e3557af39231")
+	data, err := contractABI.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(1000), []byte{0xca, 0xfe})
+	require.NoError(t, err)
+
+	return contractABI, types.Log{
+		Address:     common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		Topics:      []common.Hash{contractABI.Events["Transfer"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:        data,
+		BlockNumber: 7361300,
+		BlockHash:   common.HexToHash("0xb1"),
+		TxHash:      common.HexToHash("0xa1"),
+		Index:       3,
+	}
+}
+
+func TestEnvelope_ValidaContraSchema(t *testing.T) {
+	schema := compilarSchema(t, "eth-event.v1.schema.json")
+	contractABI, vLog := logTransferTeste(t)
+	chain := NewChain("sepolia", 11155111)
+
+	casos := []struct {
+		nome    string
+		removed bool
+	}{
+		{"evento", false},
+		{"reversão", true},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			vLog.Removed = caso.removed
+			eventName, args, err := decodeEventLog(contractABI, vLog)
+			require.NoError(t, err)
+
+			event := novoEnvelope(chain, vLog, eventName, args)
+			event.BlockTimestamp = time.Unix(1735689600, 0).UTC()
+			if !caso.removed {
+				event.GasPriceWei = "1500000000"
+				event.TxFeeWei = "31500000000000"
+			}
+
+			assert.Equal(t, caso.removed, event.Removed)
+			assert.Equal(t, "1000", event.Args["value"])
+			assert.Equal(t, "0xcafe", event.Args["memo"])
+			validarContraSchema(t, schema, event)
+		})
+	}
+}
+
+func TestEnvelope_SchemaRejeitaEnvelopeInvalido(t *testing.T) {
+	schema := compilarSchema(t, "eth-event.v1.schema.json")
+	contractABI, vLog := logTransferTeste(t)
+	eventName, args, err := decodeEventLog(contractABI, vLog)
+	require.NoError(t, err)
+
+	event := novoEnvelope(NewChain("sepolia", 11155111), vLog, eventName, args)
+	event.Args["value"] = 1000 // inteiros devem ser strings decimais
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	var documento interface{}
+	require.NoError(t, json.Unmarshal(payload, &documento))
+	assert.Error(t, schema.Validate(documento))
+}
+
+func TestDeadLetter_ValidaContraSchema(t *testing.T) {
+	schema := compilarSchema(t, "eth-event-dlq.v1.schema.json")
+	contractABI, vLog := logTransferTeste(t)
+	chain := NewChain("sepolia", 11155111)
+
+	for _, removed := range []bool{false, true} {
+		vLog.Removed = removed
+		vLog.Data = []byte{0x01} // dados truncados
+		_, _, err := decodeEventLog(contractABI, vLog)
+		require.Error(t, err)
+
+		deadLetter := novoDeadLetter(chain, vLog, err)
+		assert.Equal(t, removed, deadLetter.Removed)
+		validarContraSchema(t, schema, deadLetter)
+	}
+}
@@ -4,278 +4,420 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"log"
+	"log/slog"
 	"math/big"
-	"net/http"
-	"os"
-	"strings"
 	"time"
 
+	"eth-listener/internal/envelope"
 	mykafka "eth-listener/internal/kafka"
 
+	"github.com/ggialluisi/nebula-back/platform/logging"
+
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
-	kafkago "github.com/segmentio/kafka-go"
 )
 
-// decodeEventLog decodifica um evento baseado na ABI do contrato
-func decodeEventLog(contractABI abi.ABI, vLog types.Log) (string, map[string]interface{}, error) {
-	for name, event := range contractABI.Events {
-		if vLog.Topics[0] == event.ID {
-			decodedData := make(map[string]interface{})
-
-			err := contractABI.UnpackIntoMap(decodedData, name, vLog.Data)
-			if err != nil {
-				return name, nil, fmt.Errorf("❌ Erro ao decodificar dados do evento: %v", err)
-			}
-
-			for i, input := range event.Inputs {
-				if input.Indexed {
-					decodedData[input.Name] = vLog.Topics[i+1].Hex()
-				}
-			}
-
-			return name, decodedData, nil
-		}
-	}
+// decodeError indica que o log não pôde ser decodificado com a ABI do contrato.
+// O log é enviado para a DLQ em vez de ser publicado.
+type decodeError struct {
+	err error
+}
 
-	return hex.EncodeToString(vLog.Topics[0].Bytes()), nil, fmt.Errorf("❌ Evento desconhecido")
+func (e *decodeError) Error() string {
+	return e.err.Error()
 }
 
-// getContractABI busca a ABI do contrato na API do Etherscan
-func GetContractABI(contractAddress string) (abi.ABI, error) {
-	apiKey := os.Getenv("API_KEY_ETHERSCAN")
-	if apiKey == "" {
-		return abi.ABI{}, fmt.Errorf("❌ API_KEY_ETHERSCAN não definida no ambiente")
+// decodeEventLog decodifica um evento baseado na ABI do contrato, inclusive os argumentos indexados
+func decodeEventLog(contractABI abi.ABI, vLog types.Log) (string, map[string]interface{}, error) {
+	if len(vLog.Topics) == 0 {
+		return "", nil, &decodeError{fmt.Errorf("Log sem tópicos (evento anônimo)")}
 	}
-	url := fmt.Sprintf("https://api-sepolia.etherscan.io/api?module=contract&action=getabi&address=%s&apikey=%s", contractAddress, apiKey)
 
-	resp, err := http.Get(url)
+	event, err := contractABI.EventByID(vLog.Topics[0])
 	if err != nil {
-		return abi.ABI{}, fmt.Errorf("❌ Erro ao acessar API do Etherscan: %v", err)
+		return vLog.Topics[0].Hex(), nil, &decodeError{fmt.Errorf("Evento desconhecido: %s", vLog.Topics[0].Hex())}
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	decodedData := make(map[string]interface{})
+	err = contractABI.UnpackIntoMap(decodedData, event.Name, vLog.Data)
 	if err != nil {
-		return abi.ABI{}, fmt.Errorf("❌ Erro ao ler resposta da API: %v", err)
+		return event.Name, nil, &decodeError{fmt.Errorf("Erro ao decodificar dados do evento: %v", err)}
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return abi.ABI{}, fmt.Errorf("❌ Erro ao parsear resposta da API: %v", err)
-	}
-
-	if result["status"] != "1" {
-		return abi.ABI{}, fmt.Errorf("❌ ABI não encontrada para o contrato %s", contractAddress)
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
 	}
-
-	contractABI, err := abi.JSON(strings.NewReader(result["result"].(string)))
+	err = abi.ParseTopicsIntoMap(decodedData, indexed, vLog.Topics[1:])
 	if err != nil {
-		return abi.ABI{}, fmt.Errorf("❌ Erro ao carregar ABI: %v", err)
+		return event.Name, nil, &decodeError{fmt.Errorf("Erro ao decodificar argumentos indexados: %v", err)}
 	}
 
-	fmt.Println("✅ ABI do contrato carregada com sucesso!")
-	return contractABI, nil
+	return event.Name, envelope.EncodeArgs(decodedData), nil
 }
 
-// Buscar eventos passados e retorna o último bloco processado
-func BuscarEventosPassados(client *ethclient.Client, contractAddress common.Address, contractABI abi.ABI, writer *kafkago.Writer) *big.Int {
-	fmt.Println("🔍 Buscando eventos passados...")
-
-	fromBlock := big.NewInt(7361255)
-	batchSize := big.NewInt(50000)
-
-	header, err := client.HeaderByNumber(context.Background(), nil)
+// BuscarEventosPassados publica os eventos dos contratos da rede entre o checkpoint (ou o bloco
+// inicial do contrato, se não houver checkpoint) e o último bloco confirmado da rede
+// (confirmationDepth blocos abaixo do head), em janelas de batchSize blocos.
+// O checkpoint é salvo a cada evento publicado e ao final de cada janela.
+func BuscarEventosPassados(ctx context.Context, client *ethclient.Client, chain *Chain, publisher *mykafka.Publisher, batchSize, confirmationDepth uint64) error {
+	header, err := client.HeaderByNumber(ctx, nil)
 	if err != nil {
-		log.Fatalf("❌ Erro ao obter último bloco: %v", err)
+		return fmt.Errorf("Erro ao obter último bloco: %v", err)
 	}
-	latestBlock := header.Number
-
-	var lastProcessedBlock *big.Int
+	chain.Status.RecordHead(header.Number.Uint64())
+	if header.Number.Uint64() < confirmationDepth {
+		return nil
+	}
+	latestBlock := header.Number.Uint64() - confirmationDepth
 
-	for fromBlock.Cmp(latestBlock) < 0 {
-		toBlock := new(big.Int).Add(fromBlock, batchSize)
-		if toBlock.Cmp(latestBlock) > 0 {
+	fromBlock := chain.StartBlock()
+	for fromBlock <= latestBlock {
+		toBlock := fromBlock + batchSize - 1
+		if toBlock > latestBlock {
 			toBlock = latestBlock
 		}
 
 		query := ethereum.FilterQuery{
-			FromBlock: fromBlock,
-			ToBlock:   toBlock,
-			Addresses: []common.Address{contractAddress},
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(toBlock),
+			Addresses: chain.Addresses(),
 		}
 
-		logs, err := client.FilterLogs(context.Background(), query)
+		logs, err := client.FilterLogs(ctx, query)
 		if err != nil {
-			log.Printf("❌ Erro ao buscar eventos (%d - %d): %v\n", fromBlock, toBlock, err)
-			break
+			return fmt.Errorf("Erro ao buscar eventos em %s (%d - %d): %v", chain.Name, fromBlock, toBlock, err)
 		}
 
 		for _, vLog := range logs {
-			processarEvento(client, contractABI, vLog, writer)
-			lastProcessedBlock = new(big.Int).SetUint64(vLog.BlockNumber)
+			if err := processarEventoComCheckpoint(ctx, client, chain, vLog, publisher); err != nil {
+				return err
+			}
 		}
 
-		fromBlock.Add(fromBlock, batchSize)
-	}
+		if err := chain.CommitBlock(ctx, toBlock); err != nil {
+			return fmt.Errorf("Erro ao salvar checkpoint: %v", err)
+		}
+		slog.Info("backfill concluído", "chain", chain.Name, "block", toBlock)
+		chain.Status.RecordProcessed(toBlock)
 
-	if lastProcessedBlock == nil {
-		lastProcessedBlock = latestBlock
+		fromBlock = toBlock + 1
 	}
 
-	return lastProcessedBlock
+	chain.Status.RecordProcessed(latestBlock)
+	return nil
 }
 
-// ListenEvents monitora eventos do contrato via WebSocket em tempo real
-func ListenEvents(client *ethclient.Client, contractAddress common.Address, contractABI abi.ABI, lastProcessedBlock *big.Int, writer *kafkago.Writer) {
+// ListenEvents monitora eventos dos contratos da rede via WebSocket em tempo real, a partir do
+// checkpoint salvo. Os logs ficam em um ConfirmationBuffer e só são publicados quando têm
+// confirmationDepth blocos acima deles. Logs removidos por reorganização são descartados do
+// buffer ou, se já foram publicados, geram uma mensagem de evento revertido.
+// A cada (re)conexão, os eventos já confirmados desde o checkpoint são publicados por
+// BuscarEventosPassados, em janelas de batchSize blocos; só os blocos ainda não confirmados
+// são buscados de uma vez para o buffer.
+func ListenEvents(ctx context.Context, client *ethclient.Client, chain *Chain, publisher *mykafka.Publisher, batchSize, confirmationDepth uint64) error {
 	logsChan := make(chan types.Log)
+	headsChan := make(chan *types.Header)
+	buffer := NewConfirmationBuffer(confirmationDepth)
 
 	query := ethereum.FilterQuery{
-		FromBlock: lastProcessedBlock,
-		Addresses: []common.Address{contractAddress},
+		Addresses: chain.Addresses(),
 	}
 
-	sub, err := client.SubscribeFilterLogs(context.Background(), query, logsChan)
+	sub, err := client.SubscribeFilterLogs(ctx, query, logsChan)
 	if err != nil {
-		log.Fatalf("❌ Erro ao assinar eventos do contrato: %v", err)
+		return fmt.Errorf("Erro ao assinar eventos dos contratos: %v", err)
 	}
+	defer sub.Unsubscribe()
 
-	fmt.Println("🎧 Ouvindo eventos novos em tempo real...")
+	headSub, err := client.SubscribeNewHead(ctx, headsChan)
+	if err != nil {
+		return fmt.Errorf("Erro ao assinar novos blocos: %v", err)
+	}
+	defer headSub.Unsubscribe()
+
+	// Eventos confirmados entre o checkpoint e o head atual (a assinatura já está ativa,
+	// então nada se perde enquanto a busca roda)
+	if err := BuscarEventosPassados(ctx, client, chain, publisher, batchSize, confirmationDepth); err != nil {
+		return err
+	}
+
+	// Logs dos blocos ainda não confirmados
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Erro ao obter último bloco: %v", err)
+	}
+	head := header.Number.Uint64()
+	chain.Status.RecordHead(head)
+	fromBlock := chain.StartBlock()
+	if fromBlock <= head {
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(head),
+			Addresses: chain.Addresses(),
+		})
+		if err != nil {
+			return fmt.Errorf("Erro ao buscar eventos recentes (%d - %d): %v", fromBlock, head, err)
+		}
+		for _, vLog := range logs {
+			buffer.Add(vLog)
+		}
+	}
+	if err := publicarConfirmados(ctx, client, chain, buffer, head, publisher); err != nil {
+		return err
+	}
+
+	slog.Info("ouvindo eventos novos em tempo real", "chain", chain.Name, "confirmations", confirmationDepth)
 
 	for {
 		select {
+		case <-ctx.Done():
+			return ctx.Err()
 		case err := <-sub.Err():
-			log.Fatalf("❌ Erro na assinatura de eventos: %v", err)
+			return fmt.Errorf("Erro na assinatura de eventos: %v", err)
+		case err := <-headSub.Err():
+			return fmt.Errorf("Erro na assinatura de novos blocos: %v", err)
 		case vLog := <-logsChan:
-			processarEvento(client, contractABI, vLog, writer)
+			if !vLog.Removed {
+				buffer.Add(vLog)
+				continue
+			}
+			if err := processarEventoRemovido(ctx, client, chain, vLog, buffer, publisher); err != nil {
+				return err
+			}
+		case header := <-headsChan:
+			chain.Status.RecordHead(header.Number.Uint64())
+			if err := publicarConfirmados(ctx, client, chain, buffer, header.Number.Uint64(), publisher); err != nil {
+				return err
+			}
 		}
 	}
 }
 
-// processarEvento extrai, exibe e envia evento ao Kafka
-func processarEvento(client *ethclient.Client, contractABI abi.ABI, vLog types.Log, writer *kafkago.Writer) {
-	eventJSON := extrairDadosEvento(client, contractABI, vLog)
-	displayEventDetails(eventJSON)
-	mykafka.SendEvent(writer, vLog.TxHash.Hex(), string(eventJSON))
-}
+// publicarConfirmados publica os logs do buffer que atingiram a profundidade de confirmação.
+// Logs cujo bloco não pertence mais à cadeia canônica são descartados.
+func publicarConfirmados(ctx context.Context, client *ethclient.Client, chain *Chain, buffer *ConfirmationBuffer, head uint64, publisher *mykafka.Publisher) error {
+	for _, vLog := range buffer.Confirmed(head) {
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(vLog.BlockNumber))
+		if err != nil {
+			return fmt.Errorf("Erro ao obter bloco %d: %v", vLog.BlockNumber, err)
+		}
+		if header.Hash() != vLog.BlockHash {
+			slog.Warn("log descartado: bloco não é mais canônico",
+				"chain", chain.Name, "tx_hash", vLog.TxHash.Hex(), "log_index", vLog.Index, "block", vLog.BlockNumber)
+			continue
+		}
 
-// extrairDadosEvento processa os detalhes do evento e retorna um JSON
-func extrairDadosEvento(client *ethclient.Client, contractABI abi.ABI, vLog types.Log) []byte {
-	eventName, decodedData, err := decodeEventLog(contractABI, vLog)
-	if err != nil {
-		log.Printf("❌ Erro ao decodificar evento: %v\n", err)
-		return []byte(`{}`)
+		if err := processarEventoComCheckpoint(ctx, client, chain, vLog, publisher); err != nil {
+			return err
+		}
 	}
 
-	blockNumber := vLog.BlockNumber
-	timestamp := buscaTimestampPorBloco(client, blockNumber)
-
-	// Buscar transação completa
-	tx, isPending, err := client.TransactionByHash(context.Background(), vLog.TxHash)
-	if err != nil {
-		log.Printf("❌ Erro ao obter transação %s: %v\n", vLog.TxHash.Hex(), err)
-		return []byte(`{}`)
+	// Os blocos confirmados já foram publicados: o checkpoint avança mesmo sem eventos, para
+	// que a próxima (re)conexão comece daqui. Sem profundidade de confirmação, o bloco do head
+	// fica de fora, pois os logs dele podem chegar depois do cabeçalho.
+	if head <= buffer.Depth {
+		return nil
+	}
+	confirmado := head - buffer.Depth
+	if buffer.Depth == 0 {
+		confirmado--
 	}
+	if err := chain.CommitBlock(ctx, confirmado); err != nil {
+		return fmt.Errorf("Erro ao salvar checkpoint: %v", err)
+	}
+	chain.Status.RecordProcessed(confirmado)
+	return nil
+}
 
-	// Definir Gas Price
-	gasPrice := "N/A"
-	var gasPriceBig *big.Int
-	if tx.GasPrice() != nil {
-		gasPriceBig = tx.GasPrice()
-		gasPrice = gasPriceBig.String()
+// processarEventoRemovido trata um log removido por reorganização: se ainda não foi publicado
+// é apenas descartado; caso contrário publica o evento revertido e volta o checkpoint para que
+// a versão canônica do bloco seja processada novamente
+func processarEventoRemovido(ctx context.Context, client *ethclient.Client, chain *Chain, vLog types.Log, buffer *ConfirmationBuffer, publisher *mykafka.Publisher) error {
+	if buffer.Remove(vLog) {
+		slog.Warn("log removido antes da confirmação", "chain", chain.Name, "tx_hash", vLog.TxHash.Hex(), "log_index", vLog.Index)
+		return nil
+	}
+	contract, ok := chain.Contracts[vLog.Address]
+	if !ok || contract.ShouldProcess(vLog.BlockNumber, vLog.Index) {
+		return nil
 	}
 
-	// Buscar recibo da transação para obter Gas Used e calcular a taxa
-	taxaTransacao := "N/A"
-	if !isPending {
-		receipt, err := client.TransactionReceipt(context.Background(), vLog.TxHash)
-		if err == nil {
-			// Converter GasUsed (uint64) para big.Int
-			gasUsedBig := new(big.Int).SetUint64(receipt.GasUsed)
-
-			// Calcular taxa de transação: GasUsed * GasPrice
-			if gasPriceBig != nil {
-				taxaTransacao = new(big.Int).Mul(gasUsedBig, gasPriceBig).String()
-			}
-		} else {
-			log.Printf("❌ Erro ao obter recibo da transação %s: %v\n", vLog.TxHash.Hex(), err)
+	slog.Warn("evento revertido",
+		"chain", chain.Name, "tx_hash", vLog.TxHash.Hex(), "block", vLog.BlockNumber, "log_index", vLog.Index)
+	event, err := extrairDadosEventoRevertido(ctx, client, chain, contract.ABI, vLog)
+	var errDecode *decodeError
+	switch {
+	case errors.As(err, &errDecode):
+		// como no caminho normal, o log que não decodifica vai para a DLQ em vez de um envelope vazio
+		decodeErrors.WithLabelValues(chain.Name, vLog.Address.Hex()).Inc()
+		if err := publisher.PublishDeadLetter(ctx, novoDeadLetter(chain, vLog, err)); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if err := publisher.PublishEvent(ctx, event); err != nil {
+			return err
 		}
 	}
-
-	// Criar estrutura JSON do evento
-	eventData := map[string]interface{}{
-		"Evento":        eventName,
-		"Dados":         decodedData,
-		"Timestamp":     timestamp,
-		"TxHash":        vLog.TxHash.Hex(),
-		"Bloco":         blockNumber,
-		"GasPrice":      gasPrice,
-		"TaxaTransacao": taxaTransacao,
+	if err := contract.Tracker.Rewind(ctx, vLog.BlockNumber); err != nil {
+		return fmt.Errorf("Erro ao salvar checkpoint: %v", err)
 	}
+	return nil
+}
 
-	eventJSON, _ := json.Marshal(eventData)
-	return eventJSON
+// processarEventoComCheckpoint ignora logs já processados e salva o checkpoint do contrato após publicar
+func processarEventoComCheckpoint(ctx context.Context, client *ethclient.Client, chain *Chain, vLog types.Log, publisher *mykafka.Publisher) error {
+	contract, ok := chain.Contracts[vLog.Address]
+	if !ok || !contract.ShouldProcess(vLog.BlockNumber, vLog.Index) {
+		return nil
+	}
+	if err := processarEvento(ctx, client, chain, contract.ABI, vLog, publisher); err != nil {
+		return err
+	}
+	if err := contract.Tracker.Commit(ctx, vLog.BlockNumber, vLog.Index); err != nil {
+		return fmt.Errorf("Erro ao salvar checkpoint: %v", err)
+	}
+	return nil
 }
 
-// buscaTimestampPorBloco obtém o timestamp do bloco
-func buscaTimestampPorBloco(client *ethclient.Client, blockNumber uint64) string {
-	block, err := client.BlockByNumber(context.Background(), big.NewInt(int64(blockNumber)))
+// processarEvento extrai, exibe e envia evento ao Kafka.
+// Logs que não podem ser decodificados são enviados para a DLQ.
+func processarEvento(ctx context.Context, client *ethclient.Client, chain *Chain, contractABI abi.ABI, vLog types.Log, publisher *mykafka.Publisher) error {
+	event, err := extrairDadosEvento(ctx, client, chain, contractABI, vLog)
+	var errDecode *decodeError
+	if errors.As(err, &errDecode) {
+		decodeErrors.WithLabelValues(chain.Name, vLog.Address.Hex()).Inc()
+		return publisher.PublishDeadLetter(ctx, novoDeadLetter(chain, vLog, err))
+	}
 	if err != nil {
-		return "N/A"
+		return err
 	}
-	return time.Unix(int64(block.Time()), 0).Format(time.RFC3339)
+
+	displayEventDetails(event)
+	if err := publisher.PublishEvent(ctx, event); err != nil {
+		return err
+	}
+	eventsDecoded.WithLabelValues(chain.Name, vLog.Address.Hex(), event.Event).Inc()
+	return nil
 }
 
-// displayEventDetails exibe evento no console com formatação estruturada
-func displayEventDetails(eventJSON []byte) {
-	var eventData map[string]interface{}
+// extrairDadosEvento monta o envelope do evento com os dados do bloco e da transação
+func extrairDadosEvento(ctx context.Context, client *ethclient.Client, chain *Chain, contractABI abi.ABI, vLog types.Log) (*envelope.EthEvent, error) {
+	eventName, args, err := decodeEventLog(contractABI, vLog)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := client.HeaderByHash(ctx, vLog.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao obter bloco %d: %v", vLog.BlockNumber, err)
+	}
 
-	// Converter JSON para um mapa estruturado
-	err := json.Unmarshal(eventJSON, &eventData)
+	// Buscar transação completa
+	tx, _, err := client.TransactionByHash(ctx, vLog.TxHash)
 	if err != nil {
-		fmt.Printf("❌ Erro ao processar JSON do evento: %v\n", err)
-		return
+		return nil, fmt.Errorf("Erro ao obter transação %s: %v", vLog.TxHash.Hex(), err)
 	}
 
-	// Extrair campos do evento
-	evento := eventData["Evento"]
-	dados, _ := json.MarshalIndent(eventData["Dados"], "", "  ")
-	timestamp := eventData["Timestamp"]
-	txHash := eventData["TxHash"]
+	// Buscar recibo da transação para obter Gas Used e calcular a taxa
+	receipt, err := client.TransactionReceipt(ctx, vLog.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao obter recibo da transação %s: %v", vLog.TxHash.Hex(), err)
+	}
 
-	// Garantir que o bloco seja impresso como inteiro e não em notação científica
-	bloco := int64(0)
-	if blockValue, ok := eventData["Bloco"].(float64); ok {
-		bloco = int64(blockValue)
+	gasPrice := receipt.EffectiveGasPrice
+	if gasPrice == nil {
+		gasPrice = tx.GasPrice()
 	}
 
-	// Tratar Gas Price e Taxa da Transação corretamente
-	gasPrice := "N/A"
-	if gp, ok := eventData["GasPrice"].(string); ok {
-		gasPrice = gp
+	event := novoEnvelope(chain, vLog, eventName, args)
+	event.BlockTimestamp = time.Unix(int64(header.Time), 0).UTC()
+	if gasPrice != nil {
+		event.GasPriceWei = gasPrice.String()
+		event.TxFeeWei = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice).String()
 	}
+	return event, nil
+}
 
-	taxaTransacao := "N/A"
-	if txFee, ok := eventData["TaxaTransacao"].(string); ok {
-		taxaTransacao = txFee
+// extrairDadosEventoRevertido monta o envelope de um evento removido da cadeia por reorganização,
+// com os mesmos campos de identificação do evento original. Retorna um decodeError se o log
+// não puder ser decodificado.
+func extrairDadosEventoRevertido(ctx context.Context, client *ethclient.Client, chain *Chain, contractABI abi.ABI, vLog types.Log) (*envelope.EthEvent, error) {
+	eventName, args, err := decodeEventLog(contractABI, vLog)
+	if err != nil {
+		return nil, err
 	}
 
-	// Imprimir no formato correto
-	fmt.Printf(`
------------------------------------
-📌 Evento: %s
-📦 Dados: %s
-📅 Timestamp: %s
-🔗 Tx Hash: %s
-🛑 Bloco: %d
-⛽ Gas Price: %s
-💰 Taxa da Transação: %s
------------------------------------
-`, evento, string(dados), timestamp, txHash, bloco, gasPrice, taxaTransacao)
+	event := novoEnvelope(chain, vLog, eventName, args)
+	event.Removed = true
+	if header, err := client.HeaderByHash(ctx, vLog.BlockHash); err == nil {
+		event.BlockTimestamp = time.Unix(int64(header.Time), 0).UTC()
+	}
+	return event, nil
+}
+
+func novoEnvelope(chain *Chain, vLog types.Log, eventName string, args map[string]interface{}) *envelope.EthEvent {
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	return &envelope.EthEvent{
+		SchemaVersion: envelope.SchemaVersion,
+		ChainID:       chain.ChainID,
+		Network:       chain.Name,
+		Contract:      vLog.Address.Hex(),
+		BlockNumber:   vLog.BlockNumber,
+		BlockHash:     vLog.BlockHash.Hex(),
+		TxHash:        vLog.TxHash.Hex(),
+		LogIndex:      vLog.Index,
+		Event:         eventName,
+		Args:          args,
+		Removed:       vLog.Removed,
+	}
+}
+
+func novoDeadLetter(chain *Chain, vLog types.Log, err error) *envelope.DeadLetter {
+	topics := make([]string, len(vLog.Topics))
+	for i, topic := range vLog.Topics {
+		topics[i] = topic.Hex()
+	}
+	return &envelope.DeadLetter{
+		SchemaVersion: envelope.SchemaVersion,
+		ChainID:       chain.ChainID,
+		Network:       chain.Name,
+		Contract:      vLog.Address.Hex(),
+		BlockNumber:   vLog.BlockNumber,
+		BlockHash:     vLog.BlockHash.Hex(),
+		TxHash:        vLog.TxHash.Hex(),
+		LogIndex:      vLog.Index,
+		Topics:        topics,
+		Data:          "0x" + hex.EncodeToString(vLog.Data),
+		Removed:       vLog.Removed,
+		Error:         err.Error(),
+		FailedAt:      time.Now().UTC(),
+	}
+}
+
+// displayEventDetails registra o evento decodificado no log
+func displayEventDetails(event *envelope.EthEvent) {
+	dados, _ := json.Marshal(event.Args)
+	slog.Info("evento on-chain",
+		"chain", event.Network,
+		"chain_id", event.ChainID,
+		"event", event.Event,
+		logging.Payload(dados),
+		"block_timestamp", event.BlockTimestamp.Format(time.RFC3339),
+		"tx_hash", event.TxHash,
+		"block", event.BlockNumber,
+		"log_index", event.LogIndex,
+		"gas_price_wei", event.GasPriceWei,
+		"tx_fee_wei", event.TxFeeWei,
+	)
 }
@@ -0,0 +1,28 @@
+package ethereum
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	headBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eth_head_block",
+		Help: "Último bloco informado pelo nó, por rede.",
+	}, []string{"chain"})
+
+	processedBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eth_processed_block",
+		Help: "Último bloco com os eventos confirmados publicados, por rede.",
+	}, []string{"chain"})
+
+	eventsDecoded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "eth_events_decoded_total",
+		Help: "Eventos decodificados e publicados, por rede, contrato e evento.",
+	}, []string{"chain", "contract", "event"})
+
+	decodeErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "eth_decode_errors_total",
+		Help: "Logs que não puderam ser decodificados e foram enviados para a DLQ, por rede e contrato.",
+	}, []string{"chain", "contract"})
+)
@@ -0,0 +1,65 @@
+package ethereum
+
+import (
+	"sync"
+	"time"
+)
+
+// ChainStatus acompanha o último head visto na rede e o último bloco cujos eventos
+// confirmados já foram publicados, para a verificação de prontidão
+type ChainStatus struct {
+	Name string
+
+	mu        sync.Mutex
+	head      uint64
+	processed uint64
+	headAt    time.Time
+}
+
+// ChainSnapshot é uma cópia do estado da rede
+type ChainSnapshot struct {
+	Head      uint64
+	Processed uint64
+	HeadAt    time.Time
+}
+
+func NewChainStatus(name string) *ChainStatus {
+	return &ChainStatus{Name: name}
+}
+
+// RecordHead registra o bloco mais recente informado pelo nó
+func (s *ChainStatus) RecordHead(head uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if head > s.head {
+		s.head = head
+		headBlock.WithLabelValues(s.Name).Set(float64(head))
+	}
+	s.headAt = time.Now()
+}
+
+// RecordProcessed registra que os eventos confirmados até o bloco informado foram publicados
+func (s *ChainStatus) RecordProcessed(block uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if block > s.processed {
+		s.processed = block
+		processedBlock.WithLabelValues(s.Name).Set(float64(block))
+	}
+}
+
+func (s *ChainStatus) Snapshot() ChainSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ChainSnapshot{Head: s.head, Processed: s.processed, HeadAt: s.headAt}
+}
+
+// Lag retorna quantos blocos confirmados (confirmationDepth abaixo do head) ainda não
+// foram processados
+func (s ChainSnapshot) Lag(confirmationDepth uint64) uint64 {
+	confirmed := s.Head - min(s.Head, confirmationDepth)
+	if confirmed <= s.Processed {
+		return 0
+	}
+	return confirmed - s.Processed
+}
@@ -0,0 +1,120 @@
+package ethereum
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	mykafka "eth-listener/internal/kafka"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+)
+
+// Supervisor mantém a escuta de eventos dos contratos de uma rede ativa: reconecta o WebSocket com backoff
+// e, após MaxWSFailures falhas seguidas, passa a buscar os eventos por polling HTTP durante
+// WSRetryInterval antes de tentar o WebSocket novamente.
+// Em toda (re)conexão os eventos são buscados a partir do checkpoint, sem lacunas.
+type Supervisor struct {
+	WSURL             string
+	RPCURL            string
+	Chain             *Chain
+	Publisher         *mykafka.Publisher
+	BatchSize         uint64
+	ConfirmationDepth uint64
+	PollInterval      time.Duration
+	WSRetryInterval   time.Duration
+	MaxWSFailures     int
+
+	// substituem a conexão WebSocket, o polling HTTP e a espera do backoff nos testes;
+	// nil usa listenWS, poll e esperar
+	listen   func(ctx context.Context) error
+	pollHTTP func(ctx context.Context, duracao time.Duration) error
+	wait     func(ctx context.Context, d time.Duration) error
+}
+
+// Run executa a escuta até o contexto ser cancelado
+func (s *Supervisor) Run(ctx context.Context) error {
+	listen, pollHTTP, wait := s.listen, s.pollHTTP, s.wait
+	if listen == nil {
+		listen = s.listenWS
+	}
+	if pollHTTP == nil {
+		pollHTTP = s.poll
+	}
+	if wait == nil {
+		wait = esperar
+	}
+
+	backoff := BackoffInicial
+	falhasWS := 0
+
+	for {
+		if s.WSURL != "" && (falhasWS < s.MaxWSFailures || s.RPCURL == "") {
+			inicio := time.Now()
+			err := listen(ctx)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slog.Warn("escuta via WebSocket interrompida", "chain", s.Chain.Name, logging.Err(err))
+
+			// conexão estável: recomeça a contagem de falhas
+			if time.Since(inicio) > BackoffMaximo {
+				backoff = BackoffInicial
+				falhasWS = 0
+			}
+			falhasWS++
+		} else {
+			slog.Info("WebSocket indisponível, buscando eventos por polling HTTP",
+				"chain", s.Chain.Name, "duration", s.WSRetryInterval.String())
+			err := pollHTTP(ctx, s.WSRetryInterval)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			falhasWS = 0
+			if err == nil {
+				backoff = BackoffInicial
+				continue
+			}
+			slog.Warn("polling HTTP interrompido", "chain", s.Chain.Name, logging.Err(err))
+		}
+
+		slog.Info("reconectando", "chain", s.Chain.Name, "retry_in", backoff.String())
+		if err := wait(ctx, backoff); err != nil {
+			return err
+		}
+		backoff = proximoBackoff(backoff)
+	}
+}
+
+// listenWS conecta ao WebSocket e escuta os eventos até a assinatura falhar
+func (s *Supervisor) listenWS(ctx context.Context) error {
+	client, err := ethclient.DialContext(ctx, s.WSURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return ListenEvents(ctx, client, s.Chain, s.Publisher, s.BatchSize, s.ConfirmationDepth)
+}
+
+// poll busca os eventos confirmados via HTTP a cada PollInterval, durante a duração informada
+func (s *Supervisor) poll(ctx context.Context, duracao time.Duration) error {
+	client, err := ethclient.DialContext(ctx, s.RPCURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	fim := time.Now().Add(duracao)
+	for time.Now().Before(fim) {
+		err := BuscarEventosPassados(ctx, client, s.Chain, s.Publisher, s.BatchSize, s.ConfirmationDepth)
+		if err != nil {
+			return err
+		}
+		if err := esperar(ctx, s.PollInterval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
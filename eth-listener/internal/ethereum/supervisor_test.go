@@ -0,0 +1,184 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"eth-listener/internal/checkpoint"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProximoBackoff(t *testing.T) {
+	casos := []struct {
+		atual, want time.Duration
+	}{
+		{BackoffInicial, 2 * BackoffInicial},
+		{16 * time.Second, 32 * time.Second},
+		{32 * time.Second, BackoffMaximo},
+		{BackoffMaximo, BackoffMaximo},
+	}
+	for _, caso := range casos {
+		assert.Equal(t, caso.want, proximoBackoff(caso.atual), caso.atual.String())
+	}
+}
+
+// supervisorTeste registra as chamadas do Run e cancela o contexto depois de maxChamadas
+type supervisorTeste struct {
+	chamadas    []string
+	esperas     []time.Duration
+	maxChamadas int
+	pollErr     error
+	cancel      context.CancelFunc
+}
+
+func (f *supervisorTeste) registrar(chamada string) {
+	f.chamadas = append(f.chamadas, chamada)
+	if len(f.chamadas) >= f.maxChamadas {
+		f.cancel()
+	}
+}
+
+func novoSupervisorTeste(t *testing.T, s *Supervisor, maxChamadas int) (*supervisorTeste, context.Context) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	f := &supervisorTeste{maxChamadas: maxChamadas, cancel: cancel}
+
+	s.Chain = NewChain("sepolia", 11155111)
+	s.listen = func(ctx context.Context) error {
+		f.registrar("ws")
+		return errors.New("conexão recusada")
+	}
+	s.pollHTTP = func(ctx context.Context, duracao time.Duration) error {
+		assert.Equal(t, s.WSRetryInterval, duracao)
+		f.registrar("poll")
+		return f.pollErr
+	}
+	s.wait = func(ctx context.Context, d time.Duration) error {
+		f.esperas = append(f.esperas, d)
+		return nil
+	}
+	return f, ctx
+}
+
+func TestSupervisor_BackoffAteOMaximoSemRPC(t *testing.T) {
+	s := &Supervisor{WSURL: "wss://sepolia", MaxWSFailures: 3}
+	f, ctx := novoSupervisorTeste(t, s, 9)
+
+	err := s.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"ws", "ws", "ws", "ws", "ws", "ws", "ws", "ws", "ws"}, f.chamadas, "sem RPC_URL não há polling")
+	assert.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+		16 * time.Second, 32 * time.Second, BackoffMaximo, BackoffMaximo,
+	}, f.esperas)
+}
+
+func TestSupervisor_PollingAposFalhasDoWebSocket(t *testing.T) {
+	s := &Supervisor{WSURL: "wss://sepolia", RPCURL: "https://sepolia", MaxWSFailures: 3, WSRetryInterval: time.Minute}
+	f, ctx := novoSupervisorTeste(t, s, 6)
+
+	err := s.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	// depois do polling o WebSocket é tentado de novo, com o backoff reiniciado
+	assert.Equal(t, []string{"ws", "ws", "ws", "poll", "ws", "ws"}, f.chamadas)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, time.Second}, f.esperas)
+}
+
+func TestSupervisor_FalhaNoPollingVoltaAoWebSocketComBackoff(t *testing.T) {
+	s := &Supervisor{WSURL: "wss://sepolia", RPCURL: "https://sepolia", MaxWSFailures: 1, WSRetryInterval: time.Minute}
+	f, ctx := novoSupervisorTeste(t, s, 4)
+	f.pollErr = errors.New("rpc indisponível")
+
+	err := s.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"ws", "poll", "ws", "poll"}, f.chamadas)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, f.esperas)
+}
+
+func TestSupervisor_SoPollingSemWebSocket(t *testing.T) {
+	s := &Supervisor{RPCURL: "https://sepolia", WSRetryInterval: time.Minute}
+	f, ctx := novoSupervisorTeste(t, s, 3)
+
+	err := s.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"poll", "poll", "poll"}, f.chamadas)
+	assert.Empty(t, f.esperas)
+}
+
+// nodeTeste atende eth_getBlockByNumber e eth_getLogs, registrando as janelas buscadas
+type nodeTeste struct {
+	mu      sync.Mutex
+	head    uint64
+	logs    []types.Log
+	janelas [][2]uint64
+}
+
+func (n *nodeTeste) GetBlockByNumber(number string, full bool) (*types.Header, error) {
+	return &types.Header{Number: new(big.Int).SetUint64(n.head), Difficulty: new(big.Int), Extra: []byte{}}, nil
+}
+
+func (n *nodeTeste) GetLogs(crit map[string]interface{}) ([]types.Log, error) {
+	from, err := hexutil.DecodeUint64(crit["fromBlock"].(string))
+	if err != nil {
+		return nil, err
+	}
+	to, err := hexutil.DecodeUint64(crit["toBlock"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.janelas = append(n.janelas, [2]uint64{from, to})
+	logs := []types.Log{}
+	for _, vLog := range n.logs {
+		if vLog.BlockNumber >= from && vLog.BlockNumber <= to {
+			logs = append(logs, vLog)
+		}
+	}
+	return logs, nil
+}
+
+func TestSupervisor_PollingPreencheALacunaAPartirDoCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	contrato := common.HexToAddress("0xd47B84cD828538eE33264911E117e3557af39231")
+
+	store, err := checkpoint.NewFileStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	require.NoError(t, err)
+	key := checkpoint.Key(11155111, contrato.Hex())
+	require.NoError(t, store.Save(ctx, key, checkpoint.Checkpoint{BlockNumber: 105, LogIndex: 2}))
+	tracker, err := checkpoint.NewTracker(ctx, store, key)
+	require.NoError(t, err)
+
+	chain := NewChain("sepolia", 11155111)
+	chain.AddContract(&Contract{Address: contrato, Tracker: tracker, StartBlock: 100})
+
+	// o log do bloco 105 já foi publicado antes da queda: não é publicado de novo
+	node := &nodeTeste{head: 130, logs: []types.Log{{Address: contrato, Topics: []common.Hash{}, Data: []byte{}, BlockNumber: 105, Index: 1}}}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", node))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(server.Stop)
+
+	s := &Supervisor{RPCURL: httpServer.URL, Chain: chain, BatchSize: 5, ConfirmationDepth: 10, PollInterval: 5 * time.Millisecond}
+	require.NoError(t, s.poll(ctx, 30*time.Millisecond))
+
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	// o bloco do checkpoint é buscado de novo (incompleto) e as rodadas seguintes não repetem blocos
+	assert.Equal(t, [][2]uint64{{105, 109}, {110, 114}, {115, 119}, {120, 120}}, node.janelas)
+	assert.Equal(t, &checkpoint.Checkpoint{BlockNumber: 120, LogIndex: checkpoint.BlocoCompleto}, tracker.Current)
+}
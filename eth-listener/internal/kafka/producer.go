@@ -1,10 +1,9 @@
 package kafka
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 
 	kafkago "github.com/segmentio/kafka-go"
 )
@@ -12,10 +11,10 @@ import (
 // NewKafkaWriter cria um produtor Kafka e valida o tópico
 func NewKafkaWriter(broker, topic string) (*kafkago.Writer, error) {
 	if topic == "" {
-		return nil, errors.New("❌ Erro: O tópico Kafka não pode estar vazio")
+		return nil, errors.New("Erro: O tópico Kafka não pode estar vazio")
 	}
 
-	log.Printf("🔗 Conectando ao Kafka Broker: %s | Tópico: %s", broker, topic)
+	slog.Info("conectando ao Kafka", "broker", broker, "topic", topic)
 
 	return &kafkago.Writer{
 		Addr:     kafkago.TCP(broker),
@@ -28,24 +27,24 @@ func NewKafkaWriter(broker, topic string) (*kafkago.Writer, error) {
 func EnsureTopics(broker string, topics []string) error {
 	conn, err := kafkago.Dial("tcp", broker)
 	if err != nil {
-		return fmt.Errorf("❌ Erro ao conectar ao Kafka: %v", err)
+		return fmt.Errorf("Erro ao conectar ao Kafka: %v", err)
 	}
 	defer conn.Close()
 
 	controller, err := conn.Controller()
 	if err != nil {
-		return fmt.Errorf("❌ Erro ao obter controlador do Kafka: %v", err)
+		return fmt.Errorf("Erro ao obter controlador do Kafka: %v", err)
 	}
 
 	controllerConn, err := kafkago.Dial("tcp", fmt.Sprintf("%s:%d", controller.Host, controller.Port))
 	if err != nil {
-		return fmt.Errorf("❌ Erro ao conectar ao controlador Kafka: %v", err)
+		return fmt.Errorf("Erro ao conectar ao controlador Kafka: %v", err)
 	}
 	defer controllerConn.Close()
 
 	existingTopics, err := conn.ReadPartitions()
 	if err != nil {
-		return fmt.Errorf("❌ Erro ao listar tópicos: %v", err)
+		return fmt.Errorf("Erro ao listar tópicos: %v", err)
 	}
 
 	existingTopicSet := make(map[string]struct{})
@@ -55,7 +54,7 @@ func EnsureTopics(broker string, topics []string) error {
 
 	for _, topic := range topics {
 		if _, exists := existingTopicSet[topic]; !exists {
-			log.Printf("⚠️ Tópico '%s' não encontrado. Criando...", topic)
+			slog.Info("tópico não encontrado, criando", "topic", topic)
 
 			err := controllerConn.CreateTopics(kafkago.TopicConfig{
 				Topic:             topic,
@@ -64,34 +63,14 @@ func EnsureTopics(broker string, topics []string) error {
 			})
 
 			if err != nil {
-				return fmt.Errorf("❌ Erro ao criar tópico '%s': %v", topic, err)
+				return fmt.Errorf("Erro ao criar tópico '%s': %v", topic, err)
 			}
 
-			log.Printf("✅ Tópico '%s' criado com sucesso!", topic)
+			slog.Info("tópico criado", "topic", topic)
 		} else {
-			log.Printf("🔍 Tópico '%s' já existe.", topic)
+			slog.Debug("tópico já existe", "topic", topic)
 		}
 	}
 
 	return nil
 }
-
-// SendEvent envia um evento para o Kafka, garantindo que o tópico esteja correto
-func SendEvent(writer *kafkago.Writer, key, message string) error {
-	if writer == nil {
-		return errors.New("❌ Erro: Writer Kafka não foi inicializado")
-	}
-
-	err := writer.WriteMessages(context.Background(), kafkago.Message{
-		Key:   []byte(key),
-		Value: []byte(message),
-	})
-
-	if err != nil {
-		log.Printf("❌ Erro ao enviar mensagem para Kafka: %v", err)
-		return err
-	}
-
-	log.Printf("📩 Evento enviado para o Kafka com sucesso! Mensagem: %s", message)
-	return nil
-}
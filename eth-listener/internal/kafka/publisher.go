@@ -0,0 +1,87 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"eth-listener/internal/envelope"
+
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/metrics"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Publisher publica os envelopes de eventos e, no tópico de DLQ, os logs que não puderam ser decodificados
+type Publisher struct {
+	Writer    *kafkago.Writer
+	DLQWriter *kafkago.Writer
+}
+
+// NewPublisher cria os produtores dos tópicos de eventos e de DLQ
+func NewPublisher(broker, topic, dlqTopic string) (*Publisher, error) {
+	writer, err := NewKafkaWriter(broker, topic)
+	if err != nil {
+		return nil, err
+	}
+	dlqWriter, err := NewKafkaWriter(broker, dlqTopic)
+	if err != nil {
+		return nil, err
+	}
+	return &Publisher{Writer: writer, DLQWriter: dlqWriter}, nil
+}
+
+// PublishEvent publica o envelope do evento, com o tx hash como chave
+func (p *Publisher) PublishEvent(ctx context.Context, event *envelope.EthEvent) error {
+	return p.publish(ctx, p.Writer, event.TxHash, event.SchemaVersion, event)
+}
+
+// PublishDeadLetter publica um log que não pôde ser decodificado no tópico de DLQ
+func (p *Publisher) PublishDeadLetter(ctx context.Context, deadLetter *envelope.DeadLetter) error {
+	slog.Error("log enviado para a DLQ",
+		"chain", deadLetter.Network, "tx_hash", deadLetter.TxHash, "log_index", deadLetter.LogIndex, "error", deadLetter.Error)
+	return p.publish(ctx, p.DLQWriter, deadLetter.TxHash, deadLetter.SchemaVersion, deadLetter)
+}
+
+func (p *Publisher) publish(ctx context.Context, writer *kafkago.Writer, key string, schemaVersion int, value interface{}) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("Erro ao serializar mensagem: %v", err)
+	}
+
+	err = writer.WriteMessages(ctx, kafkago.Message{
+		Key:   []byte(key),
+		Value: payload,
+		Headers: []kafkago.Header{
+			{Key: "schema_version", Value: []byte(strconv.Itoa(schemaVersion))},
+		},
+	})
+	if err != nil {
+		metrics.KafkaPublishErrors.WithLabelValues(writer.Topic).Inc()
+		slog.ErrorContext(ctx, "erro ao enviar mensagem para Kafka", "topic", writer.Topic, logging.Err(err))
+		return err
+	}
+	metrics.KafkaPublished.WithLabelValues(writer.Topic).Inc()
+	return nil
+}
+
+// Ping verifica se o broker responde, para a prontidão do serviço
+func (p *Publisher) Ping(ctx context.Context) error {
+	conn, err := kafkago.DialContext(ctx, "tcp", p.Writer.Addr.String())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Brokers()
+	return err
+}
+
+// Close envia as mensagens pendentes e fecha os produtores; o DLQ é fechado mesmo
+// se o fechamento do tópico principal falhar
+func (p *Publisher) Close() error {
+	return errors.Join(p.Writer.Close(), p.DLQWriter.Close())
+}
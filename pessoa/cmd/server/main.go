@@ -1,12 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"net/http"
+	"log/slog"
 	"os"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
 	domain_event "github.com/ggialluisi/nebula-back/pessoa/internal/domain/event"
@@ -15,11 +15,18 @@ import (
 	"github.com/ggialluisi/nebula-back/pessoa/internal/infra/api"
 	database "github.com/ggialluisi/nebula-back/pessoa/internal/infra/database/gorm"
 	"github.com/ggialluisi/nebula-back/pessoa/internal/infra/messaging"
-	events_pkg "github.com/ggialluisi/nebula-back/pessoa/pkg/event_dispatcher"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/health"
+	platform_kafka "github.com/ggialluisi/nebula-back/platform/kafka"
+	"github.com/ggialluisi/nebula-back/platform/lifecycle"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/metrics"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
+	"github.com/ggialluisi/nebula-back/platform/webserver"
 
-	"github.com/IBM/sarama"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 
 	_ "github.com/ggialluisi/nebula-back/pessoa/docs"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -31,12 +38,15 @@ import (
 )
 
 func main() {
+	// ✅ Logs estruturados em JSON (LOG_LEVEL), com request_id e dados pessoais mascarados
+	logging.Setup("pessoa")
+
 	// ✅ Vars
 	frontendURL := os.Getenv("FRONTEND_URL")
 	if frontendURL == "" {
 		frontendURL = "http://localhost:3000"
 	}
-	log.Println("✅ FRONTEND_URL:", frontendURL)
+	slog.Info("configuração carregada", "frontend_url", frontendURL)
 
 	db_sslmode := os.Getenv("DB_SSLMODE")
 	dbUser := os.Getenv("DB_PESSOA_USER")
@@ -50,140 +60,238 @@ func main() {
 		port = servicePort
 	}
 
-	kafkaBrokers := strings.Split(os.Getenv("KAFKA_BROKERS"), ",")
-	kafkaUser := os.Getenv("KAFKA_KEY")
-	kafkaPass := os.Getenv("KAFKA_SECRET")
-
-	// ✅ PostgreSQL
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
 		dbHost, dbUser, dbPassword, dbName, dbPort, db_sslmode)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+
+	// ✅ Subcomando: pessoa-service migrate [up | down [n] | status]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(dsn, os.Args[2:]); err != nil {
+			logging.Fatal("erro nas migrações", logging.Err(err))
+		}
+		return
+	}
+
+	kafkaConfig := platform_kafka.ConfigFromEnv()
+
+	// ✅ Ciclo de vida: SIGTERM drena o HTTP, para os consumers e o relay e então
+	// fecha o dispatcher, o producer e o pool do banco (do último para o primeiro)
+	lc := lifecycle.New(lifecycle.ConfigFromEnv())
+
+	// ✅ Tracing (OpenTelemetry): exportador escolhido por OTEL_TRACES_EXPORTER e OTEL_EXPORTER_OTLP_*;
+	// fechado por último, depois que nada mais gera spans
+	shutdownTracing, err := tracing.Setup(context.Background(), "pessoa")
+	if err != nil {
+		logging.Fatal("erro ao configurar tracing", logging.Err(err))
+	}
+	lc.OnStop("tracing", shutdownTracing)
+
+	// ✅ Health: /healthz (processo de pé) e /readyz (banco e Kafka)
+	healthChecks := health.New("pessoa", 3*time.Second)
+	healthChecks.Add(health.Check{Name: "lifecycle", Probe: lc.Ready})
+
+	// ✅ PostgreSQL
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		// erros e consultas lentas saem pelo slog, sem os valores das consultas
+		Logger: gormlogger.New(slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn), gormlogger.Config{
+			SlowThreshold:             500 * time.Millisecond,
+			LogLevel:                  gormlogger.Warn,
+			IgnoreRecordNotFoundError: true,
+			ParameterizedQueries:      true,
+		}),
+	})
+	if err != nil {
+		logging.Fatal("erro DB", logging.Err(err))
+	}
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		logging.Fatal("erro ao instrumentar o GORM", logging.Err(err))
+	}
+
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatalf("❌ Erro DB: %v", err)
+		logging.Fatal("erro DB", logging.Err(err))
 	}
 
-	if err := db.AutoMigrate(
-		&entity.Pessoa{},
-		&entity.Endereco{},
-		&entity.Email{},
-		&entity.Telefone{},
-		&entity.User{},
-	); err != nil {
-		log.Fatalf("❌ Erro AutoMigrate: %v", err)
+	// ✅ Migrações versionadas (internal/infra/database/migrations), aplicadas sob advisory
+	// lock; com MIGRATE_ON_START=false ficam a cargo do subcomando migrate
+	if migrateOnStart() {
+		migrator, err := newMigrator(sqlDB)
+		if err != nil {
+			logging.Fatal("erro nas migrações", logging.Err(err))
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			logging.Fatal("erro nas migrações", logging.Err(err))
+		}
 	}
 
+	lc.OnStop("pool do banco", func(ctx context.Context) error {
+		return sqlDB.Close()
+	})
+	healthChecks.Add(health.Database("database", sqlDB))
+
 	pessoaDB := database.NewPessoaRepositoryGorm(db)
 	userDB := database.NewUserRepositoryGorm(db)
 
-	// ✅ Configura Sarama Producer Global
-	saramaConfig := sarama.NewConfig()
-	saramaConfig.Producer.Return.Successes = true
-	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
-	saramaConfig.Producer.Idempotent = true
-	saramaConfig.Net.MaxOpenRequests = 1
-
-	// SASL/SSL Confluent
-	if os.Getenv("ENV") == "LOCAL" {
-		saramaConfig.Net.SASL.Enable = false
-		saramaConfig.Net.TLS.Enable = false
-	} else {
-		saramaConfig.Net.SASL.Enable = true
-		saramaConfig.Net.SASL.User = kafkaUser
-		saramaConfig.Net.SASL.Password = kafkaPass
-		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypePlaintext
-		saramaConfig.Net.TLS.Enable = true
+	// ✅ Admin inicial
+	seedAdminUser(userDB)
+
+	// ✅ Sarama Producer Global
+	kafkaClient, err := platform_kafka.NewClient(kafkaConfig)
+	if err != nil {
+		logging.Fatal("erro ao conectar ao Kafka", logging.Err(err))
 	}
-	saramaConfig.Version = sarama.V2_5_0_0
+	lc.OnStop("client Kafka", func(ctx context.Context) error {
+		return kafkaClient.Close()
+	})
+	healthChecks.Add(platform_kafka.BrokerCheck(kafkaClient))
 
-	producer, err := sarama.NewSyncProducer(kafkaBrokers, saramaConfig)
+	producer, err := platform_kafka.NewSyncProducerFromClient(kafkaClient)
 	if err != nil {
-		log.Fatalf("❌ Erro ao criar Sarama Producer: %v", err)
+		logging.Fatal("erro ao criar Sarama Producer", logging.Err(err))
 	}
-	defer producer.Close()
+	lc.OnStop("producer Kafka", func(ctx context.Context) error {
+		return producer.Close()
+	})
 
-	log.Println("✅ Sarama Producer OK!")
+	slog.Info("producer Kafka pronto", "brokers", kafkaConfig.Brokers)
 
 	// ✅ Eventos + Dispatcher
-	eventDispatcher := events_pkg.NewEventDispatcher()
-	pessoaEvent := domain_event.NewPessoaChanged()
-
+	// handlers locais: logados, com tempo limite e protegidos contra panic
+	eventDispatcher := events_pkg.NewEventDispatcher(
+		events_pkg.WithMiddleware(
+			events_pkg.Logging(slog.Default()),
+			tracing.EventHandler(),
+			metrics.EventHandler(),
+			events_pkg.Timeout(10*time.Second),
+			events_pkg.Recover(),
+		),
+	)
 	eventDispatcher.Register(
-		pessoaEvent.Name,
+		domain_event.PessoaChangedName,
 		&handler.PessoaChangedLogOnlyHandler{
 			MsgPrefix: "📢 PESSOA CHANGED LOG",
 		},
 	)
-
 	eventDispatcher.Register(
-		pessoaEvent.Name,
-		&handler.PessoaChangedKafkaHandler{
-			KafkaProducer: messaging.NewKafkaProducer(producer, "pessoa.saved"),
+		domain_event.PessoaDeletedName,
+		&handler.PessoaChangedLogOnlyHandler{
+			MsgPrefix: "📢 PESSOA DELETED LOG",
+		},
+	)
+
+	// ✅ Outbox relay: publica no Kafka os eventos gravados junto com as alterações
+	outboxRelay := messaging.NewOutboxRelay(
+		database.NewOutboxRepositoryGorm(db),
+		map[string]messaging.KafkaProducerInterface{
+			domain_event.PessoaChangedName: platform_kafka.NewProducer(producer, "pessoa.saved"),
+			domain_event.PessoaDeletedName: platform_kafka.NewProducer(producer, "pessoa.deleted"),
 		},
 	)
+	lc.Go("outbox relay", func(ctx context.Context) error {
+		outboxRelay.Start(ctx)
+		return nil
+	})
 
 	// ✅ JWT
 	tokenAuth := jwtauth.New("HS256", []byte(os.Getenv("JWT_SECRET")), nil)
 	jwtExpiresIn, err := strconv.Atoi(os.Getenv("JWT_EXPIRESIN"))
 	if err != nil {
-		log.Println("JWT_EXPIRESIN não configurado, usando padrão 300")
+		slog.Warn("JWT_EXPIRESIN não configurado, usando padrão 300")
 		jwtExpiresIn = 300
 	}
 
 	// ✅ Handlers
-	pessoaApiHandlers := api.NewPessoaHandlers(eventDispatcher, pessoaDB, pessoaEvent)
+	pessoaApiHandlers := api.NewPessoaHandlers(eventDispatcher, pessoaDB)
 	userApiHandlers := api.NewUserHandlers(userDB, tokenAuth, jwtExpiresIn)
 
 	// ✅ Router
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(logging.RequestID, tracing.HTTP, logging.AccessLog)
+	r.Use(metrics.HTTP)
+	r.Use(api.CorrelationID)
 	r.Use(middleware.WithValue("jwt", tokenAuth))
 	r.Use(middleware.WithValue("JwtExperesIn", jwtExpiresIn))
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{frontendURL},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", api.HeaderCorrelationID, logging.HeaderRequestID, tracing.HeaderTraceParent},
+		ExposedHeaders:   []string{api.HeaderCorrelationID, logging.HeaderRequestID},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
 	// ✅ Rotas
-	r.Post("/users", userApiHandlers.CreateUser)
+	// POST /users aceita token opcional: apenas admins criam usuários com papéis elevados
+	r.With(jwtauth.Verifier(tokenAuth)).Post("/users", userApiHandlers.CreateUser)
 	r.Post("/users/generate_token", userApiHandlers.GetJWT)
 
-	r.Post("/pessoas", pessoaApiHandlers.CreatePessoa)
-	r.Post("/pessoas/v1", pessoaApiHandlers.CreatePessoaNomeEmail)
-	r.Get("/pessoas", pessoaApiHandlers.GetPessoas)
-	r.Get("/pessoas/{id}", pessoaApiHandlers.GetPessoa)
-	r.Put("/pessoas/{id}", pessoaApiHandlers.UpdatePessoa)
-	r.Delete("/pessoas/{id}", pessoaApiHandlers.DeletePessoa)
-
-	r.Post("/enderecos", pessoaApiHandlers.CreateEndereco)
-	r.Put("/enderecos/{id}", pessoaApiHandlers.UpdateEndereco)
-	r.Get("/enderecos/{id}", pessoaApiHandlers.GetEndereco)
-	r.Delete("/enderecos/{id}", pessoaApiHandlers.DeleteEndereco)
-	r.Get("/pessoas/{parent}/enderecos", pessoaApiHandlers.GetEnderecosDaPessoa)
-
-	r.Post("/emails", pessoaApiHandlers.CreateEmail)
-	r.Put("/emails/{id}", pessoaApiHandlers.UpdateEmail)
-	r.Get("/emails/{id}", pessoaApiHandlers.GetEmail)
-	r.Delete("/emails/{id}", pessoaApiHandlers.DeleteEmail)
-	r.Get("/pessoas/{parent}/emails", pessoaApiHandlers.GetEmailsDaPessoa)
-
-	r.Post("/telefones", pessoaApiHandlers.CreateTelefone)
-	r.Put("/telefones/{id}", pessoaApiHandlers.UpdateTelefone)
-	r.Get("/telefones/{id}", pessoaApiHandlers.GetTelefone)
-	r.Delete("/telefones/{id}", pessoaApiHandlers.DeleteTelefone)
-	r.Get("/pessoas/{parent}/telefones", pessoaApiHandlers.GetTelefonesDaPessoa)
-
 	// ✅ Swagger
 	swaggerURL := fmt.Sprintf("http://localhost:%s/docs/doc.json", servicePort)
 	r.Get("/docs/*", httpSwagger.Handler(httpSwagger.URL(swaggerURL)))
 
-	// ✅ Admin com Qor5
-	adminPanel := admin.InitializeAdmin(db)
-	r.Mount("/admin", adminPanel)
+	r.Group(func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(jwtauth.Authenticator)
+
+		// Leituras: admin e instrutor
+		r.Group(func(r chi.Router) {
+			r.Use(webserver.RequireRoles(api.RoleFromContext, entity.RoleAdmin, entity.RoleInstrutor))
+
+			r.Get("/pessoas", pessoaApiHandlers.GetPessoas)
+			r.Get("/pessoas/{id}", pessoaApiHandlers.GetPessoa)
+			r.Get("/enderecos/{id}", pessoaApiHandlers.GetEndereco)
+			r.Get("/pessoas/{parent}/enderecos", pessoaApiHandlers.GetEnderecosDaPessoa)
+			r.Get("/emails/{id}", pessoaApiHandlers.GetEmail)
+			r.Get("/pessoas/{parent}/emails", pessoaApiHandlers.GetEmailsDaPessoa)
+			r.Get("/telefones/{id}", pessoaApiHandlers.GetTelefone)
+			r.Get("/pessoas/{parent}/telefones", pessoaApiHandlers.GetTelefonesDaPessoa)
+		})
+
+		// Alterações: apenas admin
+		r.Group(func(r chi.Router) {
+			r.Use(webserver.RequireRoles(api.RoleFromContext, entity.RoleAdmin))
+
+			r.Post("/pessoas", pessoaApiHandlers.CreatePessoa)
+			r.Post("/pessoas/v1", pessoaApiHandlers.CreatePessoaNomeEmail)
+			r.Put("/pessoas/{id}", pessoaApiHandlers.UpdatePessoa)
+			r.Delete("/pessoas/{id}", pessoaApiHandlers.DeletePessoa)
+
+			r.Post("/enderecos", pessoaApiHandlers.CreateEndereco)
+			r.Put("/enderecos/{id}", pessoaApiHandlers.UpdateEndereco)
+			r.Delete("/enderecos/{id}", pessoaApiHandlers.DeleteEndereco)
+
+			r.Post("/emails", pessoaApiHandlers.CreateEmail)
+			r.Put("/emails/{id}", pessoaApiHandlers.UpdateEmail)
+			r.Delete("/emails/{id}", pessoaApiHandlers.DeleteEmail)
+
+			r.Post("/telefones", pessoaApiHandlers.CreateTelefone)
+			r.Put("/telefones/{id}", pessoaApiHandlers.UpdateTelefone)
+			r.Delete("/telefones/{id}", pessoaApiHandlers.DeleteTelefone)
+
+			// ✅ Admin com Qor5
+			adminPanel := admin.InitializeAdmin(db)
+			r.Mount("/admin", adminPanel)
+		})
+	})
+
+	// ✅ Dispatcher por último na ordem de registro: é o primeiro a ser fechado,
+	// depois que nenhuma requisição pode mais disparar eventos
+	lc.OnStop("event dispatcher", eventDispatcher.Close)
+
+	// ✅ Liveness, readiness e métricas fora dos middlewares, sem autenticação e sem poluir o log
+	root := chi.NewRouter()
+	root.Get("/healthz", healthChecks.Liveness)
+	root.Get("/readyz", healthChecks.Readiness)
+	root.Handle("/metrics", metrics.Handler())
+	root.Mount("/", r)
 
 	// ✅ Iniciar servidor
-	log.Printf("🚀 Pessoa Service rodando na porta :%s", port)
-	http.ListenAndServe(":"+port, r)
+	server := webserver.NewServer(":"+port, root)
+	lc.Go("servidor HTTP", func(ctx context.Context) error {
+		return webserver.Serve(ctx, server, lc.Config.DrainTimeout)
+	})
+
+	if err := lc.Run(); err != nil {
+		logging.Fatal("erro no encerramento", logging.Err(err))
+	}
+	slog.Info("serviço encerrado")
 }
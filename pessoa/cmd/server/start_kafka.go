@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"strings"
 
@@ -31,7 +31,7 @@ func startKafka(brokers string) error {
 
 	admin, err := sarama.NewClusterAdmin(brokerList, config)
 	if err != nil {
-		return fmt.Errorf("❌ Erro ao criar ClusterAdmin: %v", err)
+		return fmt.Errorf("Erro ao criar ClusterAdmin: %v", err)
 	}
 	defer admin.Close()
 
@@ -50,12 +50,12 @@ func startKafka(brokers string) error {
 
 			if err != nil {
 				if err.(sarama.KError) == sarama.ErrTopicAlreadyExists {
-					log.Printf("🔍 Tópico '%s' já existe.", topic)
+					slog.Debug("tópico já existe", "topic", topic)
 				} else {
-					return fmt.Errorf("❌ Erro ao criar tópico '%s': %v", topic, err)
+					return fmt.Errorf("Erro ao criar tópico '%s': %v", topic, err)
 				}
 			} else {
-				log.Printf("✅ Tópico '%s' criado com sucesso!", topic)
+				slog.Info("tópico criado", "topic", topic)
 			}
 		}
 	}
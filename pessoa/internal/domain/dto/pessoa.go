@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
 	"github.com/google/uuid"
 )
@@ -49,6 +51,13 @@ type PessoaOutputDTO struct {
 	Tipo      entity.TipoPessoa `json:"tipo"`
 	Nome      string            `json:"nome"`
 	Documento string            `json:"documento"`
+	UpdatedAt time.Time         `json:"updated_at"` // versão da pessoa, usada pelos consumidores para descartar eventos antigos
+}
+
+// PessoaDeletedOutputDTO é o payload do evento pessoa.deleted
+type PessoaDeletedOutputDTO struct {
+	ID        uuid.UUID `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
 }
 
 type PessoaAgregadoOutputDTO struct {
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatusOutbox string
+
+const (
+	OutboxPendente  StatusOutbox = "pendente"
+	OutboxPublicado StatusOutbox = "publicado"
+	OutboxFalhou    StatusOutbox = "falhou" // sem producer ou excedeu as tentativas; não é mais publicado
+)
+
+// OutboxEvent é um evento de domínio gravado na mesma transação da alteração do agregado.
+// O relay do outbox publica os eventos pendentes no Kafka.
+type OutboxEvent struct {
+	CreatedAt     time.Time    `json:"created_at" gorm:"autoCreateTime;index"`
+	ID            uuid.UUID    `gorm:"type:uuid;primary_key" json:"id"`
+	EventName     string       `gorm:"type:varchar(100);not null" json:"event_name"`
+	AggregateID   uuid.UUID    `gorm:"type:uuid" json:"aggregate_id"`
+	CorrelationID string       `gorm:"type:varchar(100)" json:"correlation_id"`
+	RequestID     string       `gorm:"type:varchar(100)" json:"request_id"`
+	TraceParent   string       `gorm:"type:varchar(100)" json:"trace_parent"`
+	Payload       string       `gorm:"type:text" json:"payload"`
+	Status        StatusOutbox `gorm:"type:varchar(20);index;default:'pendente'" json:"status"`
+	Attempts      int          `json:"attempts"`
+	LastError     string       `gorm:"type:text" json:"last_error"`
+	PublishedAt   *time.Time   `json:"published_at"`
+}
+
+func NewOutboxEvent(eventName string, aggregateID uuid.UUID, payload interface{}) (*OutboxEvent, error) {
+	if eventName == "" {
+		return nil, errors.New("invalid event name")
+	}
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		ID:          uuid.New(),
+		EventName:   eventName,
+		AggregateID: aggregateID,
+		Payload:     string(jsonPayload),
+		Status:      OutboxPendente,
+	}, nil
+}
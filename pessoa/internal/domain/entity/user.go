@@ -1,18 +1,38 @@
 package entity
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+type Role string
+
+const (
+	RoleAdmin     Role = "admin"
+	RoleInstrutor Role = "instrutor"
+	RoleAluno     Role = "aluno"
+)
+
 type User struct {
 	ID       uuid.UUID `gorm:"type:uuid;primary_key"                json:"id"`
 	Name     string    `gorm:"type:varchar(100)"                    json:"name"`
 	Email    string    `gorm:"type:varchar(100);unique;not null"    json:"email"`
 	Password string    `gorm:"type:varchar(100)"                    json:"-"`
+	Role     Role      `gorm:"type:varchar(20);default:'aluno'"     json:"role"`
 }
 
+// NewUser cria um usuário com o papel padrão de aluno.
 func NewUser(name, email, password string) (*User, error) {
+	return NewUserWithRole(name, email, password, RoleAluno)
+}
+
+// NewUserWithRole cria um usuário com o papel informado.
+func NewUserWithRole(name, email, password string, role Role) (*User, error) {
+	if !role.IsValid() {
+		return nil, errors.New("invalid role: " + string(role))
+	}
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
@@ -22,6 +42,7 @@ func NewUser(name, email, password string) (*User, error) {
 		Name:     name,
 		Email:    email,
 		Password: string(hash),
+		Role:     role,
 	}, nil
 }
 
@@ -29,3 +50,7 @@ func (u *User) ValidatePassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 	return err == nil
 }
+
+func (r Role) IsValid() bool {
+	return r == RoleAdmin || r == RoleInstrutor || r == RoleAluno
+}
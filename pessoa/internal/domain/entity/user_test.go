@@ -23,3 +23,20 @@ func TestUser_ValidatePassword(t *testing.T) {
 	assert.False(t, user.ValidatePassword("1234567"))
 	assert.NotEqual(t, "123456", user.Password)
 }
+
+func TestNewUser_DefaultRoleAluno(t *testing.T) {
+	user, err := NewUser("John Doe", "j@j.com", "123456")
+	assert.Nil(t, err)
+	assert.Equal(t, RoleAluno, user.Role)
+}
+
+func TestNewUserWithRole(t *testing.T) {
+	user, err := NewUserWithRole("John Doe", "j@j.com", "123456", RoleInstrutor)
+	assert.Nil(t, err)
+	assert.Equal(t, RoleInstrutor, user.Role)
+
+	user, err = NewUserWithRole("John Doe", "j@j.com", "123456", Role("root"))
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Equal(t, "invalid role: root", err.Error())
+}
@@ -1,12 +1,12 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"log"
-	"sync"
+	"log/slog"
 
-	event_pkg "github.com/ggialluisi/nebula-back/pessoa/pkg/event_dispatcher"
+	event_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/logging"
 )
 
 type PessoaChangedLogOnlyHandler struct {
@@ -19,18 +19,14 @@ func NewPessoaChangedLogOnlyHandler(msgPrefix string) *PessoaChangedLogOnlyHandl
 	}
 }
 
-func (h *PessoaChangedLogOnlyHandler) Handle(event event_pkg.EventInterface, wg *sync.WaitGroup) {
-	defer wg.Done()
-	fmt.Printf("Pessoa Changed: %v", event.GetPayload())
+func (h *PessoaChangedLogOnlyHandler) Handle(ctx context.Context, event event_pkg.EventInterface) error {
 	jsonOutput, err := json.Marshal(event.GetPayload())
 	if err != nil {
-		log.Default().Printf("Error marshalling payload: %v", err)
-		return
+		slog.ErrorContext(ctx, "erro ao serializar payload", "event", event.GetName(), logging.Err(err))
+		return err
 	}
 
-	//do the log
-	log.Default().Println("Log Only Handler - Pessoa Changed")
-	log.Default().Printf("%s: %v", h.MsgPrefix, string(jsonOutput))
-	log.Default().Println("end of Log Only Handler - Pessoa Changed")
-
+	// documento, email e telefone saem mascarados
+	slog.InfoContext(ctx, h.MsgPrefix, "event", event.GetName(), logging.Payload(jsonOutput))
+	return nil
 }
@@ -1,30 +1,20 @@
 package event
 
-import "time"
+import (
+	"context"
 
-type OrderCreated struct {
-	Name    string
-	Payload interface{}
-}
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+)
 
-func NewOrderCreated() *OrderCreated {
-	return &OrderCreated{
-		Name: "OrderCreated",
-	}
-}
+const OrderCreatedName = "OrderCreated"
 
-func (e *OrderCreated) GetName() string {
-	return e.Name
-}
-
-func (e *OrderCreated) GetPayload() interface{} {
-	return e.Payload
+type OrderCreated struct {
+	Event
 }
 
-func (e *OrderCreated) SetPayload(payload interface{}) {
-	e.Payload = payload
+func NewOrderCreated(ctx context.Context, orderID string, payload interface{}) OrderCreated {
+	return OrderCreated{Event: newEvent(ctx, OrderCreatedName, orderID, payload)}
 }
 
-func (e *OrderCreated) GetDateTime() time.Time {
-	return time.Now()
-}
+// verifica se implementa a interface
+var _ events_pkg.EventInterface = OrderCreated{}
@@ -1,37 +1,21 @@
 package event
 
 import (
-	"time"
+	"context"
 
-	events_pkg "github.com/ggialluisi/nebula-back/pessoa/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
 )
 
-type PessoaChanged struct {
-	Name    string
-	Payload interface{}
-}
+const PessoaChangedName = "PessoaChanged"
 
-func NewPessoaChanged() *PessoaChanged {
-	return &PessoaChanged{
-		Name: "PessoaChanged",
-	}
-}
-
-func (e *PessoaChanged) GetName() string {
-	return e.Name
-}
-
-func (e *PessoaChanged) GetPayload() interface{} {
-	return e.Payload
-}
-
-func (e *PessoaChanged) SetPayload(payload interface{}) {
-	e.Payload = payload
+type PessoaChanged struct {
+	Event
 }
 
-func (e *PessoaChanged) GetDateTime() time.Time {
-	return time.Now()
+func NewPessoaChanged(ctx context.Context, payload dto.PessoaOutputDTO) PessoaChanged {
+	return PessoaChanged{Event: newEvent(ctx, PessoaChangedName, payload.ID.String(), payload)}
 }
 
 // verifica se implementa a interface
-var _ events_pkg.EventInterface = (*PessoaChanged)(nil)
+var _ events_pkg.EventInterface = PessoaChanged{}
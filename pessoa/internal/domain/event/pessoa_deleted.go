@@ -0,0 +1,21 @@
+package event
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/dto"
+	events_pkg "github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+)
+
+const PessoaDeletedName = "PessoaDeleted"
+
+type PessoaDeleted struct {
+	Event
+}
+
+func NewPessoaDeleted(ctx context.Context, payload dto.PessoaDeletedOutputDTO) PessoaDeleted {
+	return PessoaDeleted{Event: newEvent(ctx, PessoaDeletedName, payload.ID.String(), payload)}
+}
+
+// verifica se implementa a interface
+var _ events_pkg.EventInterface = PessoaDeleted{}
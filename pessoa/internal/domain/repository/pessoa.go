@@ -1,17 +1,25 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/query"
 	"github.com/google/uuid"
 )
 
 type PessoaRepositoryInterface interface {
+	// WithTransaction executa fn dentro de uma transação; fn recebe um repositório ligado à
+	// transação e ao contexto (trace da requisição nas consultas)
+	WithTransaction(ctx context.Context, fn func(repo PessoaRepositoryInterface) error) error
+	CreateOutboxEvent(obj *entity.OutboxEvent) error
+
 	CreatePessoa(obj *entity.Pessoa) (*entity.Pessoa, error)
 	UpdatePessoa(obj *entity.Pessoa) (*entity.Pessoa, error)
 	DeletePessoa(objID uuid.UUID) error
 	GetPessoa(objID uuid.UUID) (*entity.Pessoa, error)
 	GetPessoaByDocumento(documento string) (*entity.Pessoa, error)
-	FindAllPessoas(page, limit int, sort string) ([]entity.Pessoa, error)
+	FindAllPessoas(spec query.Spec) (query.Page[entity.Pessoa], error)
 
 	CreateEndereco(obj *entity.Endereco) (*entity.Endereco, error)
 	UpdateEndereco(obj *entity.Endereco) (*entity.Endereco, error)
@@ -1,9 +1,12 @@
 package usecase
 
 import (
+	"context"
+
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/event"
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/repository"
-	"github.com/ggialluisi/nebula-back/pessoa/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
 )
 
 type OrderInputDTO struct {
@@ -21,23 +24,20 @@ type OrderOutputDTO struct {
 
 type CreateOrderUseCase struct {
 	OrderRepository repository.OrderRepositoryInterface
-	OrderCreated    event_dispatcher.EventInterface
 	EventDispatcher event_dispatcher.EventDispatcherInterface
 }
 
 func NewCreateOrderUseCase(
 	OrderRepository repository.OrderRepositoryInterface,
-	OrderCreated event_dispatcher.EventInterface,
 	EventDispatcher event_dispatcher.EventDispatcherInterface,
 ) *CreateOrderUseCase {
 	return &CreateOrderUseCase{
 		OrderRepository: OrderRepository,
-		OrderCreated:    OrderCreated,
 		EventDispatcher: EventDispatcher,
 	}
 }
 
-func (c *CreateOrderUseCase) Execute(input OrderInputDTO) (OrderOutputDTO, error) {
+func (c *CreateOrderUseCase) Execute(ctx context.Context, input OrderInputDTO) (OrderOutputDTO, error) {
 	order := entity.Order{
 		ID:    input.ID,
 		Price: input.Price,
@@ -55,8 +55,7 @@ func (c *CreateOrderUseCase) Execute(input OrderInputDTO) (OrderOutputDTO, error
 		FinalPrice: order.Price + order.Tax,
 	}
 
-	c.OrderCreated.SetPayload(dto)
-	c.EventDispatcher.Dispatch(c.OrderCreated)
+	c.EventDispatcher.Dispatch(ctx, event.NewOrderCreated(ctx, order.ID, dto))
 
 	return dto, nil
 }
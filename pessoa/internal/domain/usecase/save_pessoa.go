@@ -1,35 +1,65 @@
 package usecase
 
 import (
-	"encoding/json"
+	"context"
+	"time"
 
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/dto"
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
+	domain_event "github.com/ggialluisi/nebula-back/pessoa/internal/domain/event"
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/repository"
-	"github.com/ggialluisi/nebula-back/pessoa/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/query"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
 	"github.com/google/uuid"
 )
 
 type SavePessoaUseCase struct {
 	PessoaRepository repository.PessoaRepositoryInterface
-	PessoaSaved      event_dispatcher.EventInterface
 	EventDispatcher  event_dispatcher.EventDispatcherInterface
 }
 
 func NewSavePessoaUseCase(
 	PessoaRepository repository.PessoaRepositoryInterface,
-	PessoaSaved event_dispatcher.EventInterface,
 	EventDispatcher event_dispatcher.EventDispatcherInterface,
 ) *SavePessoaUseCase {
 	return &SavePessoaUseCase{
 		PessoaRepository: PessoaRepository,
-		PessoaSaved:      PessoaSaved,
 		EventDispatcher:  EventDispatcher,
 	}
 }
 
+// addToOutbox grava o evento no outbox usando o repositório da transação corrente,
+// garantindo que ele só exista se a alteração do agregado for confirmada. O registro
+// herda id, instante e correlação do evento, então o evento despachado localmente e
+// o publicado no Kafka têm o mesmo event_id. O traceparent do contexto é guardado para a
+// publicação continuar o trace da requisição.
+func (c *SavePessoaUseCase) addToOutbox(ctx context.Context, repo repository.PessoaRepositoryInterface, event event_dispatcher.EventInterface) error {
+	aggregateID, err := uuid.Parse(event.GetAggregateID())
+	if err != nil {
+		return err
+	}
+	eventID, err := uuid.Parse(event.GetID())
+	if err != nil {
+		return err
+	}
+	outboxEvent, err := entity.NewOutboxEvent(event.GetName(), aggregateID, event.GetPayload())
+	if err != nil {
+		return err
+	}
+	outboxEvent.ID = eventID
+	outboxEvent.CreatedAt = event.GetDateTime()
+	outboxEvent.CorrelationID = event.GetCorrelationID()
+	outboxEvent.RequestID = event.GetRequestID()
+	outboxEvent.TraceParent = tracing.TraceParent(ctx)
+	return repo.CreateOutboxEvent(outboxEvent)
+}
+
 // region cadastro de Pessoa
-func (c *SavePessoaUseCase) ExecuteCreatePessoaNomeEmail(input dto.PessoaNomeEmailInputDTO) (dto.PessoaOutputDTO, error) {
+func (c *SavePessoaUseCase) ExecuteCreatePessoaNomeEmail(ctx context.Context, input dto.PessoaNomeEmailInputDTO) (dto.PessoaOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SavePessoaUseCase.ExecuteCreatePessoaNomeEmail")
+	defer span.End()
+
 	pessoa, err := entity.NewPessoa(
 		nil,
 		entity.PessoaFisica,
@@ -40,44 +70,54 @@ func (c *SavePessoaUseCase) ExecuteCreatePessoaNomeEmail(input dto.PessoaNomeEma
 		return dto.PessoaOutputDTO{}, err
 	}
 
-	ret, err := c.PessoaRepository.CreatePessoa(pessoa)
-	if err != nil {
-		return dto.PessoaOutputDTO{}, err
-	}
-
-	if input.Email != "" {
-		// Cria o email associado à pessoa
-		email, err := entity.NewEmail(
-			ret.ID,
-			nil,
-			input.Email,
-			true, // é principal por padrão
-		)
+	var out_dto dto.PessoaOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.PessoaRepository.WithTransaction(ctx, func(repo repository.PessoaRepositoryInterface) error {
+		ret, err := repo.CreatePessoa(pessoa)
 		if err != nil {
-			return dto.PessoaOutputDTO{}, err
+			return err
+		}
+
+		if input.Email != "" {
+			// Cria o email associado à pessoa
+			email, err := entity.NewEmail(
+				ret.ID,
+				nil,
+				input.Email,
+				true, // é principal por padrão
+			)
+			if err != nil {
+				return err
+			}
+
+			_, err = repo.CreateEmail(email)
+			if err != nil {
+				return err
+			}
 		}
 
-		_, err = c.PessoaRepository.CreateEmail(email)
+		// retorna o objeto salvo
+		saved_obj, err := repo.GetPessoa(ret.ID)
 		if err != nil {
-			return dto.PessoaOutputDTO{}, err
+			return err
 		}
-	}
 
-	// retorna o objeto salvo
-	saved_obj, err := c.PessoaRepository.GetPessoa(ret.ID)
+		out_dto = dto.PessoaOutputDTO{
+			ID:        saved_obj.ID,
+			Tipo:      saved_obj.Tipo,
+			Nome:      saved_obj.Nome,
+			Documento: saved_obj.Documento,
+			UpdatedAt: saved_obj.UpdatedAt,
+		}
+
+		evt = domain_event.NewPessoaChanged(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return dto.PessoaOutputDTO{}, err
 	}
 
-	out_dto := dto.PessoaOutputDTO{
-		ID:        saved_obj.ID,
-		Tipo:      saved_obj.Tipo,
-		Nome:      saved_obj.Nome,
-		Documento: saved_obj.Documento,
-	}
-
-	c.PessoaSaved.SetPayload(out_dto)
-	err = c.EventDispatcher.Dispatch(c.PessoaSaved)
+	err = c.EventDispatcher.Dispatch(ctx, evt)
 	if err != nil {
 		return dto.PessoaOutputDTO{}, err
 	}
@@ -85,7 +125,10 @@ func (c *SavePessoaUseCase) ExecuteCreatePessoaNomeEmail(input dto.PessoaNomeEma
 	return out_dto, nil
 }
 
-func (c *SavePessoaUseCase) ExecuteCreatePessoa(input dto.PessoaInputDTO) (dto.PessoaOutputDTO, error) {
+func (c *SavePessoaUseCase) ExecuteCreatePessoa(ctx context.Context, input dto.PessoaInputDTO) (dto.PessoaOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SavePessoaUseCase.ExecuteCreatePessoa")
+	defer span.End()
+
 	pessoa, err := entity.NewPessoa(
 		nil,
 		input.Tipo,
@@ -96,25 +139,35 @@ func (c *SavePessoaUseCase) ExecuteCreatePessoa(input dto.PessoaInputDTO) (dto.P
 		return dto.PessoaOutputDTO{}, err
 	}
 
-	ret, err := c.PessoaRepository.CreatePessoa(pessoa)
-	if err != nil {
-		return dto.PessoaOutputDTO{}, err
-	}
+	var out_dto dto.PessoaOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.PessoaRepository.WithTransaction(ctx, func(repo repository.PessoaRepositoryInterface) error {
+		ret, err := repo.CreatePessoa(pessoa)
+		if err != nil {
+			return err
+		}
+
+		saved_obj, err := repo.GetPessoa(ret.ID)
+		if err != nil {
+			return err
+		}
+
+		out_dto = dto.PessoaOutputDTO{
+			ID:        saved_obj.ID,
+			Tipo:      saved_obj.Tipo,
+			Nome:      saved_obj.Nome,
+			Documento: saved_obj.Documento,
+			UpdatedAt: saved_obj.UpdatedAt,
+		}
 
-	saved_obj, err := c.PessoaRepository.GetPessoa(ret.ID)
+		evt = domain_event.NewPessoaChanged(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return dto.PessoaOutputDTO{}, err
 	}
 
-	out_dto := dto.PessoaOutputDTO{
-		ID:        saved_obj.ID,
-		Tipo:      saved_obj.Tipo,
-		Nome:      saved_obj.Nome,
-		Documento: saved_obj.Documento,
-	}
-
-	c.PessoaSaved.SetPayload(out_dto)
-	err = c.EventDispatcher.Dispatch(c.PessoaSaved)
+	err = c.EventDispatcher.Dispatch(ctx, evt)
 	if err != nil {
 		return dto.PessoaOutputDTO{}, err
 	}
@@ -122,7 +175,10 @@ func (c *SavePessoaUseCase) ExecuteCreatePessoa(input dto.PessoaInputDTO) (dto.P
 	return out_dto, nil
 }
 
-func (c *SavePessoaUseCase) ExecuteUpdatePessoa(obj_id string, input dto.PessoaInputDTO) (dto.PessoaOutputDTO, error) {
+func (c *SavePessoaUseCase) ExecuteUpdatePessoa(ctx context.Context, obj_id string, input dto.PessoaInputDTO) (dto.PessoaOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "SavePessoaUseCase.ExecuteUpdatePessoa")
+	defer span.End()
+
 	obj_uuid, err := uuid.Parse(obj_id)
 	if err != nil {
 		return dto.PessoaOutputDTO{}, err
@@ -138,30 +194,35 @@ func (c *SavePessoaUseCase) ExecuteUpdatePessoa(obj_id string, input dto.PessoaI
 		return dto.PessoaOutputDTO{}, err
 	}
 
-	ret, err := c.PessoaRepository.UpdatePessoa(pessoa)
-	if err != nil {
-		return dto.PessoaOutputDTO{}, err
-	}
+	var out_dto dto.PessoaOutputDTO
+	var evt event_dispatcher.EventInterface
+	err = c.PessoaRepository.WithTransaction(ctx, func(repo repository.PessoaRepositoryInterface) error {
+		ret, err := repo.UpdatePessoa(pessoa)
+		if err != nil {
+			return err
+		}
 
-	saved_obj, err := c.PessoaRepository.GetPessoa(ret.ID)
-	if err != nil {
-		return dto.PessoaOutputDTO{}, err
-	}
+		saved_obj, err := repo.GetPessoa(ret.ID)
+		if err != nil {
+			return err
+		}
 
-	out_dto := dto.PessoaOutputDTO{
-		ID:        saved_obj.ID,
-		Tipo:      saved_obj.Tipo,
-		Nome:      saved_obj.Nome,
-		Documento: saved_obj.Documento,
-	}
+		out_dto = dto.PessoaOutputDTO{
+			ID:        saved_obj.ID,
+			Tipo:      saved_obj.Tipo,
+			Nome:      saved_obj.Nome,
+			Documento: saved_obj.Documento,
+			UpdatedAt: saved_obj.UpdatedAt,
+		}
 
-	_, err = json.Marshal(out_dto)
+		evt = domain_event.NewPessoaChanged(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return dto.PessoaOutputDTO{}, err
 	}
 
-	c.PessoaSaved.SetPayload(out_dto)
-	err = c.EventDispatcher.Dispatch(c.PessoaSaved)
+	err = c.EventDispatcher.Dispatch(ctx, evt)
 	if err != nil {
 		return dto.PessoaOutputDTO{}, err
 	}
@@ -169,20 +230,37 @@ func (c *SavePessoaUseCase) ExecuteUpdatePessoa(obj_id string, input dto.PessoaI
 	return out_dto, nil
 }
 
-func (c *SavePessoaUseCase) ExecuteDeletePessoa(obj_id string) error {
+func (c *SavePessoaUseCase) ExecuteDeletePessoa(ctx context.Context, obj_id string) error {
+	ctx, span := tracing.Start(ctx, "SavePessoaUseCase.ExecuteDeletePessoa")
+	defer span.End()
+
 	obj_uuid, err := uuid.Parse(obj_id)
 	if err != nil {
 		return err
 	}
 
-	err = c.PessoaRepository.DeletePessoa(obj_uuid)
+	// a exclusão e o evento pessoa.deleted são gravados na mesma transação
+	out_dto := dto.PessoaDeletedOutputDTO{ID: obj_uuid, DeletedAt: time.Now()}
+	var evt event_dispatcher.EventInterface
+	err = c.PessoaRepository.WithTransaction(ctx, func(repo repository.PessoaRepositoryInterface) error {
+		_, err := repo.GetPessoa(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		err = repo.DeletePessoa(obj_uuid)
+		if err != nil {
+			return err
+		}
+
+		evt = domain_event.NewPessoaDeleted(ctx, out_dto)
+		return c.addToOutbox(ctx, repo, evt)
+	})
 	if err != nil {
 		return err
 	}
 
-	//to-do - aqui precisa disparar um evento para o log
-
-	return nil
+	return c.EventDispatcher.Dispatch(ctx, evt)
 }
 
 func (c *SavePessoaUseCase) ExecuteGetPessoa(obj_id string) (dto.PessoaOutputDTO, error) {
@@ -201,28 +279,27 @@ func (c *SavePessoaUseCase) ExecuteGetPessoa(obj_id string) (dto.PessoaOutputDTO
 		Tipo:      saved_obj.Tipo,
 		Nome:      saved_obj.Nome,
 		Documento: saved_obj.Documento,
+		UpdatedAt: saved_obj.UpdatedAt,
 	}
 
 	return out_dto, nil
 }
 
-func (c *SavePessoaUseCase) ExecuteGetPessoas(page, limit int, sort string) ([]dto.PessoaOutputDTO, error) {
-	saved_objs, err := c.PessoaRepository.FindAllPessoas(page, limit, sort)
+func (c *SavePessoaUseCase) ExecuteGetPessoas(spec query.Spec) (query.Page[dto.PessoaOutputDTO], error) {
+	saved_objs, err := c.PessoaRepository.FindAllPessoas(spec)
 	if err != nil {
-		return []dto.PessoaOutputDTO{}, err
+		return query.Page[dto.PessoaOutputDTO]{}, err
 	}
 
-	var dtos []dto.PessoaOutputDTO
-	for _, saved_obj := range saved_objs {
-		out_dto := dto.PessoaOutputDTO{
+	return query.Map(saved_objs, func(saved_obj entity.Pessoa) dto.PessoaOutputDTO {
+		return dto.PessoaOutputDTO{
 			ID:        saved_obj.ID,
 			Tipo:      saved_obj.Tipo,
 			Nome:      saved_obj.Nome,
 			Documento: saved_obj.Documento,
+			UpdatedAt: saved_obj.UpdatedAt,
 		}
-		dtos = append(dtos, out_dto)
-	}
-	return dtos, nil
+	}), nil
 }
 
 // endregion
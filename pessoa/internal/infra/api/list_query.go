@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ggialluisi/nebula-back/platform/query"
+)
+
+// Campos de filtro e ordenação aceitos por cada listagem. Os demais parâmetros da
+// query string são ignorados.
+
+var pessoasQuery = query.Schema{
+	Fields: map[string]query.Field{
+		"nome":       {Column: "nome", Filter: query.Contains, Sortable: true},
+		"tipo":       {Column: "tipo", Filter: query.Equals, Sortable: true},
+		"documento":  {Column: "documento", Filter: query.Equals},
+		"created_at": {Column: "created_at", Sortable: true},
+		"updated_at": {Column: "updated_at", Sortable: true},
+	},
+	DefaultSort: []query.Sort{{Column: "created_at"}},
+}
+
+// parseListQuery lê os parâmetros da listagem; se forem inválidos, responde 400 e
+// devolve false.
+func parseListQuery(w http.ResponseWriter, r *http.Request, schema query.Schema) (query.Spec, bool) {
+	spec, err := schema.Parse(r.URL.Query())
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(Error{Message: err.Error()})
+		return query.Spec{}, false
+	}
+	return spec, true
+}
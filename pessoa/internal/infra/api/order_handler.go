@@ -6,24 +6,21 @@ import (
 
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/repository"
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/usecase"
-	"github.com/ggialluisi/nebula-back/pessoa/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
 )
 
 type WebOrderHandler struct {
-	EventDispatcher   event_dispatcher.EventDispatcherInterface
-	OrderRepository   repository.OrderRepositoryInterface
-	OrderCreatedEvent event_dispatcher.EventInterface
+	EventDispatcher event_dispatcher.EventDispatcherInterface
+	OrderRepository repository.OrderRepositoryInterface
 }
 
 func NewWebOrderHandler(
 	EventDispatcher event_dispatcher.EventDispatcherInterface,
 	OrderRepository repository.OrderRepositoryInterface,
-	OrderCreatedEvent event_dispatcher.EventInterface,
 ) *WebOrderHandler {
 	return &WebOrderHandler{
-		EventDispatcher:   EventDispatcher,
-		OrderRepository:   OrderRepository,
-		OrderCreatedEvent: OrderCreatedEvent,
+		EventDispatcher: EventDispatcher,
+		OrderRepository: OrderRepository,
 	}
 }
 
@@ -35,8 +32,8 @@ func (h *WebOrderHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	createOrder := usecase.NewCreateOrderUseCase(h.OrderRepository, h.OrderCreatedEvent, h.EventDispatcher)
-	output, err := createOrder.Execute(dto)
+	createOrder := usecase.NewCreateOrderUseCase(h.OrderRepository, h.EventDispatcher)
+	output, err := createOrder.Execute(r.Context(), dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
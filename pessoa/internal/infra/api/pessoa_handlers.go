@@ -2,32 +2,28 @@ package api
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/dto"
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/repository"
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/usecase"
-	"github.com/ggialluisi/nebula-back/pessoa/pkg/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
 	"github.com/google/uuid"
 )
 
 type PessoaHandlers struct {
-	EventDispatcher    event_dispatcher.EventDispatcherInterface
-	PessoaRepository   repository.PessoaRepositoryInterface
-	PessoaChangedEvent event_dispatcher.EventInterface
+	EventDispatcher  event_dispatcher.EventDispatcherInterface
+	PessoaRepository repository.PessoaRepositoryInterface
 }
 
 func NewPessoaHandlers(
 	EventDispatcher event_dispatcher.EventDispatcherInterface,
 	PessoaRepository repository.PessoaRepositoryInterface,
-	PessoaChangedEvent event_dispatcher.EventInterface,
 ) *PessoaHandlers {
 	return &PessoaHandlers{
-		EventDispatcher:    EventDispatcher,
-		PessoaRepository:   PessoaRepository,
-		PessoaChangedEvent: PessoaChangedEvent,
+		EventDispatcher:  EventDispatcher,
+		PessoaRepository: PessoaRepository,
 	}
 }
 
@@ -53,8 +49,8 @@ func (h *PessoaHandlers) CreatePessoa(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
-	output, err := ucPessoa.ExecuteCreatePessoa(dto)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
+	output, err := ucPessoa.ExecuteCreatePessoa(r.Context(), dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -87,8 +83,8 @@ func (h *PessoaHandlers) CreatePessoaNomeEmail(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
-	output, err := ucPessoa.ExecuteCreatePessoaNomeEmail(dto)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
+	output, err := ucPessoa.ExecuteCreatePessoaNomeEmail(r.Context(), dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -116,7 +112,7 @@ func (h *PessoaHandlers) UpdatePessoa(w http.ResponseWriter, r *http.Request) {
 	// id := chi.URLParam(r, "id")
 	id := r.PathValue("id")
 
-	log.Default().Println("UpdatePessoa - ID: ", id)
+	slog.DebugContext(r.Context(), "UpdatePessoa", "id", id)
 
 	if id == "" {
 		w.WriteHeader(http.StatusBadRequest)
@@ -130,8 +126,8 @@ func (h *PessoaHandlers) UpdatePessoa(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
-	output, err := ucPessoa.ExecuteUpdatePessoa(id, dto)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
+	output, err := ucPessoa.ExecuteUpdatePessoa(r.Context(), id, dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -157,7 +153,7 @@ func (h *PessoaHandlers) UpdatePessoa(w http.ResponseWriter, r *http.Request) {
 // @Router       /pessoas/{id} [get]
 func (h *PessoaHandlers) GetPessoa(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	log.Default().Println("GetPessoa - ID: ", id)
+	slog.DebugContext(r.Context(), "GetPessoa", "id", id)
 
 	if id == "" {
 		w.WriteHeader(http.StatusBadRequest)
@@ -200,13 +196,14 @@ func (h *PessoaHandlers) DeletePessoa(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id_uuid, err := uuid.Parse(id)
+	_, err := uuid.Parse(id)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	err = h.PessoaRepository.DeletePessoa(id_uuid)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
+	err = ucPessoa.ExecuteDeletePessoa(r.Context(), id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -221,28 +218,23 @@ func (h *PessoaHandlers) DeletePessoa(w http.ResponseWriter, r *http.Request) {
 // @Tags         pessoas
 // @Accept       json
 // @Produce      json
-// @Param        page      query     string  false  "page number"
-// @Param        limit     query     string  false  "limit"
-// @Param        sort      query     string  false  "sort"
-// @Success      200
+// @Param        nome      query     string  false  "contém (sem diferenciar maiúsculas)"
+// @Param        tipo      query     string  false  "FISICA ou JURIDICA"
+// @Param        documento query     string  false  "documento"
+// @Param        page      query     int     false  "página (padrão 1)"
+// @Param        limit     query     int     false  "registros por página (padrão 50, máximo 500)"
+// @Param        sort      query     string  false  "campos separados por vírgula, - para decrescente: nome, tipo, created_at, updated_at"
+// @Success      200  {object}  query.Page[entity.Pessoa]
+// @Failure      400  {object}  Error
 // @Failure      404
-// @Failure      500
 // @Router       /pessoas [get]
 func (h *PessoaHandlers) GetPessoas(w http.ResponseWriter, r *http.Request) {
-	page := r.URL.Query().Get("page")
-	limit := r.URL.Query().Get("limit")
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		pageInt = 0
-	}
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		limitInt = 0
+	spec, ok := parseListQuery(w, r, pessoasQuery)
+	if !ok {
+		return
 	}
-	sort := r.URL.Query().Get("sort")
 
-	itens, err := h.PessoaRepository.FindAllPessoas(pageInt, limitInt, sort)
+	itens, err := h.PessoaRepository.FindAllPessoas(spec)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -276,7 +268,7 @@ func (h *PessoaHandlers) CreateEndereco(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	output, err := ucPessoa.ExecuteCreateEndereco(dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -315,7 +307,7 @@ func (h *PessoaHandlers) UpdateEndereco(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	output, err := ucPessoa.ExecuteUpdateEndereco(id, dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -353,7 +345,7 @@ func (h *PessoaHandlers) GetEndereco(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	obj, err := ucPessoa.ExecuteGetEndereco(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -389,7 +381,7 @@ func (h *PessoaHandlers) DeleteEndereco(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	err = ucPessoa.ExecuteDeleteEndereco(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -423,7 +415,7 @@ func (h *PessoaHandlers) GetEnderecosDaPessoa(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	itens, err := ucPessoa.ExecuteGetEnderecosDaPessoa(parent_id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -458,7 +450,7 @@ func (h *PessoaHandlers) CreateTelefone(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	output, err := ucPessoa.ExecuteCreateTelefone(dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -497,7 +489,7 @@ func (h *PessoaHandlers) UpdateTelefone(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	output, err := ucPessoa.ExecuteUpdateTelefone(id, dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -535,7 +527,7 @@ func (h *PessoaHandlers) GetTelefone(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	obj, err := ucPessoa.ExecuteGetTelefone(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -571,7 +563,7 @@ func (h *PessoaHandlers) DeleteTelefone(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	err = ucPessoa.ExecuteDeleteTelefone(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -605,7 +597,7 @@ func (h *PessoaHandlers) GetTelefonesDaPessoa(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	itens, err := ucPessoa.ExecuteGetTelefonesDaPessoa(parent_id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -640,7 +632,7 @@ func (h *PessoaHandlers) CreateEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	output, err := ucPessoa.ExecuteCreateEmail(dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -679,7 +671,7 @@ func (h *PessoaHandlers) UpdateEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	output, err := ucPessoa.ExecuteUpdateEmail(id, dto)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -717,7 +709,7 @@ func (h *PessoaHandlers) GetEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	obj, err := ucPessoa.ExecuteGetEmail(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -753,7 +745,7 @@ func (h *PessoaHandlers) DeleteEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	err = ucPessoa.ExecuteDeleteEmail(id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -787,7 +779,7 @@ func (h *PessoaHandlers) GetEmailsDaPessoa(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.PessoaChangedEvent, h.EventDispatcher)
+	ucPessoa := usecase.NewSavePessoaUseCase(h.PessoaRepository, h.EventDispatcher)
 	itens, err := ucPessoa.ExecuteGetEmailsDaPessoa(parent_id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
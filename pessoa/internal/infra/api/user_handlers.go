@@ -78,8 +78,9 @@ func (h *UserHandlers) GetJWT(w http.ResponseWriter, r *http.Request) {
 	}
 
 	_, tokenString, _ := h.Jwt.Encode(map[string]interface{}{
-		"sub": u.ID,
-		"exp": time.Now().Add(time.Hour * time.Duration(h.JwtExpiresIn)).Unix(),
+		"sub":  u.ID.String(),
+		"role": string(u.Role),
+		"exp":  time.Now().Add(time.Hour * time.Duration(h.JwtExpiresIn)).Unix(),
 	})
 
 	accessTokenSerializer := struct {
@@ -95,16 +96,18 @@ type CreateUserInput struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Role     string `json:"role"`
 }
 
 // CreateUser godoc
 // @Summary      Create user
-// @Description  Create user
+// @Description  Create user. Sem token de admin, o usuário é sempre criado com o papel aluno.
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Param        request     body      string  true  "user request"
 // @Success      201
+// @Failure      403         {object}  Error
 // @Failure      500         {object}  Error
 // @Router       /users [post]
 func (h *UserHandlers) CreateUser(w http.ResponseWriter, r *http.Request) {
@@ -114,7 +117,21 @@ func (h *UserHandlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	u, err := entity.NewUser(user.Name, user.Email, user.Password)
+	role := entity.RoleAluno
+	if user.Role != "" {
+		role = entity.Role(user.Role)
+	}
+
+	// apenas admins podem criar usuários com outros papéis
+	claims, ok := ClaimsFromContext(r.Context())
+	if role != entity.RoleAluno && !(ok && claims.HasRole(entity.RoleAdmin)) {
+		w.WriteHeader(http.StatusForbidden)
+		error := Error{Message: ErrForbidden.Error()}
+		json.NewEncoder(w).Encode(error)
+		return
+	}
+
+	u, err := entity.NewUserWithRole(user.Name, user.Email, user.Password, role)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		error := Error{Message: err.Error()}
@@ -0,0 +1,210 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/dto"
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
+	domain_event "github.com/ggialluisi/nebula-back/pessoa/internal/domain/event"
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/usecase"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupOutboxDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+	db.AutoMigrate(&entity.Pessoa{}, &entity.Email{}, &entity.Telefone{}, &entity.Endereco{}, &entity.OutboxEvent{})
+	return db
+}
+
+func TestOutbox_FindPendingAndMarkPublished(t *testing.T) {
+	db := setupOutboxDB(t)
+	outboxDB := NewOutboxRepositoryGorm(db)
+
+	evt, err := entity.NewOutboxEvent("PessoaChanged", uuid.New(), map[string]string{"nome": "Fulano"})
+	assert.NoError(t, err)
+	assert.NoError(t, outboxDB.CreateOutboxEvent(evt))
+
+	pending, err := outboxDB.FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, evt.ID, pending[0].ID)
+	assert.JSONEq(t, `{"nome":"Fulano"}`, pending[0].Payload)
+
+	assert.NoError(t, outboxDB.MarkOutboxEventFailed(evt.ID, "broker down"))
+	pending, err = outboxDB.FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, "broker down", pending[0].LastError)
+
+	assert.NoError(t, outboxDB.MarkOutboxEventPublished(evt.ID))
+	pending, err = outboxDB.FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 0)
+}
+
+func TestOutbox_MarkDeadRemovesEventFromPending(t *testing.T) {
+	db := setupOutboxDB(t)
+	outboxDB := NewOutboxRepositoryGorm(db)
+
+	e1, err := entity.NewOutboxEvent("EventoSemTopico", uuid.New(), map[string]string{})
+	assert.NoError(t, err)
+	assert.NoError(t, outboxDB.CreateOutboxEvent(e1))
+	e2, err := entity.NewOutboxEvent("PessoaChanged", uuid.New(), map[string]string{})
+	assert.NoError(t, err)
+	assert.NoError(t, outboxDB.CreateOutboxEvent(e2))
+
+	err = outboxDB.WithTransaction(context.Background(), func(repo repository.OutboxRepositoryInterface) error {
+		pending, err := repo.FindPendingOutboxEvents(10)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, pending, 2)
+		return repo.MarkOutboxEventDead(e1.ID, "nenhum producer")
+	})
+	assert.NoError(t, err)
+
+	pending, err := outboxDB.FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, e2.ID, pending[0].ID)
+
+	var dead entity.OutboxEvent
+	assert.NoError(t, db.First(&dead, "id = ?", e1.ID).Error)
+	assert.Equal(t, entity.OutboxFalhou, dead.Status)
+	assert.Equal(t, 1, dead.Attempts)
+	assert.Equal(t, "nenhum producer", dead.LastError)
+}
+
+func TestOutbox_WithTransactionCommitsPessoaAndEvent(t *testing.T) {
+	db := setupOutboxDB(t)
+	pessoaDB := NewPessoaRepositoryGorm(db)
+
+	pessoa, err := entity.NewPessoa(nil, "FISICA", "Nome Da Pessoa", "cpf da pessoa")
+	assert.NoError(t, err)
+
+	err = pessoaDB.WithTransaction(context.Background(), func(repo repository.PessoaRepositoryInterface) error {
+		if _, err := repo.CreatePessoa(pessoa); err != nil {
+			return err
+		}
+		evt, err := entity.NewOutboxEvent("PessoaChanged", pessoa.ID, pessoa)
+		if err != nil {
+			return err
+		}
+		return repo.CreateOutboxEvent(evt)
+	})
+	assert.NoError(t, err)
+
+	_, err = pessoaDB.GetPessoa(pessoa.ID)
+	assert.NoError(t, err)
+	pending, err := NewOutboxRepositoryGorm(db).FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, pessoa.ID, pending[0].AggregateID)
+}
+
+func TestOutbox_WithTransactionRollbackDiscardsEvent(t *testing.T) {
+	db := setupOutboxDB(t)
+	pessoaDB := NewPessoaRepositoryGorm(db)
+
+	pessoa, err := entity.NewPessoa(nil, "FISICA", "Nome Da Pessoa", "cpf da pessoa")
+	assert.NoError(t, err)
+
+	err = pessoaDB.WithTransaction(context.Background(), func(repo repository.PessoaRepositoryInterface) error {
+		if _, err := repo.CreatePessoa(pessoa); err != nil {
+			return err
+		}
+		evt, err := entity.NewOutboxEvent("PessoaChanged", pessoa.ID, pessoa)
+		if err != nil {
+			return err
+		}
+		if err := repo.CreateOutboxEvent(evt); err != nil {
+			return err
+		}
+		return errors.New("falha depois de gravar")
+	})
+	assert.Error(t, err)
+
+	_, err = pessoaDB.GetPessoa(pessoa.ID)
+	assert.Error(t, err)
+	pending, err := NewOutboxRepositoryGorm(db).FindPendingOutboxEvents(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 0)
+}
+
+type eventosRecebidos struct {
+	mu      sync.Mutex
+	eventos []event_dispatcher.EventInterface
+}
+
+func (h *eventosRecebidos) Handle(ctx context.Context, evt event_dispatcher.EventInterface) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.eventos = append(h.eventos, evt)
+	return nil
+}
+
+// Rodar com -race: o usecase é compartilhado entre as goroutines e cada gravação
+// precisa despachar o seu próprio evento.
+func TestOutbox_ConcurrentSavesDispatchIsolatedEvents(t *testing.T) {
+	db := setupOutboxDB(t)
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	// o banco em memória existe só na conexão que o criou
+	sqlDB.SetMaxOpenConns(1)
+
+	recebidos := &eventosRecebidos{}
+	dispatcher := event_dispatcher.NewEventDispatcher()
+	assert.NoError(t, dispatcher.Register(domain_event.PessoaChangedName, recebidos))
+	uc := usecase.NewSavePessoaUseCase(NewPessoaRepositoryGorm(db), dispatcher)
+
+	const total = 20
+	var wg sync.WaitGroup
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := event_dispatcher.WithCorrelationID(context.Background(), fmt.Sprintf("req-%d", i))
+			_, err := uc.ExecuteCreatePessoa(ctx, dto.PessoaInputDTO{Tipo: entity.PessoaFisica, Nome: fmt.Sprintf("Pessoa %d", i), Documento: "doc"})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, recebidos.eventos, total)
+	ids := map[string]bool{}
+	for _, evt := range recebidos.eventos {
+		out, ok := evt.GetPayload().(dto.PessoaOutputDTO)
+		assert.True(t, ok)
+		assert.Equal(t, out.ID.String(), evt.GetAggregateID())
+		// o payload é o da requisição que gerou o evento
+		var i int
+		_, err := fmt.Sscanf(out.Nome, "Pessoa %d", &i)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("req-%d", i), evt.GetCorrelationID())
+		ids[evt.GetID()] = true
+	}
+	assert.Len(t, ids, total)
+
+	// o outbox guarda a mesma ocorrência que foi despachada localmente
+	pending, err := NewOutboxRepositoryGorm(db).FindPendingOutboxEvents(total)
+	assert.NoError(t, err)
+	assert.Len(t, pending, total)
+	for _, evt := range pending {
+		assert.True(t, ids[evt.ID.String()])
+		assert.NotEmpty(t, evt.CorrelationID)
+	}
+}
@@ -1,8 +1,10 @@
 package gorm
 
 import (
+	"context"
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/query"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -18,6 +20,16 @@ func NewPessoaRepositoryGorm(db *gorm.DB) *PessoaRepositoryGorm {
 	return &PessoaRepositoryGorm{DB: db}
 }
 
+func (r *PessoaRepositoryGorm) WithTransaction(ctx context.Context, fn func(repo repository.PessoaRepositoryInterface) error) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewPessoaRepositoryGorm(tx))
+	})
+}
+
+func (r *PessoaRepositoryGorm) CreateOutboxEvent(obj *entity.OutboxEvent) error {
+	return NewOutboxRepositoryGorm(r.DB).CreateOutboxEvent(obj)
+}
+
 func (r *PessoaRepositoryGorm) CreateEndereco(obj *entity.Endereco) (*entity.Endereco, error) {
 	if err := r.DB.Create(obj).Error; err != nil {
 		return nil, err
@@ -173,17 +185,6 @@ func (r *PessoaRepositoryGorm) GetPessoaByDocumento(documento string) (*entity.P
 	return &pessoa, nil
 }
 
-func (r *PessoaRepositoryGorm) FindAllPessoas(page, limit int, sort string) ([]entity.Pessoa, error) {
-	var itens []entity.Pessoa
-	var err error
-	if sort != "" && sort != "asc" && sort != "desc" {
-		sort = "asc"
-	}
-	preloads := r.DB.Preload("Enderecos").Preload("Telefones").Preload("Emails")
-	if page != 0 && limit != 0 {
-		err = preloads.Limit(limit).Offset((page - 1) * limit).Order("created_at " + sort).Find(&itens).Error
-	} else {
-		err = preloads.Order("created_at " + sort).Find(&itens).Error
-	}
-	return itens, err
+func (r *PessoaRepositoryGorm) FindAllPessoas(spec query.Spec) (query.Page[entity.Pessoa], error) {
+	return query.Find[entity.Pessoa](r.DB.Preload("Enderecos").Preload("Telefones").Preload("Emails"), spec)
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/query"
 	"github.com/google/uuid"
 
 	"github.com/stretchr/testify/assert"
@@ -373,21 +374,34 @@ func TestFinalAllPessoas(t *testing.T) {
 	}
 
 	repo := NewPessoaRepositoryGorm(db)
-	itens, err := repo.FindAllPessoas(1, 10, "asc")
+	porCriacao := []query.Sort{{Column: "created_at"}}
+	page, err := repo.FindAllPessoas(query.Spec{Page: 1, Limit: 10, Sort: porCriacao})
 	assert.NoError(t, err)
-	assert.Len(t, itens, 10)
-	assert.Equal(t, "Pessoa 1", itens[0].Nome)
-	assert.Equal(t, "Pessoa 10", itens[9].Nome)
+	assert.Len(t, page.Items, 10)
+	assert.Equal(t, int64(23), page.Total)
+	assert.Equal(t, "Pessoa 1", page.Items[0].Nome)
+	assert.Equal(t, "Pessoa 10", page.Items[9].Nome)
+	assert.Equal(t, 2, *page.Next)
 
-	itens, err = repo.FindAllPessoas(2, 10, "asc")
+	page, err = repo.FindAllPessoas(query.Spec{Page: 2, Limit: 10, Sort: porCriacao})
 	assert.NoError(t, err)
-	assert.Len(t, itens, 10)
-	assert.Equal(t, "Pessoa 11", itens[0].Nome)
-	assert.Equal(t, "Pessoa 20", itens[9].Nome)
+	assert.Len(t, page.Items, 10)
+	assert.Equal(t, "Pessoa 11", page.Items[0].Nome)
+	assert.Equal(t, "Pessoa 20", page.Items[9].Nome)
 
-	itens, err = repo.FindAllPessoas(3, 10, "asc")
+	page, err = repo.FindAllPessoas(query.Spec{Page: 3, Limit: 10, Sort: porCriacao})
 	assert.NoError(t, err)
-	assert.Len(t, itens, 3)
-	assert.Equal(t, "Pessoa 21", itens[0].Nome)
-	assert.Equal(t, "Pessoa 23", itens[2].Nome)
+	assert.Len(t, page.Items, 3)
+	assert.Equal(t, "Pessoa 21", page.Items[0].Nome)
+	assert.Equal(t, "Pessoa 23", page.Items[2].Nome)
+	assert.Nil(t, page.Next)
+
+	page, err = repo.FindAllPessoas(query.Spec{
+		Filters: []query.Filter{{Column: "nome", Op: query.Contains, Value: "pessoa 2"}},
+		Sort:    []query.Sort{{Column: "nome", Desc: true}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), page.Total)
+	assert.Equal(t, "Pessoa 23", page.Items[0].Nome)
+	assert.Equal(t, "Pessoa 2", page.Items[4].Nome)
 }
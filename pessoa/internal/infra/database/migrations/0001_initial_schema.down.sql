@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "outbox_events";
+DROP TABLE IF EXISTS "users";
+DROP TABLE IF EXISTS "telefones";
+DROP TABLE IF EXISTS "emails";
+DROP TABLE IF EXISTS "enderecos";
+DROP TABLE IF EXISTS "pessoas";
@@ -0,0 +1,80 @@
+-- Schema inicial, gerado a partir das entidades que o AutoMigrate criava.
+-- IF NOT EXISTS permite adotar bancos já criados pelo AutoMigrate.
+
+CREATE TABLE IF NOT EXISTS "pessoas" (
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "id" uuid,
+    "tipo" varchar(20),
+    "nome" varchar(100),
+    "documento" varchar(20),
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "enderecos" (
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "id" uuid,
+    "pessoa_id" uuid,
+    "logradouro" varchar(100),
+    "numero" varchar(20),
+    "cep" varchar(20),
+    "bairro" varchar(50),
+    "cidade" varchar(50),
+    "estado" varchar(2),
+    "principal" boolean,
+    "sem_numero" boolean,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_pessoas_enderecos" FOREIGN KEY ("pessoa_id") REFERENCES "pessoas"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "emails" (
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "id" uuid,
+    "pessoa_id" uuid,
+    "endereco" varchar(100),
+    "principal" boolean,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_pessoas_emails" FOREIGN KEY ("pessoa_id") REFERENCES "pessoas"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "telefones" (
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "id" uuid,
+    "pessoa_id" uuid,
+    "ddd" varchar(3),
+    "numero" varchar(20),
+    "principal" boolean,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_pessoas_telefones" FOREIGN KEY ("pessoa_id") REFERENCES "pessoas"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" uuid,
+    "name" varchar(100),
+    "email" varchar(100) NOT NULL,
+    "password" varchar(100),
+    "role" varchar(20) DEFAULT 'aluno',
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email")
+);
+
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+    "created_at" timestamptz,
+    "id" uuid,
+    "event_name" varchar(100) NOT NULL,
+    "aggregate_id" uuid,
+    "correlation_id" varchar(100),
+    "request_id" varchar(100),
+    "trace_parent" varchar(100),
+    "payload" text,
+    "status" varchar(20) DEFAULT 'pendente',
+    "attempts" bigint,
+    "last_error" text,
+    "published_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_status" ON "outbox_events" ("status");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_created_at" ON "outbox_events" ("created_at");
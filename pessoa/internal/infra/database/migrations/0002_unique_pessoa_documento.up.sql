@@ -0,0 +1,4 @@
+-- Um documento identifica uma única pessoa. O marcador "n.d" (cadastro só com nome e
+-- email) fica de fora. Falha se já houver documentos repetidos: resolva-os antes.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_pessoas_documento" ON "pessoas" ("documento")
+    WHERE "documento" <> '' AND "documento" <> 'n.d';
@@ -0,0 +1,10 @@
+// Package migrations contém as migrações SQL versionadas do banco do serviço, aplicadas
+// pelo platform/migrate na subida ou pelo subcomando migrate.
+//
+// Novas migrações seguem a numeração: NNNN_nome.up.sql e NNNN_nome.down.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/platform/migrate"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var entities = []interface{}{
+	&entity.Pessoa{},
+	&entity.Endereco{},
+	&entity.Email{},
+	&entity.Telefone{},
+	&entity.User{},
+	&entity.OutboxEvent{},
+}
+
+func newMigratedDB(t *testing.T) (*gorm.DB, *migrate.Migrator) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	m, err := migrate.New(sqlDB, "sqlite", FS, migrate.NoLock)
+	require.NoError(t, err)
+	_, err = m.Up(context.Background())
+	require.NoError(t, err)
+	return db, m
+}
+
+// As migrações precisam acompanhar as entidades: toda coluna mapeada pelo GORM deve existir.
+func TestMigrations_CobremAsEntidades(t *testing.T) {
+	db, _ := newMigratedDB(t)
+
+	for _, e := range entities {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(e))
+		require.True(t, db.Migrator().HasTable(e), "tabela %s", stmt.Schema.Table)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			assert.True(t, db.Migrator().HasColumn(e, field.DBName), "coluna %s.%s", stmt.Schema.Table, field.DBName)
+		}
+	}
+}
+
+func TestMigrations_DocumentoUnico(t *testing.T) {
+	db, _ := newMigratedDB(t)
+
+	novaPessoa := func(documento string) *entity.Pessoa {
+		return &entity.Pessoa{ID: uuid.New(), Tipo: entity.PessoaFisica, Nome: "Ana", Documento: documento}
+	}
+	assert.NoError(t, db.Create(novaPessoa("12345678900")).Error)
+	assert.Error(t, db.Create(novaPessoa("12345678900")).Error)
+	// o marcador dos cadastros só com nome e email pode se repetir
+	assert.NoError(t, db.Create(novaPessoa("n.d")).Error)
+	assert.NoError(t, db.Create(novaPessoa("n.d")).Error)
+}
+
+func TestMigrations_DownRemoveTudo(t *testing.T) {
+	db, m := newMigratedDB(t)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	reverted, err := m.Down(context.Background(), len(statuses))
+	require.NoError(t, err)
+	assert.Equal(t, len(statuses), reverted)
+
+	for _, e := range entities {
+		assert.False(t, db.Migrator().HasTable(e))
+	}
+}
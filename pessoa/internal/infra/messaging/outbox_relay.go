@@ -0,0 +1,120 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/repository"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
+)
+
+// OutboxRelay lê os eventos pendentes do outbox e publica no Kafka.
+// Em caso de falha o evento continua pendente e é publicado novamente na próxima rodada,
+// com intervalo crescente até MaxInterval. Depois de MaxAttempts falhas, ou se não houver
+// producer para o evento, ele é marcado como falhou e deixa de bloquear os seguintes.
+type OutboxRelay struct {
+	Repository  repository.OutboxRepositoryInterface
+	Producers   map[string]KafkaProducerInterface // nome do evento -> producer do tópico
+	BatchSize   int
+	MaxAttempts int
+	Interval    time.Duration
+	MaxInterval time.Duration
+}
+
+func NewOutboxRelay(repo repository.OutboxRepositoryInterface, producers map[string]KafkaProducerInterface) *OutboxRelay {
+	return &OutboxRelay{
+		Repository:  repo,
+		Producers:   producers,
+		BatchSize:   100,
+		MaxAttempts: 10,
+		Interval:    time.Second,
+		MaxInterval: time.Minute,
+	}
+}
+
+// Start executa o relay até o contexto ser cancelado.
+func (r *OutboxRelay) Start(ctx context.Context) {
+	slog.Info("outbox relay iniciado")
+	wait := r.Interval
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("outbox relay finalizado")
+			return
+		case <-time.After(wait):
+		}
+
+		if _, err := r.PublishPending(ctx); err != nil {
+			wait *= 2
+			if wait > r.MaxInterval {
+				wait = r.MaxInterval
+			}
+			slog.Error("erro no outbox relay", "retry_in", wait.String(), logging.Err(err))
+			continue
+		}
+		wait = r.Interval
+	}
+}
+
+// PublishPending publica um lote de eventos pendentes e retorna quantos foram publicados.
+// Para no primeiro erro para manter a ordem dos eventos de um mesmo agregado. O lote é lido
+// e marcado numa transação, para que várias instâncias do relay não publiquem o mesmo evento.
+func (r *OutboxRelay) PublishPending(ctx context.Context) (int, error) {
+	published := 0
+	var publishErr error
+	err := r.Repository.WithTransaction(ctx, func(repo repository.OutboxRepositoryInterface) error {
+		events, err := repo.FindPendingOutboxEvents(r.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, evt := range events {
+			producer, ok := r.Producers[evt.EventName]
+			if !ok {
+				slog.Error("outbox: nenhum producer para o evento", "event", evt.EventName, "event_id", evt.ID.String())
+				if err := repo.MarkOutboxEventDead(evt.ID, fmt.Sprintf("nenhum producer para o evento %s", evt.EventName)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			headers := map[string]string{
+				HeaderEventID:       evt.ID.String(),
+				HeaderEventName:     evt.EventName,
+				HeaderOccurredAt:    evt.CreatedAt.UTC().Format(time.RFC3339Nano),
+				HeaderCorrelationID: evt.CorrelationID,
+			}
+			if evt.RequestID != "" {
+				headers[HeaderRequestID] = evt.RequestID
+			}
+			// a publicação entra no trace da requisição que gravou o evento
+			pubCtx := tracing.WithTraceParent(ctx, evt.TraceParent)
+			err := producer.PublishMessage(pubCtx, evt.AggregateID.String(), evt.Payload, headers)
+			if err != nil {
+				if evt.Attempts+1 >= r.MaxAttempts {
+					slog.Error("outbox: evento excedeu as tentativas de publicação", "event", evt.EventName, "event_id", evt.ID.String(), "attempts", evt.Attempts+1, logging.Err(err))
+					if err := repo.MarkOutboxEventDead(evt.ID, err.Error()); err != nil {
+						return err
+					}
+					continue
+				}
+				// a falha é registrada mesmo interrompendo o lote
+				publishErr = err
+				return repo.MarkOutboxEventFailed(evt.ID, err.Error())
+			}
+
+			if err := repo.MarkOutboxEventPublished(evt.ID); err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return published, publishErr
+}
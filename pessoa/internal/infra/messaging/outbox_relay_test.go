@@ -0,0 +1,137 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/entity"
+	"github.com/ggialluisi/nebula-back/pessoa/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOutboxRepository struct {
+	events    []entity.OutboxEvent
+	published []uuid.UUID
+	failed    []uuid.UUID
+	dead      []uuid.UUID
+}
+
+func (f *fakeOutboxRepository) WithTransaction(ctx context.Context, fn func(repo repository.OutboxRepositoryInterface) error) error {
+	return fn(f)
+}
+
+func (f *fakeOutboxRepository) CreateOutboxEvent(obj *entity.OutboxEvent) error {
+	f.events = append(f.events, *obj)
+	return nil
+}
+
+func (f *fakeOutboxRepository) FindPendingOutboxEvents(limit int) ([]entity.OutboxEvent, error) {
+	return f.events, nil
+}
+
+func (f *fakeOutboxRepository) MarkOutboxEventPublished(objID uuid.UUID) error {
+	f.published = append(f.published, objID)
+	return nil
+}
+
+func (f *fakeOutboxRepository) MarkOutboxEventFailed(objID uuid.UUID, errMsg string) error {
+	f.failed = append(f.failed, objID)
+	return nil
+}
+
+func (f *fakeOutboxRepository) MarkOutboxEventDead(objID uuid.UUID, errMsg string) error {
+	f.dead = append(f.dead, objID)
+	return nil
+}
+
+type fakeProducer struct {
+	failOn   string
+	keys     []string
+	eventIDs []string
+}
+
+func (f *fakeProducer) PublishMessage(ctx context.Context, key, value string, headers map[string]string) error {
+	if key == f.failOn {
+		return errors.New("broker indisponível")
+	}
+	f.keys = append(f.keys, key)
+	f.eventIDs = append(f.eventIDs, headers[HeaderEventID])
+	return nil
+}
+
+func newPendingEvent(t *testing.T) *entity.OutboxEvent {
+	evt, err := entity.NewOutboxEvent("PessoaChanged", uuid.New(), map[string]string{"nome": "Fulano"})
+	assert.NoError(t, err)
+	return evt
+}
+
+func TestOutboxRelay_PublishPending(t *testing.T) {
+	repo := &fakeOutboxRepository{}
+	e1, e2 := newPendingEvent(t), newPendingEvent(t)
+	repo.CreateOutboxEvent(e1)
+	repo.CreateOutboxEvent(e2)
+
+	producer := &fakeProducer{}
+	relay := NewOutboxRelay(repo, map[string]KafkaProducerInterface{"PessoaChanged": producer})
+
+	n, err := relay.PublishPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{e1.AggregateID.String(), e2.AggregateID.String()}, producer.keys)
+	assert.Equal(t, []string{e1.ID.String(), e2.ID.String()}, producer.eventIDs)
+	assert.Equal(t, []uuid.UUID{e1.ID, e2.ID}, repo.published)
+}
+
+func TestOutboxRelay_StopsOnFirstFailure(t *testing.T) {
+	repo := &fakeOutboxRepository{}
+	e1, e2 := newPendingEvent(t), newPendingEvent(t)
+	repo.CreateOutboxEvent(e1)
+	repo.CreateOutboxEvent(e2)
+
+	producer := &fakeProducer{failOn: e1.AggregateID.String()}
+	relay := NewOutboxRelay(repo, map[string]KafkaProducerInterface{"PessoaChanged": producer})
+
+	n, err := relay.PublishPending(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, []uuid.UUID{e1.ID}, repo.failed)
+	assert.Empty(t, repo.published)
+	assert.Empty(t, producer.keys)
+}
+
+func TestOutboxRelay_MarksEventWithoutProducerAsDead(t *testing.T) {
+	repo := &fakeOutboxRepository{}
+	e1, e2 := newPendingEvent(t), newPendingEvent(t)
+	e1.EventName = "EventoSemTopico"
+	repo.CreateOutboxEvent(e1)
+	repo.CreateOutboxEvent(e2)
+
+	producer := &fakeProducer{}
+	relay := NewOutboxRelay(repo, map[string]KafkaProducerInterface{"PessoaChanged": producer})
+
+	n, err := relay.PublishPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []uuid.UUID{e1.ID}, repo.dead)
+	assert.Equal(t, []uuid.UUID{e2.ID}, repo.published)
+}
+
+func TestOutboxRelay_MarksEventAsDeadAfterMaxAttempts(t *testing.T) {
+	repo := &fakeOutboxRepository{}
+	e1, e2 := newPendingEvent(t), newPendingEvent(t)
+	e1.Attempts = 9
+	repo.CreateOutboxEvent(e1)
+	repo.CreateOutboxEvent(e2)
+
+	producer := &fakeProducer{failOn: e1.AggregateID.String()}
+	relay := NewOutboxRelay(repo, map[string]KafkaProducerInterface{"PessoaChanged": producer})
+
+	n, err := relay.PublishPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Empty(t, repo.failed)
+	assert.Equal(t, []uuid.UUID{e1.ID}, repo.dead)
+	assert.Equal(t, []uuid.UUID{e2.ID}, repo.published)
+}
@@ -0,0 +1,7 @@
+// Package platform reúne a infraestrutura compartilhada pelos microserviços:
+// dispatcher de eventos, bootstrap do servidor HTTP e autorização por papel,
+// integração com o Kafka, ciclo de vida (encerramento gracioso), health checks,
+// métricas do Prometheus, logs estruturados com request_id e mascaramento de
+// dados pessoais, tracing OpenTelemetry do HTTP, do GORM e do Kafka, migrações
+// SQL versionadas e listagens paginadas com filtros e ordenação.
+package platform
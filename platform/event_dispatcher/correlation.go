@@ -0,0 +1,37 @@
+package event_dispatcher
+
+import "context"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID devolve um contexto que carrega o id de correlação usado
+// pelos eventos criados a partir dele.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext devolve o id de correlação do contexto, ou "" se não houver.
+func CorrelationIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+type requestIDKey struct{}
+
+// WithRequestID devolve um contexto que carrega o id da requisição (X-Request-ID)
+// que originou o processamento; os eventos e os logs criados a partir dele o repetem.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext devolve o id da requisição do contexto, ou "" se não houver.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
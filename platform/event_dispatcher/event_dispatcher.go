@@ -0,0 +1,235 @@
+package event_dispatcher
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+var ErrHandlerAlreadyRegistered = errors.New("handler already registered")
+var ErrDispatcherClosed = errors.New("event dispatcher closed")
+
+// ErrorHandler recebe os erros dos handlers executados no modo assíncrono.
+type ErrorHandler func(event EventInterface, err error)
+
+type Option func(*EventDispatcher)
+
+// WithMiddleware aplica os middlewares a todos os handlers, na ordem informada
+// (o primeiro é o mais externo).
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(ed *EventDispatcher) {
+		ed.middlewares = append(ed.middlewares, middlewares...)
+	}
+}
+
+// WithAsync executa os handlers em um pool de workers com fila limitada.
+// Dispatch apenas enfileira e bloqueia quando a fila está cheia (ou até o contexto ser cancelado).
+func WithAsync(workers, queueSize int) Option {
+	return func(ed *EventDispatcher) {
+		if workers < 1 {
+			workers = 1
+		}
+		if queueSize < 0 {
+			queueSize = 0
+		}
+		ed.workers = workers
+		ed.queueSize = queueSize
+	}
+}
+
+// WithErrorHandler define quem recebe os erros do modo assíncrono; o padrão apenas registra no log.
+func WithErrorHandler(errorHandler ErrorHandler) Option {
+	return func(ed *EventDispatcher) {
+		ed.errorHandler = errorHandler
+	}
+}
+
+type job struct {
+	ctx     context.Context
+	event   EventInterface
+	handler HandlerFunc
+}
+
+type EventDispatcher struct {
+	mu          sync.RWMutex
+	handlers    map[string][]EventHandlerInterface
+	middlewares []Middleware
+
+	// modo assíncrono
+	workers      int
+	queueSize    int
+	queue        chan job
+	queueMu      sync.RWMutex // protege o envio na fila contra o close em Close
+	workersWg    sync.WaitGroup
+	closeOnce    sync.Once
+	closed       chan struct{}
+	errorHandler ErrorHandler
+}
+
+func NewEventDispatcher(opts ...Option) *EventDispatcher {
+	ed := &EventDispatcher{
+		handlers: make(map[string][]EventHandlerInterface),
+		closed:   make(chan struct{}),
+		errorHandler: func(event EventInterface, err error) {
+			slog.Error("erro no handler do evento", "event", event.GetName(), "event_id", event.GetID(), "error", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(ed)
+	}
+
+	if ed.workers > 0 {
+		ed.queue = make(chan job, ed.queueSize)
+		for i := 0; i < ed.workers; i++ {
+			ed.workersWg.Add(1)
+			go ed.worker()
+		}
+	}
+	return ed
+}
+
+func (ed *EventDispatcher) worker() {
+	defer ed.workersWg.Done()
+	for j := range ed.queue {
+		if err := j.handler(j.ctx, j.event); err != nil {
+			ed.errorHandler(j.event, err)
+		}
+	}
+}
+
+// Dispatch entrega o evento a todos os handlers registrados.
+// No modo síncrono os handlers rodam em paralelo e os erros são agregados com errors.Join;
+// no modo assíncrono os handlers são enfileirados e o retorno indica apenas falha ao enfileirar.
+func (ed *EventDispatcher) Dispatch(ctx context.Context, event EventInterface) error {
+	ed.mu.RLock()
+	handlers := make([]EventHandlerInterface, len(ed.handlers[event.GetName()]))
+	copy(handlers, ed.handlers[event.GetName()])
+	ed.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	if ed.queue != nil {
+		return ed.enqueue(ctx, event, handlers)
+	}
+
+	errs := make([]error, len(handlers))
+	wg := &sync.WaitGroup{}
+	for i, handler := range handlers {
+		wg.Add(1)
+		go func(i int, handler EventHandlerInterface) {
+			defer wg.Done()
+			errs[i] = ed.wrap(handler)(ctx, event)
+		}(i, handler)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (ed *EventDispatcher) enqueue(ctx context.Context, event EventInterface, handlers []EventHandlerInterface) error {
+	ed.queueMu.RLock()
+	defer ed.queueMu.RUnlock()
+
+	// o handler assíncrono não deve ser cancelado junto com a requisição que gerou o evento
+	jobCtx := context.WithoutCancel(ctx)
+	for _, handler := range handlers {
+		select {
+		case <-ed.closed:
+			return ErrDispatcherClosed
+		default:
+		}
+		select {
+		case ed.queue <- job{ctx: jobCtx, event: event, handler: ed.wrap(handler)}:
+		case <-ed.closed:
+			return ErrDispatcherClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// wrap aplica os middlewares ao handler
+func (ed *EventDispatcher) wrap(handler EventHandlerInterface) HandlerFunc {
+	h := HandlerFunc(handler.Handle)
+	for i := len(ed.middlewares) - 1; i >= 0; i-- {
+		h = ed.middlewares[i](h)
+	}
+	return h
+}
+
+// Close para de aceitar eventos e espera os workers terminarem a fila, ou o contexto expirar.
+// No modo síncrono não faz nada.
+func (ed *EventDispatcher) Close(ctx context.Context) error {
+	if ed.queue == nil {
+		return nil
+	}
+	ed.closeOnce.Do(func() {
+		close(ed.closed)
+		// espera os Dispatch em andamento desistirem antes de fechar a fila
+		ed.queueMu.Lock()
+		close(ed.queue)
+		ed.queueMu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ed.workersWg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ed *EventDispatcher) Register(eventName string, handler EventHandlerInterface) error {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+	if _, ok := ed.handlers[eventName]; ok {
+		for _, h := range ed.handlers[eventName] {
+			if h == handler {
+				return ErrHandlerAlreadyRegistered
+			}
+		}
+	}
+	ed.handlers[eventName] = append(ed.handlers[eventName], handler)
+	return nil
+}
+
+func (ed *EventDispatcher) Has(eventName string, handler EventHandlerInterface) bool {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+	if _, ok := ed.handlers[eventName]; ok {
+		for _, h := range ed.handlers[eventName] {
+			if h == handler {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (ed *EventDispatcher) Remove(eventName string, handler EventHandlerInterface) error {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+	if _, ok := ed.handlers[eventName]; ok {
+		for i, h := range ed.handlers[eventName] {
+			if h == handler {
+				ed.handlers[eventName] = append(ed.handlers[eventName][:i], ed.handlers[eventName][i+1:]...)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (ed *EventDispatcher) Clear() {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+	ed.handlers = make(map[string][]EventHandlerInterface)
+}
@@ -1,7 +1,10 @@
 package event_dispatcher
 
 import (
+	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -11,14 +14,31 @@ import (
 )
 
 type TestEvent struct {
+	ID      string
 	Name    string
 	Payload interface{}
 }
 
+func (e *TestEvent) GetID() string {
+	return e.ID
+}
+
 func (e *TestEvent) GetName() string {
 	return e.Name
 }
 
+func (e *TestEvent) GetAggregateID() string {
+	return ""
+}
+
+func (e *TestEvent) GetCorrelationID() string {
+	return ""
+}
+
+func (e *TestEvent) GetRequestID() string {
+	return ""
+}
+
 func (e *TestEvent) GetPayload() interface{} {
 	return e.Payload
 }
@@ -27,15 +47,12 @@ func (e *TestEvent) GetDateTime() time.Time {
 	return time.Now()
 }
 
-func (e *TestEvent) SetPayload(payload interface{}) {
-	e.Payload = payload
-}
-
 type TestEventHandler struct {
 	ID int
 }
 
-func (h *TestEventHandler) Handle(event EventInterface, wg *sync.WaitGroup) {
+func (h *TestEventHandler) Handle(ctx context.Context, event EventInterface) error {
+	return nil
 }
 
 type EventDispatcherTestSuite struct {
@@ -150,28 +167,137 @@ type MockHandler struct {
 	mock.Mock
 }
 
-func (m *MockHandler) Handle(event EventInterface, wg *sync.WaitGroup) {
-	m.Called(event)
-	wg.Done()
+func (m *MockHandler) Handle(ctx context.Context, event EventInterface) error {
+	args := m.Called(event)
+	return args.Error(0)
 }
 
 func (suite *EventDispatcherTestSuite) TestEventDispatch_Dispatch() {
 	eh := &MockHandler{}
-	eh.On("Handle", &suite.event)
+	eh.On("Handle", &suite.event).Return(nil)
 
 	eh2 := &MockHandler{}
-	eh2.On("Handle", &suite.event)
+	eh2.On("Handle", &suite.event).Return(nil)
 
 	suite.eventDispatcher.Register(suite.event.GetName(), eh)
 	suite.eventDispatcher.Register(suite.event.GetName(), eh2)
 
-	suite.eventDispatcher.Dispatch(&suite.event)
+	err := suite.eventDispatcher.Dispatch(context.Background(), &suite.event)
+	suite.Nil(err)
 	eh.AssertExpectations(suite.T())
 	eh2.AssertExpectations(suite.T())
 	eh.AssertNumberOfCalls(suite.T(), "Handle", 1)
 	eh2.AssertNumberOfCalls(suite.T(), "Handle", 1)
 }
 
+func (suite *EventDispatcherTestSuite) TestEventDispatch_DispatchAggregatesErrors() {
+	errA := errors.New("falha A")
+	errB := errors.New("falha B")
+
+	eh := &MockHandler{}
+	eh.On("Handle", &suite.event).Return(errA)
+	eh2 := &MockHandler{}
+	eh2.On("Handle", &suite.event).Return(errB)
+	eh3 := &MockHandler{}
+	eh3.On("Handle", &suite.event).Return(nil)
+
+	suite.eventDispatcher.Register(suite.event.GetName(), eh)
+	suite.eventDispatcher.Register(suite.event.GetName(), eh2)
+	suite.eventDispatcher.Register(suite.event.GetName(), eh3)
+
+	err := suite.eventDispatcher.Dispatch(context.Background(), &suite.event)
+	suite.ErrorIs(err, errA)
+	suite.ErrorIs(err, errB)
+	eh3.AssertNumberOfCalls(suite.T(), "Handle", 1)
+}
+
 func TestSuite(t *testing.T) {
 	suite.Run(t, new(EventDispatcherTestSuite))
 }
+
+func TestEventDispatcher_MiddlewareOrder(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, event EventInterface) error {
+				mu.Lock()
+				calls = append(calls, name)
+				mu.Unlock()
+				return next(ctx, event)
+			}
+		}
+	}
+
+	ed := NewEventDispatcher(WithMiddleware(record("externo"), record("interno")))
+	ed.Register("test", HandlerFunc(func(ctx context.Context, event EventInterface) error {
+		mu.Lock()
+		calls = append(calls, "handler")
+		mu.Unlock()
+		return nil
+	}))
+
+	assert.NoError(t, ed.Dispatch(context.Background(), &TestEvent{Name: "test"}))
+	assert.Equal(t, []string{"externo", "interno", "handler"}, calls)
+}
+
+func TestEventDispatcher_RetryMiddleware(t *testing.T) {
+	var calls int32
+	ed := NewEventDispatcher(WithMiddleware(Retry(3, time.Millisecond)))
+	ed.Register("test", HandlerFunc(func(ctx context.Context, event EventInterface) error {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return errors.New("temporário")
+		}
+		return nil
+	}))
+
+	assert.NoError(t, ed.Dispatch(context.Background(), &TestEvent{Name: "test"}))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestEventDispatcher_TimeoutAndRecoverMiddleware(t *testing.T) {
+	ed := NewEventDispatcher(WithMiddleware(Timeout(10*time.Millisecond), Recover()))
+	ed.Register("lento", HandlerFunc(func(ctx context.Context, event EventInterface) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	ed.Register("panico", HandlerFunc(func(ctx context.Context, event EventInterface) error {
+		panic("boom")
+	}))
+
+	err := ed.Dispatch(context.Background(), &TestEvent{Name: "lento"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = ed.Dispatch(context.Background(), &TestEvent{Name: "panico"})
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestEventDispatcher_AsyncWorkerPool(t *testing.T) {
+	var handled int32
+	var failed int32
+	ed := NewEventDispatcher(
+		WithAsync(2, 4),
+		WithErrorHandler(func(event EventInterface, err error) {
+			atomic.AddInt32(&failed, 1)
+		}),
+	)
+	ed.Register("test", HandlerFunc(func(ctx context.Context, event EventInterface) error {
+		atomic.AddInt32(&handled, 1)
+		if event.GetPayload() == "erro" {
+			return errors.New("falhou")
+		}
+		return nil
+	}))
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, ed.Dispatch(context.Background(), &TestEvent{Name: "test", Payload: "ok"}))
+	}
+	assert.NoError(t, ed.Dispatch(context.Background(), &TestEvent{Name: "test", Payload: "erro"}))
+
+	assert.NoError(t, ed.Close(context.Background()))
+	assert.Equal(t, int32(11), atomic.LoadInt32(&handled))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&failed))
+
+	err := ed.Dispatch(context.Background(), &TestEvent{Name: "test"})
+	assert.ErrorIs(t, err, ErrDispatcherClosed)
+}
@@ -0,0 +1,32 @@
+package event_dispatcher
+
+import (
+	"context"
+	"time"
+)
+
+// EventInterface é um evento de domínio imutável, criado a cada ocorrência.
+// GetDateTime devolve o instante da ocorrência, não o do consumo.
+type EventInterface interface {
+	GetID() string
+	GetName() string
+	GetDateTime() time.Time
+	GetAggregateID() string
+	GetCorrelationID() string
+	GetRequestID() string
+	GetPayload() interface{}
+}
+
+// EventHandlerInterface trata um evento. O erro retornado é repassado pelo dispatcher
+// (modo síncrono) ou entregue ao ErrorHandler (modo assíncrono).
+type EventHandlerInterface interface {
+	Handle(ctx context.Context, event EventInterface) error
+}
+
+type EventDispatcherInterface interface {
+	Register(eventName string, handler EventHandlerInterface) error
+	Dispatch(ctx context.Context, event EventInterface) error
+	Remove(eventName string, handler EventHandlerInterface) error
+	Has(eventName string, handler EventHandlerInterface) bool
+	Clear()
+}
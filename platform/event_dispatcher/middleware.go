@@ -0,0 +1,107 @@
+package event_dispatcher
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+)
+
+// HandlerFunc é a forma funcional de um EventHandlerInterface, usada pelos middlewares.
+type HandlerFunc func(ctx context.Context, event EventInterface) error
+
+func (f HandlerFunc) Handle(ctx context.Context, event EventInterface) error {
+	return f(ctx, event)
+}
+
+// Middleware envolve a execução de um handler.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Logging registra a duração e o erro de cada execução de handler.
+func Logging(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event EventInterface) error {
+			start := time.Now()
+			err := next(ctx, event)
+			attrs := []any{
+				"event", event.GetName(),
+				"event_id", event.GetID(),
+				"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				logger.ErrorContext(ctx, "handler do evento falhou", append(attrs, "error", err)...)
+				return err
+			}
+			logger.DebugContext(ctx, "handler do evento executado", attrs...)
+			return nil
+		}
+	}
+}
+
+// Retry executa o handler até attempts vezes, dobrando a espera a cada falha.
+func Retry(attempts int, backoff time.Duration) Middleware {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event EventInterface) error {
+			wait := backoff
+			var err error
+			for attempt := 1; attempt <= attempts; attempt++ {
+				err = next(ctx, event)
+				if err == nil || attempt == attempts {
+					break
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(wait):
+				}
+				wait *= 2
+			}
+			return err
+		}
+	}
+}
+
+// Timeout limita a duração de cada execução do handler. O handler deve respeitar o contexto;
+// se não respeitar, o erro de timeout é retornado sem esperar o handler terminar.
+// O handler roda em outra goroutine: Recover deve vir depois de Timeout para cobrir panics.
+func Timeout(d time.Duration) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event EventInterface) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			done := make(chan error, 1)
+			go func() {
+				done <- next(ctx, event)
+			}()
+			select {
+			case err := <-done:
+				return err
+			case <-ctx.Done():
+				return fmt.Errorf("evento %s: %w", event.GetName(), ctx.Err())
+			}
+		}
+	}
+}
+
+// Recover transforma um panic do handler em erro.
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event EventInterface) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					slog.ErrorContext(ctx, "panic no handler do evento", "event", event.GetName(), "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+					err = fmt.Errorf("panic no handler do evento %s: %v", event.GetName(), r)
+				}
+			}()
+			return next(ctx, event)
+		}
+	}
+}
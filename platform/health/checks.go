@@ -0,0 +1,21 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Pinger é satisfeito por *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+var _ Pinger = &sql.DB{}
+
+// Database verifica a conexão com o banco.
+func Database(name string, db Pinger) Check {
+	return Check{
+		Name:  name,
+		Probe: db.PingContext,
+	}
+}
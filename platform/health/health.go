@@ -0,0 +1,168 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Check é uma dependência verificada pela prontidão do serviço.
+type Check struct {
+	Name  string
+	Probe func(ctx context.Context) error
+	// Details, opcional, acrescenta informações ao resultado (ex.: lag de blocos)
+	Details func() interface{}
+}
+
+// CheckResult é o resultado de uma verificação.
+type CheckResult struct {
+	Status    string      `json:"status"`
+	LatencyMS int64       `json:"latency_ms"`
+	Error     string      `json:"error,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// Report é a resposta JSON de /healthz e /readyz.
+type Report struct {
+	Status  string                 `json:"status"`
+	Service string                 `json:"service"`
+	Uptime  string                 `json:"uptime"`
+	Checks  map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Health expõe a vivacidade (/healthz) e a prontidão (/readyz) do serviço.
+type Health struct {
+	Service string
+	// Timeout limita cada verificação de prontidão
+	Timeout time.Duration
+
+	mu      sync.RWMutex
+	checks  []Check
+	started time.Time
+}
+
+func New(service string, timeout time.Duration) *Health {
+	return &Health{
+		Service: service,
+		Timeout: timeout,
+		started: time.Now(),
+	}
+}
+
+// Add registra uma verificação de prontidão.
+func (h *Health) Add(checks ...Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks = append(h.checks, checks...)
+}
+
+// Check executa as verificações em paralelo, cada uma limitada a Timeout.
+func (h *Health) Check(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := append([]Check(nil), h.checks...)
+	h.mu.RUnlock()
+
+	report := h.report(StatusOK)
+	report.Checks = make(map[string]CheckResult, len(checks))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c Check) {
+			defer wg.Done()
+			result := h.run(ctx, c)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.Name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusUnavailable
+			}
+		}(c)
+	}
+	wg.Wait()
+	return report
+}
+
+// run executa uma verificação; uma sonda que ignora o contexto é abandonada no timeout.
+func (h *Health) run(ctx context.Context, c Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+
+	inicio := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		errCh <- c.Probe(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = fmt.Errorf("sem resposta em %s", h.Timeout)
+	}
+
+	result := CheckResult{
+		Status:    StatusOK,
+		LatencyMS: time.Since(inicio).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusUnavailable
+		result.Error = err.Error()
+	}
+	if c.Details != nil {
+		result.Details = c.Details()
+	}
+	return result
+}
+
+// Liveness responde 200 enquanto o processo estiver de pé; não consulta dependências,
+// para o orquestrador não reiniciar o serviço por uma falha do banco ou do Kafka.
+func (h *Health) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.report(StatusOK))
+}
+
+// Readiness responde 200 se todas as dependências estão disponíveis, ou 503 com o
+// resultado de cada verificação.
+func (h *Health) Readiness(w http.ResponseWriter, r *http.Request) {
+	report := h.Check(r.Context())
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, report)
+}
+
+// Register adiciona GET /healthz e GET /readyz ao mux.
+func (h *Health) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.Liveness)
+	mux.HandleFunc("GET /readyz", h.Readiness)
+}
+
+func (h *Health) report(status string) Report {
+	return Report{
+		Status:  status,
+		Service: h.Service,
+		Uptime:  time.Since(h.started).Round(time.Second).String(),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
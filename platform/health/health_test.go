@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, h *Health, path string) (int, Report) {
+	mux := http.NewServeMux()
+	h.Register(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var report Report
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	return rec.Code, report
+}
+
+func TestReadiness_TodasAsDependenciasDisponiveis(t *testing.T) {
+	h := New("curso", time.Second)
+	h.Add(Check{Name: "database", Probe: func(ctx context.Context) error { return nil }})
+	h.Add(Check{
+		Name:    "chain",
+		Probe:   func(ctx context.Context) error { return nil },
+		Details: func() interface{} { return map[string]int{"lag": 3} },
+	})
+
+	code, report := get(t, h, "/readyz")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Equal(t, "curso", report.Service)
+	assert.Equal(t, StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, map[string]interface{}{"lag": float64(3)}, report.Checks["chain"].Details)
+}
+
+func TestReadiness_DependenciaIndisponivelRetorna503(t *testing.T) {
+	h := New("curso", time.Second)
+	h.Add(
+		Check{Name: "database", Probe: func(ctx context.Context) error { return nil }},
+		Check{Name: "kafka", Probe: func(ctx context.Context) error { return errors.New("broker fora do ar") }},
+	)
+
+	code, report := get(t, h, "/readyz")
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.Equal(t, StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, StatusUnavailable, report.Checks["kafka"].Status)
+	assert.Equal(t, "broker fora do ar", report.Checks["kafka"].Error)
+}
+
+func TestReadiness_SondaLentaEstouraOTimeout(t *testing.T) {
+	h := New("pessoa", 20*time.Millisecond)
+	h.Add(Check{Name: "lenta", Probe: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}})
+
+	inicio := time.Now()
+	code, report := get(t, h, "/readyz")
+
+	assert.Less(t, time.Since(inicio), 500*time.Millisecond)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, report.Checks["lenta"].Error, "sem resposta")
+}
+
+func TestLiveness_NaoConsultaDependencias(t *testing.T) {
+	h := New("curso", time.Second)
+	h.Add(Check{Name: "database", Probe: func(ctx context.Context) error { return errors.New("fora do ar") }})
+
+	code, report := get(t, h, "/healthz")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Empty(t, report.Checks)
+}
@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Config reúne o acesso ao cluster Kafka. Fora do ambiente local a conexão usa
+// SASL/PLAIN sobre TLS (Confluent Cloud).
+type Config struct {
+	Brokers  []string
+	Username string
+	Password string
+	Local    bool
+}
+
+// ConfigFromEnv lê KAFKA_BROKERS (separados por vírgula), KAFKA_KEY, KAFKA_SECRET e ENV.
+func ConfigFromEnv() Config {
+	return Config{
+		Brokers:  strings.Split(os.Getenv("KAFKA_BROKERS"), ","),
+		Username: os.Getenv("KAFKA_KEY"),
+		Password: os.Getenv("KAFKA_SECRET"),
+		Local:    os.Getenv("ENV") == "LOCAL",
+	}
+}
+
+// NewSaramaConfig cria a configuração base do Sarama, com autenticação e timeouts de rede.
+func NewSaramaConfig(cfg Config) *sarama.Config {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_6_0_0 // compatível com brokers Confluent Cloud
+
+	if cfg.Local {
+		config.Net.SASL.Enable = false
+		config.Net.TLS.Enable = false
+	} else {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.User = cfg.Username
+		config.Net.SASL.Password = cfg.Password
+		config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = &tls.Config{}
+	}
+
+	// Timeouts de rede recomendados
+	config.Net.DialTimeout = 10 * time.Second
+	config.Net.ReadTimeout = 10 * time.Second
+	config.Net.WriteTimeout = 10 * time.Second
+
+	return config
+}
+
+// NewProducerConfig acrescenta à configuração base as garantias do producer:
+// confirmação de todas as réplicas e envio idempotente.
+func NewProducerConfig(cfg Config) *sarama.Config {
+	config := NewSaramaConfig(cfg)
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = 5
+	config.Producer.Idempotent = true
+	config.Net.MaxOpenRequests = 1
+	return config
+}
+
+// NewConsumerConfig acrescenta à configuração base o retorno dos erros de consumo.
+func NewConsumerConfig(cfg Config) *sarama.Config {
+	config := NewSaramaConfig(cfg)
+	config.Consumer.Return.Errors = true
+	return config
+}
+
+// NewSyncProducer cria o SyncProducer compartilhado pelos producers de cada tópico.
+func NewSyncProducer(cfg Config) (sarama.SyncProducer, error) {
+	return sarama.NewSyncProducer(cfg.Brokers, NewProducerConfig(cfg))
+}
@@ -0,0 +1,122 @@
+package kafka
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/metrics"
+)
+
+// Consumer liga um tópico ao handler que processa as suas mensagens.
+type Consumer struct {
+	Topic   string
+	GroupID string
+	Handler sarama.ConsumerGroupHandler
+}
+
+// RunConsumers abre um ConsumerGroup para cada consumidor e consome até o contexto
+// ser cancelado. Erros de consumo são registrados e o consumo é retomado após uma pausa.
+// Ao sair, o Close de cada grupo confirma os offsets marcados e deixa o grupo.
+// O estado de cada consumidor é registrado em status, se informado.
+func RunConsumers(ctx context.Context, cfg Config, consumers []Consumer, status *ConsumerStatus) {
+	var wg sync.WaitGroup
+
+	for _, consumer := range consumers {
+		status.registrar(consumer)
+		wg.Add(1)
+		go func(c Consumer) {
+			defer wg.Done()
+
+			client, err := sarama.NewConsumerGroup(cfg.Brokers, c.GroupID, NewConsumerConfig(cfg))
+			if err != nil {
+				slog.Error("erro ao criar ConsumerGroup", "group", c.GroupID, "topic", c.Topic, logging.Err(err))
+				os.Exit(1)
+			}
+			defer func() {
+				if err := client.Close(); err != nil {
+					slog.Error("erro ao fechar ConsumerGroup", "group", c.GroupID, logging.Err(err))
+				}
+			}()
+
+			// erros da sessão em andamento (ex.: commit de offsets); a sessão continua
+			go func() {
+				for err := range client.Errors() {
+					slog.Warn("erro no ConsumerGroup", "group", c.GroupID, "topic", c.Topic, logging.Err(err))
+				}
+			}()
+
+			handler := sarama.ConsumerGroupHandler(&groupHandler{ConsumerGroupHandler: c.Handler, status: status, consumer: c})
+			for ctx.Err() == nil {
+				err := client.Consume(ctx, []string{c.Topic}, handler)
+				if err != nil {
+					slog.Error("erro no ConsumerGroup, nova tentativa em 5s", "group", c.GroupID, "topic", c.Topic, logging.Err(err))
+					status.falhou(c, err)
+					metrics.KafkaConsumeErrors.WithLabelValues(c.Topic, "session").Inc()
+					select {
+					case <-ctx.Done():
+					case <-time.After(5 * time.Second):
+					}
+				}
+			}
+		}(consumer)
+	}
+
+	wg.Wait()
+}
+
+// groupHandler registra no ConsumerStatus o início e o fim de cada sessão do grupo e,
+// para cada mensagem recebida, a contagem e o lag da partição.
+type groupHandler struct {
+	sarama.ConsumerGroupHandler
+	status   *ConsumerStatus
+	consumer Consumer
+}
+
+func (h *groupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	if err := h.ConsumerGroupHandler.Setup(session); err != nil {
+		return err
+	}
+	h.status.sessaoIniciada(h.consumer)
+	return nil
+}
+
+func (h *groupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	h.status.sessaoEncerrada(h.consumer)
+	return h.ConsumerGroupHandler.Cleanup(session)
+}
+
+func (h *groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	consumed := metrics.KafkaConsumed.WithLabelValues(claim.Topic(), h.consumer.GroupID)
+	lag := metrics.KafkaConsumerLag.WithLabelValues(claim.Topic(), h.consumer.GroupID, strconv.Itoa(int(claim.Partition())))
+
+	messages := make(chan *sarama.ConsumerMessage)
+	go func() {
+		defer close(messages)
+		for msg := range claim.Messages() {
+			consumed.Inc()
+			lag.Set(float64(claim.HighWaterMarkOffset() - msg.Offset - 1))
+			select {
+			case messages <- msg:
+			case <-session.Context().Done():
+				return
+			}
+		}
+	}()
+	return h.ConsumerGroupHandler.ConsumeClaim(session, &meteredClaim{ConsumerGroupClaim: claim, messages: messages})
+}
+
+// meteredClaim entrega ao handler as mensagens já contabilizadas por groupHandler.
+type meteredClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *meteredClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
@@ -0,0 +1,136 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ggialluisi/nebula-back/platform/health"
+)
+
+// NewClient cria o client Sarama do producer; o mesmo client é consultado pela
+// verificação de prontidão do Kafka.
+func NewClient(cfg Config) (sarama.Client, error) {
+	return sarama.NewClient(cfg.Brokers, NewProducerConfig(cfg))
+}
+
+// NewSyncProducerFromClient cria o SyncProducer sobre um client existente. Fechar o
+// producer não fecha o client.
+func NewSyncProducerFromClient(client sarama.Client) (sarama.SyncProducer, error) {
+	return sarama.NewSyncProducerFromClient(client)
+}
+
+// BrokerCheck verifica se o cluster responde, renovando os metadados do controller.
+func BrokerCheck(client sarama.Client) health.Check {
+	return health.Check{
+		Name: "kafka",
+		Probe: func(ctx context.Context) error {
+			if client.Closed() {
+				return errors.New("client Kafka fechado")
+			}
+			_, err := client.RefreshController()
+			return err
+		},
+	}
+}
+
+type consumerState struct {
+	Topic   string    `json:"topic"`
+	GroupID string    `json:"group_id"`
+	Ativo   bool      `json:"ativo"`
+	Erro    string    `json:"erro,omitempty"`
+	Desde   time.Time `json:"desde"`
+	iniciou bool
+}
+
+// ConsumerStatus acompanha o estado dos consumer groups iniciados por RunConsumers.
+type ConsumerStatus struct {
+	mu     sync.Mutex
+	states map[string]*consumerState
+}
+
+func NewConsumerStatus() *ConsumerStatus {
+	return &ConsumerStatus{states: make(map[string]*consumerState)}
+}
+
+// Check falha enquanto algum consumidor não entrou no grupo ou teve erro desde a
+// última sessão. O intervalo entre sessões de um rebalanceamento não é falha.
+func (s *ConsumerStatus) Check() health.Check {
+	return health.Check{
+		Name: "kafka_consumers",
+		Probe: func(ctx context.Context) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			var errs []error
+			for _, key := range s.keys() {
+				st := s.states[key]
+				switch {
+				case st.Erro != "":
+					errs = append(errs, fmt.Errorf("%s: %s", key, st.Erro))
+				case !st.iniciou:
+					errs = append(errs, fmt.Errorf("%s: aguardando entrada no grupo", key))
+				}
+			}
+			return errors.Join(errs...)
+		},
+		Details: func() interface{} {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			states := make([]consumerState, 0, len(s.states))
+			for _, key := range s.keys() {
+				states = append(states, *s.states[key])
+			}
+			return states
+		},
+	}
+}
+
+func (s *ConsumerStatus) keys() []string {
+	keys := make([]string, 0, len(s.states))
+	for key := range s.states {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *ConsumerStatus) update(c Consumer, fn func(st *consumerState)) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := c.GroupID + "/" + c.Topic
+	st, ok := s.states[key]
+	if !ok {
+		st = &consumerState{Topic: c.Topic, GroupID: c.GroupID, Desde: time.Now()}
+		s.states[key] = st
+	}
+	fn(st)
+}
+
+func (s *ConsumerStatus) registrar(c Consumer) {
+	s.update(c, func(st *consumerState) {})
+}
+
+func (s *ConsumerStatus) sessaoIniciada(c Consumer) {
+	s.update(c, func(st *consumerState) {
+		st.Ativo, st.iniciou, st.Erro, st.Desde = true, true, "", time.Now()
+	})
+}
+
+func (s *ConsumerStatus) sessaoEncerrada(c Consumer) {
+	s.update(c, func(st *consumerState) {
+		st.Ativo, st.Desde = false, time.Now()
+	})
+}
+
+func (s *ConsumerStatus) falhou(c Consumer, err error) {
+	s.update(c, func(st *consumerState) {
+		st.Erro = err.Error()
+	})
+}
@@ -0,0 +1,95 @@
+package kafka
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/IBM/sarama"
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/ggialluisi/nebula-back/platform/metrics"
+	"github.com/ggialluisi/nebula-back/platform/tracing"
+)
+
+// HeaderRequestID leva às mensagens o X-Request-ID da requisição que as originou.
+const HeaderRequestID = "request_id"
+
+// Producer publica mensagens num tópico usando um SyncProducer compartilhado.
+type Producer struct {
+	Producer sarama.SyncProducer
+	Topic    string
+}
+
+func NewProducer(producer sarama.SyncProducer, topic string) *Producer {
+	if topic == "" {
+		slog.Error("tópico Kafka deve ser especificado")
+		os.Exit(1)
+	}
+
+	return &Producer{
+		Producer: producer,
+		Topic:    topic,
+	}
+}
+
+// ForTopic retorna um producer para outro tópico reaproveitando a conexão com o broker.
+func (p *Producer) ForTopic(topic string) *Producer {
+	return NewProducer(p.Producer, topic)
+}
+
+// PublishMessage publica no tópico do producer. O request_id e o contexto do trace
+// vão nos headers quando o chamador não os informa.
+func (p *Producer) PublishMessage(ctx context.Context, key, value string, headers map[string]string) error {
+	return p.send(ctx, p.Topic, key, sarama.StringEncoder(value), headers)
+}
+
+// PublishToTopic publica no tópico informado, com headers; usado pela DLQ e pelo replay.
+func (p *Producer) PublishToTopic(ctx context.Context, topic, key string, value []byte, headers map[string]string) error {
+	return p.send(ctx, topic, key, sarama.ByteEncoder(value), headers)
+}
+
+func (p *Producer) send(ctx context.Context, topic, key string, value sarama.Encoder, headers map[string]string) error {
+	ctx, span := tracing.StartProducer(ctx, topic)
+	msg := &sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.StringEncoder(key),
+		Value:   value,
+		Headers: recordHeaders(ctx, headers),
+	}
+
+	partition, offset, err := p.Producer.SendMessage(msg)
+	tracing.End(span, err)
+	if err != nil {
+		metrics.KafkaPublishErrors.WithLabelValues(topic).Inc()
+		slog.ErrorContext(ctx, "erro ao publicar mensagem Kafka", "topic", topic, "key", key, logging.Err(err))
+		return err
+	}
+	metrics.KafkaPublished.WithLabelValues(topic).Inc()
+
+	slog.DebugContext(ctx, "mensagem publicada", "topic", topic, "key", key, "partition", partition, "offset", offset)
+	return nil
+}
+
+func (p *Producer) Close() error {
+	return p.Producer.Close()
+}
+
+func recordHeaders(ctx context.Context, headers map[string]string) []sarama.RecordHeader {
+	var records []sarama.RecordHeader
+	for k, v := range headers {
+		records = append(records, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+	if _, ok := headers[HeaderRequestID]; !ok {
+		if requestID := event_dispatcher.RequestIDFromContext(ctx); requestID != "" {
+			records = append(records, sarama.RecordHeader{Key: []byte(HeaderRequestID), Value: []byte(requestID)})
+		}
+	}
+	// traceparent do span de publicação: o consumidor continua o mesmo trace
+	for k, v := range tracing.Inject(ctx) {
+		if _, ok := headers[k]; !ok {
+			records = append(records, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+		}
+	}
+	return records
+}
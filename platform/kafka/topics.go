@@ -0,0 +1,37 @@
+package kafka
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/IBM/sarama"
+)
+
+// EnsureTopics cria os tópicos que ainda não existem, com uma partição e uma réplica.
+// Usado no ambiente local; em produção os tópicos são provisionados fora do serviço.
+func EnsureTopics(cfg Config, topics []string) error {
+	admin, err := sarama.NewClusterAdmin(cfg.Brokers, NewSaramaConfig(cfg))
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	for _, topic := range topics {
+		err := admin.CreateTopic(topic, &sarama.TopicDetail{
+			NumPartitions:     1,
+			ReplicationFactor: 1,
+		}, false)
+		if err != nil {
+			// O Sarama retorna TopicError embutido no erro
+			var topicErr *sarama.TopicError
+			if errors.As(err, &topicErr) && topicErr.Err == sarama.ErrTopicAlreadyExists {
+				slog.Debug("tópico Kafka já existe", "topic", topic)
+				continue
+			}
+			return err
+		}
+		slog.Info("tópico Kafka criado", "topic", topic)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Config define os prazos do encerramento.
+type Config struct {
+	// ShutdownTimeout é o prazo total para os workers pararem e os hooks de
+	// encerramento executarem depois do sinal.
+	ShutdownTimeout time.Duration
+	// DrainTimeout é o prazo para concluir o trabalho em andamento, como as
+	// requisições HTTP abertas, antes de interrompê-lo.
+	DrainTimeout time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		ShutdownTimeout: 30 * time.Second,
+		DrainTimeout:    15 * time.Second,
+	}
+}
+
+// ConfigFromEnv lê SHUTDOWN_TIMEOUT e SHUTDOWN_DRAIN_TIMEOUT (ex.: "30s"),
+// mantendo o padrão para valores ausentes ou inválidos.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	if v, err := parseDuration(os.Getenv("SHUTDOWN_TIMEOUT")); err == nil && v > 0 {
+		cfg.ShutdownTimeout = v
+	}
+	if v, err := parseDuration(os.Getenv("SHUTDOWN_DRAIN_TIMEOUT")); err == nil && v > 0 {
+		cfg.DrainTimeout = v
+	}
+	return cfg
+}
+
+// parseDuration aceita durações do Go ("30s") ou segundos inteiros ("30").
+func parseDuration(s string) (time.Duration, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	return time.ParseDuration(s)
+}
+
+type hook struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Lifecycle coordena a execução e o encerramento de um serviço: os workers rodam
+// até o contexto ser cancelado (SIGINT/SIGTERM ou falha de um worker) e, depois que
+// eles param, os hooks de encerramento executam na ordem inversa do registro.
+type Lifecycle struct {
+	Config Config
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	hooks   []hook
+	errs    []error
+	signals []os.Signal
+}
+
+func New(cfg Config) *Lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Lifecycle{
+		Config:  cfg,
+		ctx:     ctx,
+		cancel:  cancel,
+		signals: []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+	}
+}
+
+// Context é cancelado quando o encerramento começa.
+func (l *Lifecycle) Context() context.Context {
+	return l.ctx
+}
+
+// Go executa um worker em background. O worker deve retornar quando o contexto for
+// cancelado; um erro antes disso inicia o encerramento do serviço.
+func (l *Lifecycle) Go(name string, run func(ctx context.Context) error) {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		err := run(l.ctx)
+		if err == nil || (l.ctx.Err() != nil && errors.Is(err, context.Canceled)) {
+			slog.Info("worker finalizado", "worker", name)
+			return
+		}
+		l.addError(fmt.Errorf("%s: %w", name, err))
+		slog.Error("worker falhou, encerrando o serviço", "worker", name, "error", err)
+		l.cancel()
+	}()
+}
+
+// OnStop registra um hook de encerramento. Os hooks executam depois que os workers
+// param, do último registrado para o primeiro, como um defer.
+func (l *Lifecycle) OnStop(name string, stop func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook{name: name, stop: stop})
+}
+
+// Ready retorna erro depois que o encerramento começou; serve de verificação de
+// prontidão para o serviço sair do balanceamento enquanto encerra.
+func (l *Lifecycle) Ready(ctx context.Context) error {
+	if l.ctx.Err() != nil {
+		return errors.New("serviço em encerramento")
+	}
+	return nil
+}
+
+// Shutdown inicia o encerramento sem esperar por um sinal.
+func (l *Lifecycle) Shutdown() {
+	l.cancel()
+}
+
+// Run bloqueia até receber SIGINT/SIGTERM, Shutdown ser chamado ou um worker falhar.
+// Então espera os workers e executa os hooks dentro de ShutdownTimeout, retornando
+// os erros dos workers e dos hooks.
+func (l *Lifecycle) Run() error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, l.signals...)
+	defer signal.Stop(sigCh)
+
+	select {
+	case sig := <-sigCh:
+		slog.Info("sinal recebido, encerrando", "signal", sig.String())
+	case <-l.ctx.Done():
+		slog.Info("encerrando")
+	}
+	l.cancel()
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), l.Config.ShutdownTimeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-stopCtx.Done():
+		l.addError(errors.New("workers não finalizaram dentro do prazo de encerramento"))
+	}
+
+	l.mu.Lock()
+	hooks := append([]hook(nil), l.hooks...)
+	l.mu.Unlock()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		if err := h.stop(stopCtx); err != nil {
+			l.addError(fmt.Errorf("%s: %w", h.name, err))
+			slog.Error("erro ao encerrar", "hook", h.name, "error", err)
+			continue
+		}
+		slog.Info("encerrado", "hook", h.name)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return errors.Join(l.errs...)
+}
+
+func (l *Lifecycle) addError(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errs = append(l.errs, err)
+}
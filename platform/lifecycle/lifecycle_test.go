@@ -0,0 +1,84 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycle_SinalParaWorkersEExecutaHooksEmOrdemInversa(t *testing.T) {
+	lc := New(Config{ShutdownTimeout: time.Second})
+
+	var ordem []string
+	lc.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		ordem = append(ordem, "worker")
+		return ctx.Err()
+	})
+	lc.OnStop("db", func(ctx context.Context) error {
+		ordem = append(ordem, "db")
+		return nil
+	})
+	lc.OnStop("producer", func(ctx context.Context) error {
+		ordem = append(ordem, "producer")
+		return nil
+	})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	}()
+
+	assert.NoError(t, lc.Run())
+	assert.Equal(t, []string{"worker", "producer", "db"}, ordem)
+}
+
+func TestLifecycle_FalhaDeWorkerEncerraERetornaErro(t *testing.T) {
+	lc := New(Config{ShutdownTimeout: time.Second})
+	stopped := false
+	lc.Go("http", func(ctx context.Context) error {
+		return errors.New("porta em uso")
+	})
+	lc.OnStop("db", func(ctx context.Context) error {
+		stopped = true
+		return nil
+	})
+
+	err := lc.Run()
+
+	assert.ErrorContains(t, err, "http: porta em uso")
+	assert.True(t, stopped)
+}
+
+func TestLifecycle_WorkerLentoEstouraOPrazo(t *testing.T) {
+	lc := New(Config{ShutdownTimeout: 50 * time.Millisecond})
+	lc.Go("lento", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+	var hookCtxErr error
+	lc.OnStop("db", func(ctx context.Context) error {
+		hookCtxErr = ctx.Err()
+		return nil
+	})
+
+	lc.Shutdown()
+	err := lc.Run()
+
+	assert.ErrorContains(t, err, "prazo de encerramento")
+	assert.ErrorIs(t, hookCtxErr, context.DeadlineExceeded)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("SHUTDOWN_TIMEOUT", "45")
+	t.Setenv("SHUTDOWN_DRAIN_TIMEOUT", "5s")
+
+	cfg := ConfigFromEnv()
+
+	assert.Equal(t, 45*time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, 5*time.Second, cfg.DrainTimeout)
+}
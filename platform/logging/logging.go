@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Setup configura o slog como logger padrão do serviço: JSON em stdout, nível lido de
+// LOG_LEVEL (debug, info, warn, error; padrão info), atributo service, os ids da
+// requisição e de correlação do contexto e o mascaramento de dados pessoais.
+// Chamadas restantes ao pacote log passam a sair pelo mesmo handler.
+func Setup(service string) *slog.Logger {
+	logger := slog.New(NewHandler(os.Stdout, LevelFromEnv())).With("service", service)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// LevelFromEnv lê o nível de LOG_LEVEL.
+func LevelFromEnv() slog.Level {
+	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// NewHandler cria o handler JSON com mascaramento e ids do contexto.
+func NewHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	return &contextHandler{
+		Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{
+			Level:       level,
+			ReplaceAttr: redactAttr,
+		}),
+	}
+}
+
+// contextHandler acrescenta a cada registro o request_id, o correlation_id e o
+// trace_id/span_id do contexto.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := event_dispatcher.RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if id := event_dispatcher.CorrelationIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("correlation_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// Err é o atributo padrão para erros.
+func Err(err error) slog.Attr {
+	return slog.Any("error", err)
+}
+
+// Fatal registra o erro e encerra o processo, como log.Fatal.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
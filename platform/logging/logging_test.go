@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	return entry
+}
+
+func TestHandler_AcrescentaIdsDoContexto(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, slog.LevelInfo))
+
+	ctx := event_dispatcher.WithRequestID(context.Background(), "req-1")
+	ctx = event_dispatcher.WithCorrelationID(ctx, "corr-1")
+	logger.InfoContext(ctx, "curso salvo", "curso_id", "42")
+
+	entry := decode(t, &buf)
+	assert.Equal(t, "curso salvo", entry["msg"])
+	assert.Equal(t, "req-1", entry["request_id"])
+	assert.Equal(t, "corr-1", entry["correlation_id"])
+	assert.Equal(t, "42", entry["curso_id"])
+}
+
+func TestHandler_MascaraCamposSensiveis(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, slog.LevelInfo))
+
+	logger.Info("pessoa salva",
+		"email", "maria@exemplo.com",
+		slog.Group("pessoa", slog.String("documento", "12345678900"), slog.String("nome", "Maria")),
+		"telefones", []string{"11999990000"},
+	)
+
+	entry := decode(t, &buf)
+	assert.Equal(t, "m***@exemplo.com", entry["email"])
+	pessoa := entry["pessoa"].(map[string]interface{})
+	assert.Equal(t, "***00", pessoa["documento"])
+	assert.Equal(t, "Maria", pessoa["nome"])
+	assert.Equal(t, "[redacted]", entry["telefones"])
+}
+
+func TestPayload_MascaraCamposSensiveisEmQualquerNivel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, slog.LevelInfo))
+
+	logger.Info("mensagem", Payload([]byte(`{
+		"id": "1",
+		"nome": "Maria",
+		"documento": "12345678900",
+		"emails": [{"email": "maria@exemplo.com"}],
+		"endereco": {"logradouro": "Rua A", "telefone_contato": "1133334444"}
+	}`)))
+
+	payload := decode(t, &buf)["payload"].(map[string]interface{})
+	assert.Equal(t, "Maria", payload["nome"])
+	assert.Equal(t, "***00", payload["documento"])
+	assert.Equal(t, "[redacted]", payload["emails"])
+	endereco := payload["endereco"].(map[string]interface{})
+	assert.Equal(t, "Rua A", endereco["logradouro"])
+	assert.Equal(t, "***44", endereco["telefone_contato"])
+	assert.NotContains(t, buf.String(), "maria@exemplo.com")
+}
+
+func TestRequestID_PropagaOuGera(t *testing.T) {
+	var recebido string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recebido = event_dispatcher.RequestIDFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderRequestID, "abc-123")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, "abc-123", recebido)
+	assert.Equal(t, "abc-123", rec.Header().Get(HeaderRequestID))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderRequestID, "valor inválido\n")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Len(t, recebido, 32)
+	assert.Equal(t, recebido, rec.Header().Get(HeaderRequestID))
+}
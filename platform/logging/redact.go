@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// campos com dados pessoais: qualquer chave que contenha um destes nomes é mascarada
+var sensitiveKeys = []string{"documento", "email", "telefone"}
+
+const redacted = "[redacted]"
+
+// IsSensitive informa se a chave de um atributo ou campo JSON guarda dados pessoais.
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask mascara um valor sensível mantendo o mínimo para identificá-lo num log:
+// a inicial e o domínio de um email, os dois últimos caracteres dos demais.
+func Mask(value string) string {
+	if value == "" {
+		return ""
+	}
+	if at := strings.LastIndex(value, "@"); at > 0 {
+		return value[:1] + "***" + value[at:]
+	}
+	if len(value) <= 4 {
+		return "***"
+	}
+	return "***" + value[len(value)-2:]
+}
+
+// redactAttr mascara os atributos sensíveis, inclusive dentro de grupos.
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if !IsSensitive(a.Key) {
+		return a
+	}
+	if a.Value.Kind() == slog.KindString {
+		return slog.String(a.Key, Mask(a.Value.String()))
+	}
+	return slog.String(a.Key, redacted)
+}
+
+// Payload devolve um atributo com o conteúdo JSON de uma mensagem, com os campos
+// sensíveis mascarados em qualquer nível. Conteúdo que não é JSON não é registrado.
+func Payload(data []byte) slog.Attr {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return slog.String("payload", fmt.Sprintf("[não JSON: %d bytes]", len(data)))
+	}
+	return slog.Any("payload", redactValue(v))
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			if IsSensitive(k) {
+				out[k] = redactSensitive(item)
+				continue
+			}
+			out[k] = redactValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = redactValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// redactSensitive mascara o valor de um campo sensível; listas e objetos (ex.: os
+// emails de uma pessoa) são mascarados por inteiro.
+func redactSensitive(v interface{}) interface{} {
+	if s, ok := v.(string); ok {
+		return Mask(s)
+	}
+	if v == nil {
+		return nil
+	}
+	return redacted
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Métricas compartilhadas pelos serviços, registradas no registry padrão do Prometheus
+// (que já inclui as métricas do runtime Go e do processo).
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Requisições HTTP atendidas, por rota e status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duração das requisições HTTP, por rota.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	KafkaPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_messages_published_total",
+		Help: "Mensagens publicadas no Kafka, por tópico.",
+	}, []string{"topic"})
+
+	KafkaPublishErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_publish_errors_total",
+		Help: "Falhas ao publicar no Kafka, por tópico.",
+	}, []string{"topic"})
+
+	KafkaConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_messages_consumed_total",
+		Help: "Mensagens recebidas dos consumer groups, por tópico.",
+	}, []string{"topic", "group"})
+
+	KafkaConsumeErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_consume_errors_total",
+		Help: "Falhas de consumo por tópico: retry (tentativa com erro), dead_letter (enviada para a DLQ) ou session (sessão do grupo).",
+	}, []string{"topic", "reason"})
+
+	KafkaConsumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_consumer_lag",
+		Help: "Mensagens da partição ainda não recebidas pelo consumer group.",
+	}, []string{"topic", "group", "partition"})
+
+	EventHandlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "event_handler_duration_seconds",
+		Help:    "Duração dos handlers do dispatcher de eventos, por evento e resultado.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"event", "status"})
+)
+
+// Handler expõe as métricas no formato do Prometheus, para a rota /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
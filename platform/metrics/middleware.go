@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// HTTP registra a duração e o status de cada requisição, rotulados pelo padrão da rota
+// do chi (ex.: /cursos/{id}), para não criar uma série por id.
+func HTTP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		HTTPRequests.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+		HTTPRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// EventHandler registra a duração de cada execução de handler do dispatcher.
+func EventHandler() event_dispatcher.Middleware {
+	return func(next event_dispatcher.HandlerFunc) event_dispatcher.HandlerFunc {
+		return func(ctx context.Context, event event_dispatcher.EventInterface) error {
+			start := time.Now()
+			err := next(ctx, event)
+			status := "ok"
+			if err != nil {
+				status = "error"
+			}
+			EventHandlerDuration.WithLabelValues(event.GetName(), status).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
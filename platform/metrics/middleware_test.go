@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct{}
+
+func (testEvent) GetID() string            { return "1" }
+func (testEvent) GetName() string          { return "TestEvent" }
+func (testEvent) GetDateTime() time.Time   { return time.Now() }
+func (testEvent) GetAggregateID() string   { return "a" }
+func (testEvent) GetCorrelationID() string { return "c" }
+func (testEvent) GetRequestID() string     { return "r" }
+func (testEvent) GetPayload() interface{}  { return nil }
+
+func TestHTTP_RotuloPeloPadraoDaRota(t *testing.T) {
+	api := chi.NewRouter()
+	api.Use(HTTP)
+	api.Get("/cursos/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	root := chi.NewRouter()
+	root.Mount("/", api)
+
+	for _, id := range []string{"1", "2", "3"} {
+		root.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/cursos/"+id, nil))
+	}
+	api.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/nao-existe", nil))
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(HTTPRequests.WithLabelValues("GET", "/cursos/{id}", "404")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(HTTPRequests.WithLabelValues("GET", "unmatched", "404")))
+}
+
+func TestEventHandler_RegistraDuracaoPorResultado(t *testing.T) {
+	ok := EventHandler()(func(ctx context.Context, event event_dispatcher.EventInterface) error { return nil })
+	falha := EventHandler()(func(ctx context.Context, event event_dispatcher.EventInterface) error { return errors.New("x") })
+
+	ok(context.Background(), testEvent{})
+	falha(context.Background(), testEvent{})
+	falha(context.Background(), testEvent{})
+
+	// uma série por resultado: ok e error
+	assert.Equal(t, 2, testutil.CollectAndCount(EventHandlerDuration))
+}
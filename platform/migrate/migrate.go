@@ -0,0 +1,274 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ggialluisi/nebula-back/platform/logging"
+)
+
+// Migration é uma versão do schema, com o SQL para aplicá-la (Up) e desfazê-la (Down).
+type Migration struct {
+	Version int64
+	Name    string
+	// Dialect restringe a migração a um banco (ex.: "postgres"); vazio vale para todos.
+	Dialect string
+	Up      string
+	Down    string
+}
+
+// Status é a situação de uma migração no banco.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// ErrNoDown indica uma migração sem o arquivo .down.sql, que não pode ser desfeita.
+var ErrNoDown = errors.New("migração sem script de down")
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)(?:\.([a-z0-9]+))?\.(up|down)\.sql$`)
+
+// Load lê as migrações de fsys (normalmente um embed.FS), nomeadas como
+// 0001_nome.up.sql e 0001_nome.down.sql, ordenadas pela versão. Migrações que usam
+// recursos de um banco específico levam o dialeto no nome: 0002_nome.postgres.up.sql.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		m := fileName.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("nome de migração inválido: %s (esperado 0001_nome.up.sql)", entry.Name())
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2], Dialect: m[3]}
+			byVersion[version] = migration
+		}
+		if migration.Name != m[2] || migration.Dialect != m[3] {
+			return nil, fmt.Errorf("versão %d usada por duas migrações: %s e %s", version, migration.Name, entry.Name())
+		}
+		if m[4] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("migração %d_%s sem script de up", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Locker obtém um lock exclusivo na conexão usada pelas migrações, para que só uma
+// réplica migre o banco por vez; as demais esperam e encontram tudo aplicado.
+type Locker func(ctx context.Context, conn *sql.Conn) (unlock func() error, err error)
+
+// PostgresLock usa um advisory lock do PostgreSQL identificado por key (ex.: o nome do
+// serviço). O lock é da sessão: liberado no unlock ou quando a conexão cai.
+func PostgresLock(key string) Locker {
+	h := fnv.New64a()
+	h.Write([]byte("migrate:" + key))
+	id := int64(h.Sum64())
+	return func(ctx context.Context, conn *sql.Conn) (func() error, error) {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", id); err != nil {
+			return nil, fmt.Errorf("advisory lock: %w", err)
+		}
+		return func() error {
+			_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", id)
+			return err
+		}, nil
+	}
+}
+
+// NoLock não trava nada; para bancos sem advisory lock, como o SQLite dos testes.
+func NoLock(context.Context, *sql.Conn) (func() error, error) {
+	return func() error { return nil }, nil
+}
+
+// Migrator aplica e desfaz as migrações, registrando as versões aplicadas na tabela
+// schema_migrations. Cada migração roda na sua própria transação. As migrações de outro
+// dialeto são ignoradas, o que permite rodar as demais no SQLite dos testes.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	lock       Locker
+}
+
+func New(db *sql.DB, dialect string, fsys fs.FS, lock Locker) (*Migrator, error) {
+	all, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	var migrations []Migration
+	for _, migration := range all {
+		if migration.Dialect == "" || migration.Dialect == dialect {
+			migrations = append(migrations, migration)
+		}
+	}
+	if lock == nil {
+		lock = NoLock
+	}
+	return &Migrator{db: db, migrations: migrations, lock: lock}, nil
+}
+
+// Up aplica, em ordem, todas as migrações ainda não aplicadas e devolve quantas foram.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.locked(ctx, func(conn *sql.Conn, done map[int64]time.Time) error {
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			start := time.Now()
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx,
+					"INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+					migration.Version, migration.Name, time.Now().UTC())
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migração %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied++
+			slog.InfoContext(ctx, "migração aplicada",
+				"version", migration.Version, "name", migration.Name, "duration", time.Since(start))
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down desfaz as últimas steps migrações aplicadas, da mais recente para a mais antiga,
+// e devolve quantas foram desfeitas.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.locked(ctx, func(conn *sql.Conn, done map[int64]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if strings.TrimSpace(migration.Down) == "" {
+				return fmt.Errorf("migração %d_%s: %w", migration.Version, migration.Name, ErrNoDown)
+			}
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migração %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted++
+			slog.InfoContext(ctx, "migração desfeita", "version", migration.Version, "name", migration.Name)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lista as migrações conhecidas e se cada uma já foi aplicada.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.locked(ctx, func(conn *sql.Conn, done map[int64]time.Time) error {
+		for _, migration := range m.migrations {
+			appliedAt, ok := done[migration.Version]
+			statuses = append(statuses, Status{Migration: migration, Applied: ok, AppliedAt: appliedAt})
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// locked reserva uma conexão, obtém o lock nela, garante a tabela schema_migrations
+// e chama fn com as versões já aplicadas.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn, done map[int64]time.Time) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	unlock, err := m.lock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			slog.WarnContext(ctx, "erro ao liberar o lock das migrações", logging.Err(err))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name varchar(200) NOT NULL,
+		applied_at timestamp NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("schema_migrations: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return fmt.Errorf("schema_migrations: %w", err)
+	}
+	done := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		done[version] = appliedAt
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return fn(conn, done)
+}
+
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,148 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFS = fstest.MapFS{
+	"0001_cria_pessoas.up.sql":   {Data: []byte("CREATE TABLE pessoas (id text PRIMARY KEY, nome text);")},
+	"0001_cria_pessoas.down.sql": {Data: []byte("DROP TABLE pessoas;")},
+	"0002_documento.up.sql": {Data: []byte(`ALTER TABLE pessoas ADD COLUMN documento text;
+CREATE UNIQUE INDEX idx_pessoas_documento ON pessoas (documento);`)},
+	"0002_documento.down.sql": {Data: []byte(`DROP INDEX idx_pessoas_documento;
+ALTER TABLE pessoas DROP COLUMN documento;`)},
+}
+
+func newTestMigrator(t *testing.T, fsys fstest.MapFS) (*Migrator, *sql.DB) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	// memória compartilhada só existe numa conexão
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := New(db, "sqlite", fsys, NoLock)
+	require.NoError(t, err)
+	return m, db
+}
+
+func TestLoad_OrdenaPelaVersao(t *testing.T) {
+	migrations, err := Load(testFS)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "cria_pessoas", migrations[0].Name)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.NotEmpty(t, migrations[1].Down)
+}
+
+func TestNew_IgnoraMigracoesDeOutroDialeto(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_cria_pessoas.up.sql":     testFS["0001_cria_pessoas.up.sql"],
+		"0002_busca.postgres.up.sql":   {Data: []byte("ALTER TABLE pessoas ADD COLUMN busca tsvector;")},
+		"0002_busca.postgres.down.sql": {Data: []byte("ALTER TABLE pessoas DROP COLUMN busca;")},
+		"0003_documento.up.sql":        {Data: []byte("ALTER TABLE pessoas ADD COLUMN documento text;")},
+	}
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	assert.Equal(t, "postgres", migrations[1].Dialect)
+
+	m, _ := newTestMigrator(t, fsys)
+	applied, err := m.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, int64(3), statuses[1].Version)
+}
+
+func TestLoad_RejeitaNomeInvalido(t *testing.T) {
+	_, err := Load(fstest.MapFS{"cria_pessoas.sql": {Data: []byte("SELECT 1;")}})
+	assert.Error(t, err)
+
+	_, err = Load(fstest.MapFS{"0001_cria_pessoas.down.sql": {Data: []byte("DROP TABLE pessoas;")}})
+	assert.Error(t, err)
+}
+
+func TestUp_AplicaPendentesUmaVez(t *testing.T) {
+	m, db := newTestMigrator(t, testFS)
+	ctx := context.Background()
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+
+	_, err = db.Exec("INSERT INTO pessoas (id, nome, documento) VALUES ('1', 'Ana', '123')")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO pessoas (id, nome, documento) VALUES ('2', 'Bia', '123')")
+	assert.Error(t, err, "índice único da migração 0002")
+
+	applied, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.True(t, statuses[1].Applied)
+	assert.False(t, statuses[1].AppliedAt.IsZero())
+}
+
+func TestUp_FalhaDesfazAMigracaoInteira(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_cria_pessoas.up.sql": testFS["0001_cria_pessoas.up.sql"],
+		"0002_quebrada.up.sql":     {Data: []byte("CREATE TABLE emails (id text);\nSELECT * FROM nao_existe;")},
+	}
+	m, db := newTestMigrator(t, fsys)
+
+	applied, err := m.Up(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, applied)
+
+	var n int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM sqlite_master WHERE name = 'emails'").Scan(&n))
+	assert.Zero(t, n)
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM schema_migrations").Scan(&n))
+	assert.Equal(t, 1, n)
+}
+
+func TestDown_DesfazAsUltimas(t *testing.T) {
+	m, db := newTestMigrator(t, testFS)
+	ctx := context.Background()
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+
+	reverted, err := m.Down(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reverted)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+	_, err = db.Exec("INSERT INTO pessoas (id, nome) VALUES ('1', 'Ana')")
+	require.NoError(t, err)
+
+	reverted, err = m.Down(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reverted)
+}
+
+func TestDown_SemScriptDeDown(t *testing.T) {
+	m, _ := newTestMigrator(t, fstest.MapFS{"0001_cria_pessoas.up.sql": testFS["0001_cria_pessoas.up.sql"]})
+	ctx := context.Background()
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+
+	_, err = m.Down(ctx, 1)
+	assert.ErrorIs(t, err, ErrNoDown)
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper escapa os curingas do LIKE no valor procurado.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Where aplica os filtros de spec em db.
+func Where(db *gorm.DB, spec Spec) *gorm.DB {
+	for _, f := range spec.Filters {
+		column := clause.Column{Name: f.Column}
+		switch f.Op {
+		case Contains:
+			db = db.Where(clause.Expr{
+				SQL:  `LOWER(?) LIKE ? ESCAPE '\'`,
+				Vars: []interface{}{column, "%" + strings.ToLower(likeEscaper.Replace(f.Value)) + "%"},
+			})
+		default:
+			db = db.Where(clause.Eq{Column: column, Value: f.Value})
+		}
+	}
+	return db
+}
+
+// Find carrega a página de T descrita por spec a partir de db, que pode trazer Preloads
+// e condições próprias. O total considera os filtros; a ordenação desempata pelo id,
+// para a paginação não repetir nem pular registros.
+func Find[T any](db *gorm.DB, spec Spec) (Page[T], error) {
+	db = Where(db.Model(new(T)), spec)
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return Page[T]{}, err
+	}
+
+	q := db.Session(&gorm.Session{})
+	byID := false
+	for _, s := range spec.Sort {
+		q = q.Order(clause.OrderByColumn{Column: clause.Column{Name: s.Column}, Desc: s.Desc})
+		byID = byID || s.Column == "id"
+	}
+	if !byID {
+		q = q.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}})
+	}
+	if spec.Limit > 0 {
+		q = q.Limit(spec.Limit).Offset(spec.Offset())
+	}
+
+	var items []T
+	if err := q.Find(&items).Error; err != nil {
+		return Page[T]{}, err
+	}
+	return NewPage(items, total, spec), nil
+}
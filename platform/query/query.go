@@ -0,0 +1,179 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Operator é a forma de comparar um filtro com a coluna.
+type Operator string
+
+const (
+	// Equals compara pelo valor exato.
+	Equals Operator = "eq"
+	// Contains procura o valor em qualquer parte do texto, sem diferenciar maiúsculas.
+	Contains Operator = "contains"
+)
+
+// ErrInvalid indica parâmetros de listagem inválidos (campo de ordenação desconhecido,
+// page ou limit fora do intervalo); a API responde 400.
+var ErrInvalid = errors.New("parâmetros de listagem inválidos")
+
+// Field é um campo exposto por uma listagem e a coluna correspondente.
+type Field struct {
+	Column string
+	// Filter é o operador usado quando o campo vem na query string; vazio não filtra.
+	Filter Operator
+	// Sortable permite usar o campo em sort.
+	Sortable bool
+}
+
+// Schema define os campos aceitos por uma listagem. Só as colunas declaradas aqui chegam
+// ao SQL: os nomes da requisição são apenas chaves deste mapa.
+type Schema struct {
+	Fields map[string]Field
+	// DefaultSort vale quando a requisição não informa sort.
+	DefaultSort []Sort
+	// DefaultLimit vale quando a requisição não informa limit (padrão 50).
+	DefaultLimit int
+	// MaxLimit é o maior limit aceito (padrão 500).
+	MaxLimit int
+}
+
+// Filter é uma condição sobre uma coluna.
+type Filter struct {
+	Column string
+	Op     Operator
+	Value  string
+}
+
+// Sort é um critério de ordenação.
+type Sort struct {
+	Column string
+	Desc   bool
+}
+
+// Spec descreve uma listagem: filtros, ordenação e a página pedida. Limit zero traz
+// todos os registros numa única página.
+type Spec struct {
+	Filters []Filter
+	Sort    []Sort
+	Page    int
+	Limit   int
+}
+
+// Offset é a quantidade de registros anteriores à página.
+func (s Spec) Offset() int {
+	if s.Page <= 1 || s.Limit <= 0 {
+		return 0
+	}
+	return (s.Page - 1) * s.Limit
+}
+
+// Parse lê a query string de uma listagem:
+//
+//	?page=2&limit=20&sort=-created_at,nome&status_aluno=ATIVO&nome=java
+//
+// sort aceita vários campos separados por vírgula, com "-" para ordem decrescente;
+// "asc" e "desc" sozinhos ordenam por created_at, como antes. Cada campo do Schema com
+// Filter vira um filtro quando presente na query string.
+func (s Schema) Parse(values url.Values) (Spec, error) {
+	spec := Spec{Page: 1, Limit: s.DefaultLimit}
+	if spec.Limit <= 0 {
+		spec.Limit = 50
+	}
+	maxLimit := s.MaxLimit
+	if maxLimit <= 0 {
+		maxLimit = 500
+	}
+
+	if v := values.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return Spec{}, fmt.Errorf("%w: page deve ser um inteiro maior que zero", ErrInvalid)
+		}
+		spec.Page = page
+	}
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return Spec{}, fmt.Errorf("%w: limit deve estar entre 1 e %d", ErrInvalid, maxLimit)
+		}
+		spec.Limit = limit
+	}
+
+	sort, err := s.parseSort(values.Get("sort"))
+	if err != nil {
+		return Spec{}, err
+	}
+	spec.Sort = sort
+
+	for name, field := range s.Fields {
+		if field.Filter == "" {
+			continue
+		}
+		if v := strings.TrimSpace(values.Get(name)); v != "" {
+			spec.Filters = append(spec.Filters, Filter{Column: field.Column, Op: field.Filter, Value: v})
+		}
+	}
+	return spec, nil
+}
+
+func (s Schema) parseSort(raw string) ([]Sort, error) {
+	switch raw {
+	case "":
+		return append([]Sort(nil), s.DefaultSort...), nil
+	case "asc":
+		return []Sort{{Column: "created_at"}}, nil
+	case "desc":
+		return []Sort{{Column: "created_at", Desc: true}}, nil
+	}
+
+	var sort []Sort
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+		field, ok := s.Fields[name]
+		if !ok || !field.Sortable {
+			return nil, fmt.Errorf("%w: não é possível ordenar por %q", ErrInvalid, name)
+		}
+		sort = append(sort, Sort{Column: field.Column, Desc: desc})
+	}
+	return sort, nil
+}
+
+// Page é o envelope das listagens: os itens da página, o total de registros que atendem
+// aos filtros e o número da próxima página (null na última).
+type Page[T any] struct {
+	Items []T   `json:"items"`
+	Total int64 `json:"total"`
+	Page  int   `json:"page"`
+	Limit int   `json:"limit"`
+	Next  *int  `json:"next"`
+}
+
+// NewPage monta o envelope de items, a página pedida em spec.
+func NewPage[T any](items []T, total int64, spec Spec) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	page := Page[T]{Items: items, Total: total, Page: max(spec.Page, 1), Limit: spec.Limit}
+	if spec.Limit > 0 && int64(spec.Offset()+len(items)) < total {
+		next := page.Page + 1
+		page.Next = &next
+	}
+	return page
+}
+
+// Map converte os itens da página, mantendo a paginação (ex.: entidades para DTOs).
+func Map[T, U any](page Page[T], fn func(T) U) Page[U] {
+	items := make([]U, 0, len(page.Items))
+	for _, item := range page.Items {
+		items = append(items, fn(item))
+	}
+	return Page[U]{Items: items, Total: page.Total, Page: page.Page, Limit: page.Limit, Next: page.Next}
+}
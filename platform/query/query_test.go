@@ -0,0 +1,149 @@
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var cursoSchema = Schema{
+	Fields: map[string]Field{
+		"nome":       {Column: "nome", Filter: Contains, Sortable: true},
+		"nivel":      {Column: "nivel", Filter: Equals, Sortable: true},
+		"created_at": {Column: "created_at", Sortable: true},
+	},
+	DefaultSort:  []Sort{{Column: "created_at"}},
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+type testModulo struct {
+	ID          int
+	TestCursoID int
+	Nome        string
+}
+
+type testCurso struct {
+	ID        int
+	CreatedAt time.Time
+	Nome      string
+	Nivel     string
+	Modulos   []testModulo `gorm:"foreignKey:TestCursoID"`
+}
+
+func TestParse_PadroesEFiltros(t *testing.T) {
+	spec, err := cursoSchema.Parse(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, Spec{Page: 1, Limit: 10, Sort: []Sort{{Column: "created_at"}}}, spec)
+
+	spec, err = cursoSchema.Parse(url.Values{
+		"page": {"3"}, "limit": {"20"}, "sort": {"-nivel,nome"},
+		"nome": {"solidity"}, "nivel": {"basico"}, "outro": {"ignorado"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, spec.Page)
+	assert.Equal(t, 20, spec.Limit)
+	assert.Equal(t, 40, spec.Offset())
+	assert.Equal(t, []Sort{{Column: "nivel", Desc: true}, {Column: "nome"}}, spec.Sort)
+	assert.ElementsMatch(t, []Filter{
+		{Column: "nome", Op: Contains, Value: "solidity"},
+		{Column: "nivel", Op: Equals, Value: "basico"},
+	}, spec.Filters)
+}
+
+func TestParse_SortLegado(t *testing.T) {
+	spec, err := cursoSchema.Parse(url.Values{"sort": {"desc"}})
+	require.NoError(t, err)
+	assert.Equal(t, []Sort{{Column: "created_at", Desc: true}}, spec.Sort)
+}
+
+func TestParse_Invalidos(t *testing.T) {
+	for _, values := range []url.Values{
+		{"sort": {"senha"}},
+		{"page": {"0"}},
+		{"limit": {"abc"}},
+		{"limit": {"101"}},
+	} {
+		_, err := cursoSchema.Parse(values)
+		assert.ErrorIs(t, err, ErrInvalid, "%v", values)
+	}
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&testCurso{}, &testModulo{}))
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 25; i++ {
+		nivel := "basico"
+		if i%2 == 0 {
+			nivel = "avancado"
+		}
+		curso := testCurso{ID: i, CreatedAt: base.Add(time.Duration(i) * time.Hour), Nome: fmt.Sprintf("Curso %02d", i), Nivel: nivel,
+			Modulos: []testModulo{{ID: i, Nome: "Introdução"}}}
+		require.NoError(t, db.Create(&curso).Error)
+	}
+	require.NoError(t, db.Create(&testCurso{ID: 26, CreatedAt: base, Nome: "100% Solidity", Nivel: "avancado"}).Error)
+	return db
+}
+
+func TestFind_PaginaComTotalENext(t *testing.T) {
+	db := newTestDB(t)
+
+	page, err := Find[testCurso](db.Preload("Modulos"), Spec{Page: 1, Limit: 10, Sort: []Sort{{Column: "created_at"}}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(26), page.Total)
+	require.Len(t, page.Items, 10)
+	assert.Equal(t, "100% Solidity", page.Items[0].Nome)
+	assert.Len(t, page.Items[1].Modulos, 1)
+	require.NotNil(t, page.Next)
+	assert.Equal(t, 2, *page.Next)
+
+	page, err = Find[testCurso](db, Spec{Page: 3, Limit: 10, Sort: []Sort{{Column: "created_at"}}})
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 6)
+	assert.Nil(t, page.Next)
+}
+
+func TestFind_FiltrosEOrdenacaoMultipla(t *testing.T) {
+	db := newTestDB(t)
+
+	page, err := Find[testCurso](db, Spec{
+		Filters: []Filter{{Column: "nivel", Op: Equals, Value: "basico"}},
+		Sort:    []Sort{{Column: "nome", Desc: true}},
+		Page:    1,
+		Limit:   5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), page.Total)
+	assert.Equal(t, "Curso 25", page.Items[0].Nome)
+	assert.Equal(t, "Curso 23", page.Items[1].Nome)
+
+	// os curingas do LIKE no valor são literais
+	page, err = Find[testCurso](db, Spec{Filters: []Filter{{Column: "nome", Op: Contains, Value: "0% SOL"}}})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, 26, page.Items[0].ID)
+
+	page, err = Find[testCurso](db, Spec{Filters: []Filter{{Column: "nome", Op: Contains, Value: "_"}}})
+	require.NoError(t, err)
+	assert.Empty(t, page.Items)
+	assert.NotNil(t, page.Items)
+}
+
+func TestMap_MantemPaginacao(t *testing.T) {
+	next := 2
+	page := Map(Page[int]{Items: []int{1, 2}, Total: 4, Page: 1, Limit: 2, Next: &next}, func(i int) string {
+		return fmt.Sprint(i * 10)
+	})
+	assert.Equal(t, []string{"10", "20"}, page.Items)
+	assert.Equal(t, int64(4), page.Total)
+	assert.Equal(t, &next, page.Next)
+}
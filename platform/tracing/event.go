@@ -0,0 +1,25 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/ggialluisi/nebula-back/platform/event_dispatcher"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// EventHandler é o middleware do dispatcher que cria um span por execução de handler,
+// filho do span de quem disparou o evento.
+func EventHandler() event_dispatcher.Middleware {
+	return func(next event_dispatcher.HandlerFunc) event_dispatcher.HandlerFunc {
+		return func(ctx context.Context, event event_dispatcher.EventInterface) error {
+			ctx, span := Start(ctx, "event "+event.GetName())
+			span.SetAttributes(
+				attribute.String("event.id", event.GetID()),
+				attribute.String("event.correlation_id", event.GetCorrelationID()),
+			)
+			err := next(ctx, event)
+			End(span, err)
+			return err
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"errors"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// GormPlugin cria um span por operação do GORM executada com um contexto que já faz
+// parte de um trace (db.WithContext(ctx)). Operações sem trace em andamento, como as do
+// relay do outbox, não geram spans soltos. A consulta registrada é a
+// parametrizada, sem os valores.
+func GormPlugin() gorm.Plugin {
+	return gormPlugin{}
+}
+
+type gormPlugin struct{}
+
+func (gormPlugin) Name() string {
+	return "tracing"
+}
+
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", beforeGorm("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", afterGorm),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", beforeGorm("select")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", afterGorm),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", beforeGorm("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", afterGorm),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", beforeGorm("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", afterGorm),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", beforeGorm("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", afterGorm),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", beforeGorm("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", afterGorm),
+	)
+}
+
+func beforeGorm(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		ctx := tx.Statement.Context
+		if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+			return
+		}
+		_, span := Start(ctx, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemKey.String(tx.Dialector.Name()),
+				semconv.DBOperationName(operation),
+			),
+		)
+		tx.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func afterGorm(tx *gorm.DB) {
+	v, ok := tx.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+	tx.InstanceSet(gormSpanKey, nil)
+
+	if tx.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(tx.Statement.Table))
+	}
+	if sql := tx.Statement.SQL.String(); sql != "" {
+		span.SetAttributes(semconv.DBQueryText(sql))
+	}
+
+	err := tx.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	End(span, err)
+}
@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartProducer inicia o span de publicação de uma mensagem no tópico.
+func StartProducer(ctx context.Context, topic string) (context.Context, trace.Span) {
+	return Start(ctx, topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingOperationTypePublish,
+		),
+	)
+}
+
+// Inject devolve os headers de propagação (traceparent, tracestate, baggage) do contexto,
+// para serem enviados junto com a mensagem.
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// StartConsumer continua o trace recebido nos headers da mensagem e inicia o span do
+// processamento dela; o trabalho feito pelo handler fica no mesmo trace da publicação.
+func StartConsumer(ctx context.Context, topic string, headers map[string]string) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+	return Start(ctx, topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingOperationTypeDeliver,
+		),
+	)
+}
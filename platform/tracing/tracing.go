@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName identifica a instrumentação da plataforma nos spans.
+const ScopeName = "github.com/ggialluisi/nebula-back/platform/tracing"
+
+// Exportadores aceitos em OTEL_TRACES_EXPORTER.
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+// HeaderTraceParent é o header W3C com o trace e o span de origem.
+const HeaderTraceParent = "traceparent"
+
+// Setup configura o TracerProvider e o propagador W3C (traceparent e baggage) globais.
+// O exportador vem de OTEL_TRACES_EXPORTER: otlp (OTLP/HTTP, configurado pelas variáveis
+// OTEL_EXPORTER_OTLP_*), stdout (ou console) ou none. Sem a variável, usa otlp quando
+// OTEL_EXPORTER_OTLP_ENDPOINT está definido e none caso contrário; mesmo sem exportador o
+// contexto do trace recebido continua sendo propagado. OTEL_SERVICE_NAME e
+// OTEL_RESOURCE_ATTRIBUTES sobrescrevem o nome do serviço e os atributos do resource.
+// A função devolvida envia os spans pendentes e encerra o provider.
+func Setup(ctx context.Context, service string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, err := newExporter(ctx, ExporterFromEnv(), os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(service)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("resource do tracing: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// ExporterFromEnv devolve o exportador escolhido por OTEL_TRACES_EXPORTER.
+func ExporterFromEnv() string {
+	switch v := strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER"))); v {
+	case "":
+		if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" {
+			return ExporterOTLP
+		}
+		return ExporterNone
+	case "console":
+		return ExporterStdout
+	default:
+		return v
+	}
+}
+
+func newExporter(ctx context.Context, name string, stdout io.Writer) (sdktrace.SpanExporter, error) {
+	switch name {
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("exportador OTLP: %w", err)
+		}
+		return exporter, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(stdout))
+	case ExporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("OTEL_TRACES_EXPORTER inválido: %q (use otlp, stdout ou none)", name)
+	}
+}
+
+// Start inicia um span com o tracer da plataforma.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(ScopeName).Start(ctx, name, opts...)
+}
+
+// End registra o erro no span, se houver, e o finaliza.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent devolve o traceparent W3C do span do contexto, ou "" se não houver trace.
+// Usado para guardar a origem de um trabalho que será concluído depois (ex.: outbox).
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get(HeaderTraceParent)
+}
+
+// WithTraceParent devolve um contexto que continua o trace do traceparent informado.
+func WithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{HeaderTraceParent: traceParent})
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupTestTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return exporter
+}
+
+func TestHTTP_NomeiaPelaRotaEContinuaTraceRecebido(t *testing.T) {
+	exporter := setupTestTracing(t)
+	api := chi.NewRouter()
+	api.Use(HTTP)
+	api.Get("/pessoas/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	root := chi.NewRouter()
+	root.Mount("/", api)
+
+	req := httptest.NewRequest(http.MethodGet, "/pessoas/42", nil)
+	req.Header.Set(HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	root.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /pessoas/{id}", spans[0].Name)
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
+
+func TestKafka_ConsumidorContinuaTraceDaPublicacao(t *testing.T) {
+	exporter := setupTestTracing(t)
+
+	ctx, span := StartProducer(context.Background(), "pessoa.saved")
+	headers := Inject(ctx)
+	span.End()
+	require.NotEmpty(t, headers[HeaderTraceParent])
+
+	_, consumer := StartConsumer(context.Background(), "pessoa.saved", headers)
+	consumer.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "pessoa.saved publish", spans[0].Name)
+	assert.Equal(t, "pessoa.saved process", spans[1].Name)
+	assert.Equal(t, spans[0].SpanContext.TraceID(), spans[1].SpanContext.TraceID())
+	assert.Equal(t, spans[0].SpanContext.SpanID(), spans[1].Parent.SpanID())
+}
+
+func TestTraceParent_IdaEVolta(t *testing.T) {
+	setupTestTracing(t)
+	assert.Empty(t, TraceParent(context.Background()))
+	assert.Equal(t, context.Background(), WithTraceParent(context.Background(), ""))
+
+	ctx, span := Start(context.Background(), "origem")
+	defer span.End()
+
+	restored := WithTraceParent(context.Background(), TraceParent(ctx))
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(restored).TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), trace.SpanContextFromContext(restored).SpanID())
+}
+
+func TestExporterFromEnv(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	assert.Equal(t, ExporterNone, ExporterFromEnv())
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://otel-collector:4318")
+	assert.Equal(t, ExporterOTLP, ExporterFromEnv())
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "console")
+	assert.Equal(t, ExporterStdout, ExporterFromEnv())
+
+	_, err := newExporter(context.Background(), "zipkin", nil)
+	assert.Error(t, err)
+}
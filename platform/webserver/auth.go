@@ -0,0 +1,38 @@
+package webserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// RequireRoles bloqueia a rota para usuários que não possuem um dos papéis informados.
+// roleFromContext lê o papel do usuário autenticado e retorna false quando não há
+// usuário (token ausente ou inválido). Deve ser usado depois de jwtauth.Verifier e
+// jwtauth.Authenticator.
+func RequireRoles[R comparable](roleFromContext func(ctx context.Context) (R, bool), roles ...R) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, ok := roleFromContext(r.Context())
+			if !ok {
+				writeAuthError(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+			for _, allowed := range roles {
+				if role == allowed {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			writeAuthError(w, http.StatusForbidden, "forbidden")
+		})
+	}
+}
+
+func writeAuthError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Message string `json:"message"`
+	}{message})
+}
@@ -0,0 +1,48 @@
+package webserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type papelTeste string
+
+type papelKey struct{}
+
+func papelDoContexto(ctx context.Context) (papelTeste, bool) {
+	papel, ok := ctx.Value(papelKey{}).(papelTeste)
+	return papel, ok
+}
+
+func TestRequireRoles(t *testing.T) {
+	handler := RequireRoles(papelDoContexto, "admin", "instrutor")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	casos := []struct {
+		nome   string
+		ctx    context.Context
+		status int
+		body   string
+	}{
+		{"sem usuário", context.Background(), http.StatusUnauthorized, `{"message":"unauthorized"}`},
+		{"papel sem permissão", context.WithValue(context.Background(), papelKey{}, papelTeste("aluno")), http.StatusForbidden, `{"message":"forbidden"}`},
+		{"papel permitido", context.WithValue(context.Background(), papelKey{}, papelTeste("instrutor")), http.StatusNoContent, ""},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(caso.ctx))
+
+			assert.Equal(t, caso.status, rec.Code)
+			if caso.body != "" {
+				assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+				assert.JSONEq(t, caso.body, rec.Body.String())
+			}
+		})
+	}
+}
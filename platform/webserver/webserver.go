@@ -0,0 +1,92 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ggialluisi/nebula-back/platform/logging"
+	"github.com/go-chi/chi/v5"
+)
+
+type WebServer struct {
+	Router        chi.Router
+	Handlers      map[string]http.HandlerFunc
+	WebServerPort string
+}
+
+func NewWebServer(serverPort string) *WebServer {
+	return &WebServer{
+		Router:        chi.NewRouter(),
+		Handlers:      make(map[string]http.HandlerFunc),
+		WebServerPort: serverPort,
+	}
+}
+
+func (s *WebServer) AddHandler(path string, handler http.HandlerFunc) {
+	s.Handlers[path] = handler
+}
+
+// loop through the handlers and add them to the router
+// register middleware request id + access log
+// start the server
+func (s *WebServer) Start() error {
+	s.Router.Use(logging.RequestID, logging.AccessLog)
+	for path, handler := range s.Handlers {
+		s.Router.Handle(path, handler)
+	}
+	return NewServer(s.WebServerPort, s.Router).ListenAndServe()
+}
+
+// NewServer cria o servidor HTTP dos serviços, com timeouts que evitam conexões
+// penduradas por clientes lentos.
+func NewServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+}
+
+// Serve atende requisições até o contexto ser cancelado e então para de aceitar
+// conexões, aguardando até drainTimeout pelas requisições em andamento. O início do
+// servidor só é registrado no log depois que a porta foi aberta.
+func Serve(ctx context.Context, srv *http.Server, drainTimeout time.Duration) error {
+	addr := srv.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	slog.Info("servidor HTTP iniciado", "addr", ln.Addr().String())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
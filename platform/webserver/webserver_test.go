@@ -0,0 +1,62 @@
+package webserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_DrenaRequisicoesEmAndamento(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	iniciou := make(chan struct{})
+	srv := NewServer(addr, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(iniciou)
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- Serve(ctx, srv, time.Second) }()
+
+	respCh := make(chan string, 1)
+	go func() {
+		for {
+			resp, err := http.Get("http://" + addr)
+			if err != nil {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			respCh <- string(body)
+			return
+		}
+	}()
+
+	<-iniciou
+	cancel()
+
+	assert.Equal(t, "ok", <-respCh)
+	assert.NoError(t, <-serveErr)
+}
+
+func TestServe_RetornaErroSePortaOcupada(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	srv := NewServer(ln.Addr().String(), http.NotFoundHandler())
+	err = Serve(context.Background(), srv, time.Second)
+	assert.Error(t, err)
+}